@@ -28,6 +28,7 @@ import (
 
 type syncer interface {
 	SyncSubscribedContent(context.Context, ...*activity.Subscription) (syncing.SyncResult, error)
+	WatchProgress() *syncing.ProgressWatcher
 }
 
 // Server implements the Activity gRPC API.
@@ -37,14 +38,19 @@ type Server struct {
 	clean     *cleanup.Stack
 	log       *zap.Logger
 	syncer    syncer
+	blobs     blobWatcher
+	webhooks  *webhookDispatcher
 }
 
 var resourcePattern = regexp.MustCompile(`^hm://[acdg]/[a-zA-Z0-9]+$`)
 
 // NewServer creates a new Server.
-func NewServer(db *sqlitex.Pool, log *zap.Logger, clean *cleanup.Stack) *Server {
+// Blob watcher is used to stream the events as soon as new blobs are stored.
+func NewServer(db *sqlitex.Pool, blobs blobWatcher, log *zap.Logger, clean *cleanup.Stack) *Server {
 	return &Server{
 		db:        db,
+		blobs:     blobs,
+		webhooks:  newWebhookDispatcher(db, log),
 		startTime: time.Now(),
 		clean:     clean,
 		log:       log,
@@ -61,6 +67,7 @@ func (srv *Server) SetSyncer(sync syncer) {
 func (srv *Server) RegisterServer(rpc grpc.ServiceRegistrar) {
 	activity.RegisterActivityFeedServer(rpc, srv)
 	activity.RegisterSubscriptionsServer(rpc, srv)
+	activity.RegisterWebhooksServer(rpc, srv)
 }
 
 // ListEvents list all the events seen locally.
@@ -77,30 +84,70 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 	}
 	defer cancel()
 
-	var events []*activity.Event
+	if req.PageSize <= 0 {
+		req.PageSize = 30
+	}
+
+	filtersStr, linksStr, err := eventFilters(req.FilterUsers, req.FilterEventType, req.FilterResource, req.AddLinkedResource)
+	if err != nil {
+		return nil, err
+	}
+
+	pageTokenStr := storage.BlobsID.String() + " <= :idx AND (" + storage.ResourcesIRI.String() + " IS NULL) AND " + storage.BlobsSize.String() + ">0 ORDER BY " + storage.BlobsID.String() + " desc limit :page_size"
+
+	var (
+		events     []*activity.Event
+		lastBlobID int64
+	)
+	err = sqlitex.Exec(conn, dqb.Str(eventsQuery(filtersStr, linksStr, pageTokenStr))(), func(stmt *sqlite.Stmt) error {
+		var event *activity.Event
+		lastBlobID, event = eventFromRow(stmt)
+		events = append(events, event)
+		return nil
+	}, cursorBlobID, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("Problem collecting activity feed, Probably no feed or token out of range: %w", err)
+	}
+
+	var nextPageToken string
+	if lastBlobID != 0 && int(req.PageSize) == len(events) {
+		nextPageToken, err = apiutil.EncodePageToken(lastBlobID-1, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode next page token: %w", err)
+		}
+	}
+
+	return &activity.ListEventsResponse{
+		Events:        events,
+		NextPageToken: nextPageToken,
+	}, err
+}
 
-	var filtersStr string
-	if len(req.FilterUsers) > 0 {
+// eventFilters builds the SQL conditions for the event filters.
+// Filters are ANDed together, and linked resources are ORed with the rest of the filters.
+// Both return values are either empty or end with "AND ", to prepend the rest of the conditions.
+func eventFilters(users, eventTypes, resources, linkedResources []string) (filtersStr, linksStr string, err error) {
+	if len(users) > 0 {
 		filtersStr = storage.PublicKeysPrincipal.String() + " in ("
-		for i, user := range req.FilterUsers {
+		for i, user := range users {
 			if i > 0 {
 				filtersStr += ", "
 			}
 			principal, err := core.DecodePrincipal(user)
 			if err != nil {
-				return nil, fmt.Errorf("Invalid user filter [%s]: %w", user, err)
+				return "", "", fmt.Errorf("Invalid user filter [%s]: %w", user, err)
 			}
 			filtersStr += "unhex('" + strings.ToUpper(hex.EncodeToString(principal)) + "')"
 		}
 		filtersStr += ") AND "
 	}
 
-	if len(req.FilterEventType) > 0 {
+	if len(eventTypes) > 0 {
 		filtersStr += "lower(" + storage.StructuralBlobsType.String() + ") in ("
-		for i, eventType := range req.FilterEventType {
+		for i, eventType := range eventTypes {
 			// Hardcode this to prevent injection attacks
 			if strings.ToLower(eventType) != "keydelegation" && strings.ToLower(eventType) != "change" && strings.ToLower(eventType) != "comment" && strings.ToLower(eventType) != "dagpb" {
-				return nil, fmt.Errorf("Invalid event type filter [%s]: Only KeyDelegation | Change | Comment | DagPB aresupported at the moment", eventType)
+				return "", "", fmt.Errorf("Invalid event type filter [%s]: Only KeyDelegation | Change | Comment | DagPB aresupported at the moment", eventType)
 			}
 			if i > 0 {
 				filtersStr += ", "
@@ -109,11 +156,11 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		}
 		filtersStr += ") AND "
 	}
-	if len(req.FilterResource) > 0 {
+	if len(resources) > 0 {
 		filtersStr += storage.ResourcesIRI.String() + " in ("
-		for i, resource := range req.FilterResource {
+		for i, resource := range resources {
 			if !resourcePattern.MatchString(resource) {
-				return nil, fmt.Errorf("Invalid resource format [%s]", resource)
+				return "", "", fmt.Errorf("Invalid resource format [%s]", resource)
 			}
 			if i > 0 {
 				filtersStr += ", "
@@ -122,16 +169,15 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		}
 		filtersStr += ") AND "
 	}
-	var linksStr string
-	if len(req.AddLinkedResource) > 0 {
-		if len(req.FilterResource) > 0 || len(req.FilterEventType) > 0 {
+	if len(linkedResources) > 0 {
+		if len(resources) > 0 || len(eventTypes) > 0 {
 			linksStr += " OR "
 		}
 		linksStr += "(" + storage.StructuralBlobsType.String() + " in ('Change', 'Comment') AND " + storage.ResourceLinksTarget.String() + " IN (" +
 			"select " + storage.ResourcesID.String() + " FROM " + storage.T_Resources + " where " + storage.ResourcesIRI.String() + " in ("
-		for i, resource := range req.AddLinkedResource {
+		for i, resource := range linkedResources {
 			if !resourcePattern.MatchString(resource) {
-				return nil, fmt.Errorf("Invalid link resource format [%s]", resource)
+				return "", "", fmt.Errorf("Invalid link resource format [%s]", resource)
 			}
 			if i > 0 {
 				linksStr += ", "
@@ -140,6 +186,13 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		}
 		linksStr += "))) AND "
 	}
+
+	return filtersStr, linksStr, nil
+}
+
+// eventsQuery builds the query to select the events with the given filters.
+// The cursor condition must include the ordering and the limit of the query.
+func eventsQuery(filtersStr, linksStr, cursorStr string) string {
 	var (
 		selectStr            = "SELECT distinct " + storage.BlobsID + ", " + storage.StructuralBlobsType + ", " + storage.PublicKeysPrincipal + ", " + storage.ResourcesIRI + ", " + storage.StructuralBlobsTs + ", " + storage.BlobsInsertTime + ", " + storage.BlobsMultihash + ", " + storage.BlobsCodec
 		tableStr             = "FROM " + storage.T_StructuralBlobs
@@ -147,13 +200,9 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		joinpkStr            = "JOIN " + storage.PublicKeys.String() + " ON " + storage.StructuralBlobsAuthor.String() + "=" + storage.PublicKeysID.String()
 		joinLinksStr         = "LEFT JOIN " + storage.ResourceLinks.String() + " ON " + storage.StructuralBlobsID.String() + "=" + storage.ResourceLinksSource.String()
 		leftjoinResourcesStr = "LEFT JOIN " + storage.Resources.String() + " ON " + storage.StructuralBlobsResource.String() + "=" + storage.ResourcesID.String()
-
-		pageTokenStr = storage.BlobsID.String() + " <= :idx AND (" + storage.ResourcesIRI.String() + " IS NULL) AND " + storage.BlobsSize.String() + ">0 ORDER BY " + storage.BlobsID.String() + " desc limit :page_size"
 	)
-	if req.PageSize <= 0 {
-		req.PageSize = 30
-	}
-	var getEventsStr = fmt.Sprintf(`
+
+	return fmt.Sprintf(`
 		%s
 		%s
 		%s
@@ -161,50 +210,33 @@ func (srv *Server) ListEvents(ctx context.Context, req *activity.ListEventsReque
 		%s
 		%s
 		WHERE %s %s %s;
-	`, selectStr, tableStr, joinIDStr, joinpkStr, joinLinksStr, leftjoinResourcesStr, filtersStr, linksStr, pageTokenStr)
-	var lastBlobID int64
-	err = sqlitex.Exec(conn, dqb.Str(getEventsStr)(), func(stmt *sqlite.Stmt) error {
-		lastBlobID = stmt.ColumnInt64(0)
-		eventType := stmt.ColumnText(1)
-		author := stmt.ColumnBytes(2)
-		resource := stmt.ColumnText(3)
-		eventTime := stmt.ColumnInt64(4) * 1000 //Its in microseconds and we need nanos
-		observeTime := stmt.ColumnInt64(5)
-		mhash := stmt.ColumnBytes(6)
-		codec := stmt.ColumnInt64(7)
-		accountID := core.Principal(author).String()
-		id := cid.NewCidV1(uint64(codec), mhash)
-		if eventType == "Comment" {
-			resource = "hm://c/" + id.String()
-		}
-		event := activity.Event{
-			Data: &activity.Event_NewBlob{NewBlob: &activity.NewBlobEvent{
-				Cid:      id.String(),
-				BlobType: eventType,
-				Author:   accountID,
-				Resource: resource,
-			}},
-			Account:     accountID,
-			EventTime:   &timestamppb.Timestamp{Seconds: eventTime / 1000000000, Nanos: int32(eventTime % 1000000000)}, //nolint:gosec
-			ObserveTime: &timestamppb.Timestamp{Seconds: observeTime},
-		}
-		events = append(events, &event)
-		return nil
-	}, cursorBlobID, req.PageSize)
-	if err != nil {
-		return nil, fmt.Errorf("Problem collecting activity feed, Probably no feed or token out of range: %w", err)
-	}
+	`, selectStr, tableStr, joinIDStr, joinpkStr, joinLinksStr, leftjoinResourcesStr, filtersStr, linksStr, cursorStr)
+}
 
-	var nextPageToken string
-	if lastBlobID != 0 && int(req.PageSize) == len(events) {
-		nextPageToken, err = apiutil.EncodePageToken(lastBlobID-1, nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to encode next page token: %w", err)
-		}
+// eventFromRow converts the row of the events query into the event.
+func eventFromRow(stmt *sqlite.Stmt) (blobID int64, event *activity.Event) {
+	blobID = stmt.ColumnInt64(0)
+	eventType := stmt.ColumnText(1)
+	author := stmt.ColumnBytes(2)
+	resource := stmt.ColumnText(3)
+	eventTime := stmt.ColumnInt64(4) * 1000 //Its in microseconds and we need nanos
+	observeTime := stmt.ColumnInt64(5)
+	mhash := stmt.ColumnBytes(6)
+	codec := stmt.ColumnInt64(7)
+	accountID := core.Principal(author).String()
+	id := cid.NewCidV1(uint64(codec), mhash)
+	if eventType == "Comment" {
+		resource = "hm://c/" + id.String()
+	}
+	return blobID, &activity.Event{
+		Data: &activity.Event_NewBlob{NewBlob: &activity.NewBlobEvent{
+			Cid:      id.String(),
+			BlobType: eventType,
+			Author:   accountID,
+			Resource: resource,
+		}},
+		Account:     accountID,
+		EventTime:   &timestamppb.Timestamp{Seconds: eventTime / 1000000000, Nanos: int32(eventTime % 1000000000)}, //nolint:gosec
+		ObserveTime: &timestamppb.Timestamp{Seconds: observeTime},
 	}
-
-	return &activity.ListEventsResponse{
-		Events:        events,
-		NextPageToken: nextPageToken,
-	}, err
 }
@@ -0,0 +1,156 @@
+package activity
+
+import (
+	"context"
+	activity "seed/backend/genproto/activity/v1alpha"
+	"seed/backend/storage"
+	"seed/backend/util/apiutil"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type blobWatcher interface {
+	WatchBlobs() (<-chan struct{}, func())
+}
+
+// Max number of events sent in a single message of the stream.
+const streamEventsBatchSize = 100
+
+// StreamEvents implements the ActivityFeed API.
+// Blob IDs are allocated in the order the blobs are committed,
+// so the last sent blob ID is used as a cursor to resume the stream.
+func (srv *Server) StreamEvents(in *activity.StreamEventsRequest, stream activity.ActivityFeed_StreamEventsServer) error {
+	if srv.blobs == nil {
+		return status.Errorf(codes.Unavailable, "event streaming is not available")
+	}
+
+	// We don't track trusted peers yet, so we can't honor the filter.
+	if in.TrustedOnly {
+		return status.Errorf(codes.InvalidArgument, "trusted_only is not supported for streaming events")
+	}
+
+	filtersStr, linksStr, err := eventFilters(in.FilterUsers, in.FilterEventType, in.FilterResource, in.AddLinkedResource)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	ctx := stream.Context()
+
+	// Subscribing before reading the cursor to avoid missing blobs in between.
+	updates, cancel := srv.blobs.WatchBlobs()
+	defer cancel()
+
+	var cursor int64
+	if in.Cursor != "" {
+		if err := apiutil.DecodePageToken(in.Cursor, &cursor, nil); err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to decode cursor: %v", err)
+		}
+	} else {
+		cursor, err = srv.lastBlobID(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	cursorStr := storage.BlobsID.String() + " > :idx AND (" + storage.ResourcesIRI.String() + " IS NULL) AND " + storage.BlobsSize.String() + ">0 ORDER BY " + storage.BlobsID.String() + " asc limit :page_size"
+	query := dqb.Str(eventsQuery(filtersStr, linksStr, cursorStr))
+
+	emit := func() error {
+		for {
+			events, last, err := srv.loadEvents(ctx, query(), cursor)
+			if err != nil {
+				return err
+			}
+
+			if len(events) == 0 {
+				return nil
+			}
+
+			cursor = last
+			token, err := apiutil.EncodePageToken(cursor, nil)
+			if err != nil {
+				return err
+			}
+
+			if err := stream.Send(&activity.StreamEventsResponse{
+				Events: events,
+				Cursor: token,
+			}); err != nil {
+				return err
+			}
+
+			if len(events) < streamEventsBatchSize {
+				return nil
+			}
+		}
+	}
+
+	// Sending the events we might have missed since the cursor.
+	if err := emit(); err != nil {
+		return streamError(ctx, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-updates:
+			if err := emit(); err != nil {
+				return streamError(ctx, err)
+			}
+		}
+	}
+}
+
+// streamError ignores the errors caused by the client closing the stream,
+// e.g. queries interrupted by the canceled context.
+func streamError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (srv *Server) loadEvents(ctx context.Context, query string, cursor int64) (events []*activity.Event, last int64, err error) {
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, query, func(stmt *sqlite.Stmt) error {
+		var event *activity.Event
+		last, event = eventFromRow(stmt)
+		events = append(events, event)
+		return nil
+	}, cursor, streamEventsBatchSize); err != nil {
+		return nil, 0, err
+	}
+
+	return events, last, nil
+}
+
+func (srv *Server) lastBlobID(ctx context.Context) (id int64, err error) {
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qLastBlobID(), func(stmt *sqlite.Stmt) error {
+		id = stmt.ColumnInt64(0)
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+var qLastBlobID = dqb.Str(`
+	SELECT ifnull(max(id), 0) FROM blobs;
+`)
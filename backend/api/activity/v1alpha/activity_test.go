@@ -2,13 +2,21 @@ package activity
 
 import (
 	context "context"
+	"seed/backend/core"
+	"seed/backend/core/coretest"
 	activity "seed/backend/genproto/activity/v1alpha"
+	"seed/backend/index"
 	"seed/backend/logging"
 	"seed/backend/storage"
+	"seed/backend/util/apiutil"
 	"seed/backend/util/cleanup"
 	"testing"
+	"time"
 
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestListEvents(t *testing.T) {
@@ -25,10 +33,106 @@ func TestListEvents(t *testing.T) {
 	require.Len(t, events.Events, 0)
 }
 
+type streamEventsStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *activity.StreamEventsResponse
+}
+
+func (s *streamEventsStream) Context() context.Context { return s.ctx }
+
+func (s *streamEventsStream) Send(u *activity.StreamEventsResponse) error {
+	s.updates <- u
+	return nil
+}
+
+func TestStreamEvents(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	srv := newTestServer(t, "alice")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	putChange := func(kp core.KeyPair, ts int64) cid.Cid {
+		eb, err := index.NewChange(kp, nil, "Create", nil, ts)
+		require.NoError(t, err)
+		blk, err := blocks.NewBlockWithCid(eb.Data, eb.CID)
+		require.NoError(t, err)
+		require.NoError(t, srv.blobs.(*index.Index).Put(ctx, blk))
+		return eb.CID
+	}
+
+	recv := func(updates chan *activity.StreamEventsResponse) *activity.StreamEventsResponse {
+		select {
+		case u := <-updates:
+			return u
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for events")
+			return nil
+		}
+	}
+
+	old := putChange(alice.Account, 1)
+
+	// Starting from an explicit cursor to avoid racing with the stream setup.
+	last, err := srv.lastBlobID(ctx)
+	require.NoError(t, err)
+	cursor, err := apiutil.EncodePageToken(last, nil)
+	require.NoError(t, err)
+
+	stream := &streamEventsStream{ctx: ctx, updates: make(chan *activity.StreamEventsResponse, 10)}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.StreamEvents(&activity.StreamEventsRequest{
+			Cursor:      cursor,
+			FilterUsers: []string{alice.Account.Principal().String()},
+		}, stream)
+	}()
+
+	putChange(bob.Account, 2)
+	c1 := putChange(alice.Account, 3)
+
+	u := recv(stream.updates)
+	require.Len(t, u.Events, 1)
+	require.Equal(t, c1.String(), u.Events[0].GetNewBlob().Cid)
+	require.Equal(t, alice.Account.Principal().String(), u.Events[0].Account)
+	require.NotEmpty(t, u.Cursor)
+
+	c2 := putChange(alice.Account, 4)
+	u = recv(stream.updates)
+	require.Len(t, u.Events, 1)
+	require.Equal(t, c2.String(), u.Events[0].GetNewBlob().Cid)
+
+	cancel()
+	require.NoError(t, <-errc)
+
+	// Resuming from the cursor skips the events already received.
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	resumed := &streamEventsStream{ctx: ctx, updates: make(chan *activity.StreamEventsResponse, 10)}
+	go func() {
+		errc <- srv.StreamEvents(&activity.StreamEventsRequest{Cursor: u.Cursor}, resumed)
+	}()
+	c3 := putChange(bob.Account, 5)
+	u = recv(resumed.updates)
+	require.Len(t, u.Events, 1)
+	require.Equal(t, c3.String(), u.Events[0].GetNewBlob().Cid)
+	require.NotEqual(t, old.String(), u.Events[0].GetNewBlob().Cid)
+
+	cancel()
+	require.NoError(t, <-errc)
+
+	_, err = srv.ListEvents(context.Background(), &activity.ListEventsRequest{FilterEventType: []string{"Unknown"}})
+	require.Error(t, err)
+	require.Error(t, srv.StreamEvents(&activity.StreamEventsRequest{FilterEventType: []string{"Unknown"}}, resumed))
+	require.Error(t, srv.StreamEvents(&activity.StreamEventsRequest{TrustedOnly: true}, resumed))
+}
+
 // TODO: update profile idempotent no change
 
 func newTestServer(t *testing.T, name string) *Server {
 	db := storage.MakeTestDB(t)
 	var clean cleanup.Stack
-	return NewServer(db, logging.New("seed/Activity", "debug"), &clean)
+	idx := index.NewIndex(db, logging.New("seed/index", "debug"), nil)
+	return NewServer(db, idx, logging.New("seed/Activity", "debug"), &clean)
 }
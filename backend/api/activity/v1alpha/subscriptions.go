@@ -2,9 +2,11 @@ package activity
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	activity "seed/backend/genproto/activity/v1alpha"
+	"seed/backend/mttnet"
 	"seed/backend/syncing"
 	"seed/backend/util/apiutil"
 	"seed/backend/util/dqb"
@@ -14,6 +16,8 @@ import (
 	"strings"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -22,6 +26,21 @@ import (
 func (srv *Server) Subscribe(ctx context.Context, req *activity.SubscribeRequest) (*emptypb.Empty, error) {
 	vals := []interface{}{}
 
+	subscriptionReq := activity.Subscription{
+		Account:   req.Account,
+		Path:      req.Path,
+		Recursive: req.Recursive,
+		BlobTypes: req.BlobTypes,
+		Authors:   req.Authors,
+		MaxDepth:  req.MaxDepth,
+	}
+	if req.MaxDepth != 0 && !req.Recursive {
+		return nil, status.Errorf(codes.InvalidArgument, "max_depth is only allowed for recursive subscriptions")
+	}
+	if err := mttnet.ValidateFilter(syncing.SubscriptionFilter(&subscriptionReq)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid subscription: %v", err)
+	}
+
 	_, ok := ctx.Deadline()
 	if !ok {
 		srv.log.Debug("Inserting deadline", zap.String("Duration", syncing.DefaultDiscoveryTimeout.String()))
@@ -57,18 +76,24 @@ func (srv *Server) Subscribe(ctx context.Context, req *activity.SubscribeRequest
 		return nil, fmt.Errorf("Syncer non defined on blocking call")
 	}
 
-	sqlStr := "INSERT OR REPLACE INTO subscriptions (iri, is_recursive) VALUES (?,?)"
-	vals = append(vals, "hm://"+req.Account+req.Path, req.Recursive)
-	if err := sqlitex.Exec(conn, sqlStr, nil, vals...); err != nil {
+	blobTypes, err := encodeList(req.BlobTypes)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	authors, err := encodeList(req.Authors)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Subscribing again only updates the parameters, so the sync status of the subscription is preserved.
+	vals = append(vals, "hm://"+req.Account+req.Path, req.Recursive, blobTypes, authors, req.MaxDepth)
+	if err := sqlitex.Exec(conn, qUpsertSubscription(), nil, vals...); err != nil {
 		cancel()
 		return &emptypb.Empty{}, err
 	}
 	cancel()
-	subscriptionReq := activity.Subscription{
-		Account:   req.Account,
-		Path:      req.Path,
-		Recursive: req.Recursive,
-	}
 	if blocking {
 		ret, err := srv.syncer.SyncSubscribedContent(ctx, &subscriptionReq)
 		if err != nil {
@@ -92,6 +117,16 @@ func (srv *Server) Subscribe(ctx context.Context, req *activity.SubscribeRequest
 	return &emptypb.Empty{}, nil
 }
 
+var qUpsertSubscription = dqb.Str(`
+	INSERT INTO subscriptions (iri, is_recursive, blob_types, authors, max_depth)
+	VALUES (:iri, :is_recursive, :blob_types, :authors, :max_depth)
+	ON CONFLICT (iri) DO UPDATE SET
+		is_recursive = excluded.is_recursive,
+		blob_types = excluded.blob_types,
+		authors = excluded.authors,
+		max_depth = excluded.max_depth;
+`)
+
 // Unsubscribe removes a subscription.
 func (srv *Server) Unsubscribe(ctx context.Context, req *activity.UnsubscribeRequest) (*emptypb.Empty, error) {
 	conn, cancel, err := srv.db.Conn(ctx)
@@ -144,6 +179,20 @@ func (srv *Server) ListSubscriptions(ctx context.Context, req *activity.ListSubs
 			Path:      strings.TrimPrefix(iri, acc),
 			Recursive: recursive != 0,
 			Since:     &timestamppb.Timestamp{Seconds: insertTime},
+			MaxDepth:  int32(stmt.ColumnInt(6)), //nolint:gosec
+
+			LastSyncError:    stmt.ColumnText(10),
+			PeersWithContent: int32(stmt.ColumnInt(8)), //nolint:gosec
+			BlobsFetched:     int32(stmt.ColumnInt(9)), //nolint:gosec
+		}
+		if ts := stmt.ColumnInt64(7); ts > 0 {
+			item.LastSyncTime = &timestamppb.Timestamp{Seconds: ts}
+		}
+		if err := json.Unmarshal(stmt.ColumnBytesUnsafe(4), &item.BlobTypes); err != nil {
+			return fmt.Errorf("failed to decode subscription blob types: %w", err)
+		}
+		if err := json.Unmarshal(stmt.ColumnBytesUnsafe(5), &item.Authors); err != nil {
+			return fmt.Errorf("failed to decode subscription authors: %w", err)
 		}
 
 		subscriptions = append(subscriptions, &item)
@@ -169,15 +218,117 @@ func (srv *Server) ListSubscriptions(ctx context.Context, req *activity.ListSubs
 
 var qListSubscriptions = dqb.Str(`
 	SELECT 
-		id,
+		subscriptions.id,
 		iri,
 		is_recursive,
-		insert_time
+		insert_time,
+		blob_types,
+		authors,
+		max_depth,
+		ifnull(syncs.last_ok_time, 0),
+		ifnull(syncs.peers, 0),
+		ifnull(syncs.blobs_fetched, 0),
+		ifnull((
+			SELECT last_error
+			FROM subscription_syncs errs
+			WHERE errs.subscription = subscriptions.id
+			AND errs.last_error != ''
+			AND errs.update_time > syncs.last_ok_time
+			ORDER BY errs.update_time DESC
+			LIMIT 1
+		), '')
 	FROM subscriptions
-	WHERE id < :last_cursor
-	ORDER BY id DESC LIMIT :page_size;
+	LEFT JOIN (
+		SELECT
+			ss.subscription,
+			max(ss.last_ok_time) AS last_ok_time,
+			-- Only the peers from the latest sync are counted,
+			-- otherwise every peer we ever synced with would add up forever.
+			sum(ss.has_content) FILTER (WHERE ss.update_time = latest.update_time) AS peers,
+			sum(ss.blobs_fetched) FILTER (WHERE ss.update_time = latest.update_time) AS blobs_fetched
+		FROM subscription_syncs ss
+		JOIN (
+			SELECT subscription, max(update_time) AS update_time
+			FROM subscription_syncs
+			GROUP BY subscription
+		) latest ON latest.subscription = ss.subscription
+		GROUP BY ss.subscription
+	) syncs ON syncs.subscription = subscriptions.id
+	WHERE subscriptions.id < :last_cursor
+	ORDER BY subscriptions.id DESC LIMIT :page_size;
 `)
 
+// GetSyncProgress implements the Subscriptions API.
+func (srv *Server) GetSyncProgress(in *activity.GetSyncProgressRequest, stream activity.Subscriptions_GetSyncProgressServer) error {
+	if srv.syncer == nil {
+		return status.Errorf(codes.Unavailable, "syncing is not available")
+	}
+
+	var wantIRI string
+	if in.Account != "" {
+		wantIRI = "hm://" + in.Account + in.Path
+	}
+
+	w := srv.syncer.WatchProgress()
+	defer w.Close()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.C():
+		}
+
+		for _, p := range w.Next() {
+			if wantIRI != "" && p.Resource != wantIRI {
+				continue
+			}
+
+			if err := stream.Send(syncProgressToProto(p)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func syncProgressToProto(p syncing.Progress) *activity.SyncProgress {
+	iri := strings.TrimPrefix(p.Resource, "hm://")
+	acc := strings.Split(iri, "/")[0]
+
+	out := &activity.SyncProgress{
+		Account:      acc,
+		Path:         strings.TrimPrefix(iri, acc),
+		PeerId:       p.Peer.String(),
+		BlobsWanted:  int32(p.Wanted),  //nolint:gosec
+		BlobsFetched: int32(p.Fetched), //nolint:gosec
+		BlobsFailed:  int32(p.Failed),  //nolint:gosec
+		Done:         p.Done,
+		StartTime:    timestamppb.New(p.StartTime),
+		UpdateTime:   timestamppb.New(p.UpdateTime),
+	}
+	if p.Err != nil {
+		out.Error = p.Err.Error()
+	}
+
+	return out
+}
+
+// encodeList encodes the list as a JSON array to store in the database.
+// Empty lists are stored as empty arrays instead of null.
+func encodeList(list []string) (string, error) {
+	if list == nil {
+		list = []string{}
+	}
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
 var qGetResource = dqb.Str(`
 	SELECT 
 		iri
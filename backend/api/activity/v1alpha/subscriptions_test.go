@@ -2,10 +2,15 @@ package activity
 
 import (
 	context "context"
+	"seed/backend/core/coretest"
 	activity "seed/backend/genproto/activity/v1alpha"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestListSubscriptions(t *testing.T) {
@@ -26,3 +31,82 @@ func TestListSubscriptions(t *testing.T) {
 	})
 	require.Error(t, err) // timeout
 }
+
+func TestSubscribeInvalidFilters(t *testing.T) {
+	alice := newTestServer(t, "alice")
+	ctx := context.Background()
+	acc := coretest.NewTester("alice").Account.Principal().String()
+
+	for _, req := range []*activity.SubscribeRequest{
+		{Account: acc, BlobTypes: []string{"Ref"}},
+		{Account: acc, Authors: []string{"not-a-principal"}},
+		{Account: acc, Recursive: true, MaxDepth: -1},
+		{Account: acc, MaxDepth: 2},
+	} {
+		_, err := alice.Subscribe(ctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err), req.String())
+	}
+}
+
+func TestListSubscriptionsSyncStatus(t *testing.T) {
+	alice := newTestServer(t, "alice")
+	ctx := context.Background()
+	acc := coretest.NewTester("alice").Account.Principal().String()
+
+	conn, release, err := alice.db.Conn(ctx)
+	require.NoError(t, err)
+	require.NoError(t, sqlitex.ExecScript(conn, `
+		INSERT INTO subscriptions (id, iri, blob_types, authors) VALUES
+			(1, 'hm://`+acc+`/synced', '["Change","Comment"]', '["`+acc+`"]'),
+			(2, 'hm://`+acc+`/failing', '[]', '[]'),
+			(3, 'hm://`+acc+`/new', '[]', '[]');
+		INSERT INTO subscription_syncs (subscription, peer, last_ok_time, last_error, has_content, blobs_fetched, update_time) VALUES
+			(1, 'peer-1', 100, '', true, 3, 100),
+			(1, 'peer-2', 0, 'stale failure', false, 0, 90),
+			(1, 'peer-3', 100, '', true, 2, 100),
+			(1, 'peer-4', 60, '', true, 7, 60),
+			(2, 'peer-1', 50, 'connection refused', true, 1, 200);
+	`))
+	release()
+
+	res, err := alice.ListSubscriptions(ctx, &activity.ListSubscriptionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Subscriptions, 3)
+
+	fresh := res.Subscriptions[0]
+	require.Equal(t, "/new", fresh.Path)
+	require.Nil(t, fresh.LastSyncTime)
+	require.Empty(t, fresh.LastSyncError)
+	require.Zero(t, fresh.PeersWithContent)
+
+	failing := res.Subscriptions[1]
+	require.Equal(t, "/failing", failing.Path)
+	require.Equal(t, int64(50), failing.LastSyncTime.Seconds)
+	require.Equal(t, "connection refused", failing.LastSyncError)
+	require.Equal(t, int32(1), failing.PeersWithContent)
+	require.Equal(t, int32(1), failing.BlobsFetched)
+
+	synced := res.Subscriptions[2]
+	require.Equal(t, "/synced", synced.Path)
+	require.Equal(t, int64(100), synced.LastSyncTime.Seconds)
+	require.Empty(t, synced.LastSyncError, "errors before the last successful sync must be ignored")
+	require.Equal(t, int32(2), synced.PeersWithContent, "only the peers from the latest sync must be counted")
+	require.Equal(t, int32(5), synced.BlobsFetched)
+	require.Equal(t, []string{"Change", "Comment"}, synced.BlobTypes)
+	require.Equal(t, []string{acc}, synced.Authors)
+	require.Empty(t, failing.BlobTypes)
+	require.Empty(t, failing.Authors)
+
+	_, err = alice.Unsubscribe(ctx, &activity.UnsubscribeRequest{Account: acc, Path: "/synced"})
+	require.NoError(t, err)
+
+	conn, release, err = alice.db.Conn(ctx)
+	require.NoError(t, err)
+	defer release()
+	var count int
+	require.NoError(t, sqlitex.Exec(conn, "SELECT count(*) FROM subscription_syncs WHERE subscription = 1", func(stmt *sqlite.Stmt) error {
+		count = stmt.ColumnInt(0)
+		return nil
+	}))
+	require.Zero(t, count, "sync status must be removed with the subscription")
+}
@@ -0,0 +1,302 @@
+package activity
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"net/url"
+	activity "seed/backend/genproto/activity/v1alpha"
+	"seed/backend/util/apiutil"
+	"seed/backend/util/dqb"
+	"seed/backend/util/errutil"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// CreateWebhook implements the Webhooks API.
+func (srv *Server) CreateWebhook(ctx context.Context, in *activity.CreateWebhookRequest) (*activity.Webhook, error) {
+	if in.Url == "" {
+		return nil, errutil.MissingArgument("url")
+	}
+
+	u, err := url.Parse(in.Url)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse url '%s': %v", in.Url, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "webhook url must be an absolute http or https url: got '%s'", in.Url)
+	}
+
+	if in.Filter == nil {
+		in.Filter = &activity.WebhookFilter{}
+	}
+
+	if _, _, err := webhookFilters(in.Filter); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	filter, err := protojson.Marshal(in.Filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret [32]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return nil, err
+	}
+
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var id int64
+	// Only the events observed after the registration are delivered,
+	// so the cursor starts at the latest blob.
+	if err := sqlitex.Exec(conn, qInsertWebhook(), func(stmt *sqlite.Stmt) error {
+		id = stmt.ColumnInt64(0)
+		return nil
+	}, in.Url, hex.EncodeToString(secret[:]), string(filter)); err != nil {
+		return nil, err
+	}
+
+	return srv.getWebhook(conn, id)
+}
+
+var qInsertWebhook = dqb.Str(`
+	INSERT INTO webhooks (url, secret, filter, cursor)
+	VALUES (:url, :secret, :filter, (SELECT ifnull(max(id), 0) FROM blobs))
+	RETURNING id;
+`)
+
+// GetWebhook implements the Webhooks API.
+func (srv *Server) GetWebhook(ctx context.Context, in *activity.GetWebhookRequest) (*activity.Webhook, error) {
+	if in.Id == 0 {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return srv.getWebhook(conn, in.Id)
+}
+
+func (srv *Server) getWebhook(conn *sqlite.Conn, id int64) (out *activity.Webhook, err error) {
+	if err := sqlitex.Exec(conn, qGetWebhook(), func(stmt *sqlite.Stmt) error {
+		out, err = webhookFromRow(stmt)
+		return err
+	}, id); err != nil {
+		return nil, err
+	}
+
+	if out == nil {
+		return nil, errutil.NotFound("webhook %d not found", id)
+	}
+
+	return out, nil
+}
+
+var qGetWebhook = dqb.Str(`
+	SELECT id, url, secret, filter, create_time
+	FROM webhooks
+	WHERE id = :id;
+`)
+
+// ListWebhooks implements the Webhooks API.
+func (srv *Server) ListWebhooks(ctx context.Context, in *activity.ListWebhooksRequest) (*activity.ListWebhooksResponse, error) {
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	type Cursor struct {
+		ID int64 `json:"i"`
+	}
+
+	var cursor = Cursor{ID: math.MaxInt64}
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode page token: %v", err)
+		}
+	}
+
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	out := &activity.ListWebhooksResponse{}
+
+	var count int32
+	if err := sqlitex.Exec(conn, qListWebhooks(), func(stmt *sqlite.Stmt) error {
+		if count == in.PageSize {
+			var err error
+			out.NextPageToken, err = apiutil.EncodePageToken(cursor, nil)
+			return err
+		}
+		count++
+
+		wh, err := webhookFromRow(stmt)
+		if err != nil {
+			return err
+		}
+
+		cursor.ID = wh.Id
+		out.Webhooks = append(out.Webhooks, wh)
+		return nil
+	}, cursor.ID, in.PageSize+1); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var qListWebhooks = dqb.Str(`
+	SELECT id, url, secret, filter, create_time
+	FROM webhooks
+	WHERE id < :cursor
+	ORDER BY id DESC
+	LIMIT :page_size;
+`)
+
+func webhookFromRow(stmt *sqlite.Stmt) (*activity.Webhook, error) {
+	filter := &activity.WebhookFilter{}
+	if err := protojson.Unmarshal(stmt.ColumnBytesUnsafe(3), filter); err != nil {
+		return nil, err
+	}
+
+	return &activity.Webhook{
+		Id:         stmt.ColumnInt64(0),
+		Url:        stmt.ColumnText(1),
+		Secret:     stmt.ColumnText(2),
+		Filter:     filter,
+		CreateTime: &timestamppb.Timestamp{Seconds: stmt.ColumnInt64(4)},
+	}, nil
+}
+
+// DeleteWebhook implements the Webhooks API.
+func (srv *Server) DeleteWebhook(ctx context.Context, in *activity.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	if in.Id == 0 {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qDeleteWebhook(), nil, in.Id); err != nil {
+		return nil, err
+	}
+
+	if conn.Changes() == 0 {
+		return nil, errutil.NotFound("webhook %d not found", in.Id)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+var qDeleteWebhook = dqb.Str(`
+	DELETE FROM webhooks WHERE id = :id;
+`)
+
+// ListWebhookDeliveries implements the Webhooks API.
+func (srv *Server) ListWebhookDeliveries(ctx context.Context, in *activity.ListWebhookDeliveriesRequest) (*activity.ListWebhookDeliveriesResponse, error) {
+	if in.WebhookId == 0 {
+		return nil, errutil.MissingArgument("webhook_id")
+	}
+
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	type Cursor struct {
+		ID int64 `json:"i"`
+	}
+
+	var cursor = Cursor{ID: math.MaxInt64}
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode page token: %v", err)
+		}
+	}
+
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if _, err := srv.getWebhook(conn, in.WebhookId); err != nil {
+		return nil, err
+	}
+
+	out := &activity.ListWebhookDeliveriesResponse{}
+
+	var count int32
+	if err := sqlitex.Exec(conn, qListWebhookDeliveries(), func(stmt *sqlite.Stmt) error {
+		if count == in.PageSize {
+			var err error
+			out.NextPageToken, err = apiutil.EncodePageToken(cursor, nil)
+			return err
+		}
+		count++
+
+		event := &activity.Event{}
+		if err := protojson.Unmarshal(stmt.ColumnBytesUnsafe(1), event); err != nil {
+			return err
+		}
+
+		d := &activity.WebhookDelivery{
+			Id:              stmt.ColumnInt64(0),
+			WebhookId:       in.WebhookId,
+			Event:           event,
+			Status:          stmt.ColumnText(2),
+			Attempts:        int32(stmt.ColumnInt(3)), //nolint:gosec
+			ResponseCode:    int32(stmt.ColumnInt(4)), //nolint:gosec
+			Error:           stmt.ColumnText(5),
+			CreateTime:      &timestamppb.Timestamp{Seconds: stmt.ColumnInt64(6)},
+			UpdateTime:      &timestamppb.Timestamp{Seconds: stmt.ColumnInt64(7)},
+			NextAttemptTime: &timestamppb.Timestamp{Seconds: stmt.ColumnInt64(8)},
+		}
+
+		cursor.ID = d.Id
+		out.Deliveries = append(out.Deliveries, d)
+		return nil
+	}, in.WebhookId, cursor.ID, in.PageSize+1); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var qListWebhookDeliveries = dqb.Str(`
+	SELECT
+		id,
+		payload,
+		status,
+		attempts,
+		response_code,
+		error,
+		create_time,
+		update_time,
+		next_attempt_time
+	FROM webhook_deliveries
+	WHERE webhook = :webhook
+	AND id < :cursor
+	ORDER BY id DESC
+	LIMIT :page_size;
+`)
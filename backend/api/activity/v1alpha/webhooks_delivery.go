@@ -0,0 +1,490 @@
+package activity
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	activity "seed/backend/genproto/activity/v1alpha"
+	"seed/backend/storage"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	webhookMaxAttempts    = 8
+	webhookMinBackoff     = 5 * time.Second
+	webhookMaxBackoff     = time.Hour
+	webhookRequestTimeout = 15 * time.Second
+	webhookBatchSize      = 50
+
+	// Max number of webhooks we deliver to concurrently.
+	webhookMaxParallel = 8
+
+	// Interval to check for pending deliveries when nothing else wakes up the dispatcher.
+	webhookIdleInterval = time.Minute
+
+	// Delivered and failed deliveries are removed from the log after this period.
+	webhookDeliveryRetention = 7 * 24 * time.Hour
+	webhookPurgeInterval     = time.Hour
+)
+
+// webhookDispatcher matches new blobs against the filters of the registered webhooks,
+// records the deliveries in the database, and sends them to the endpoints.
+type webhookDispatcher struct {
+	db     *sqlitex.Pool
+	log    *zap.Logger
+	client *http.Client
+
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	retention   time.Duration
+}
+
+func newWebhookDispatcher(db *sqlitex.Pool, log *zap.Logger) *webhookDispatcher {
+	return &webhookDispatcher{
+		db:          db,
+		log:         log,
+		client:      &http.Client{Timeout: webhookRequestTimeout},
+		maxAttempts: webhookMaxAttempts,
+		minBackoff:  webhookMinBackoff,
+		maxBackoff:  webhookMaxBackoff,
+		retention:   webhookDeliveryRetention,
+	}
+}
+
+// DeliverWebhooks runs the webhook delivery loop until the context is canceled.
+// Must only be called once, when the server has a blob watcher.
+func (srv *Server) DeliverWebhooks(ctx context.Context) error {
+	if srv.blobs == nil {
+		return fmt.Errorf("webhooks require a blob watcher")
+	}
+
+	updates, cancel := srv.blobs.WatchBlobs()
+	defer cancel()
+
+	return srv.webhooks.run(ctx, updates)
+}
+
+func (d *webhookDispatcher) run(ctx context.Context, updates <-chan struct{}) error {
+	var lastPurge time.Time
+	for {
+		if time.Since(lastPurge) >= webhookPurgeInterval {
+			if err := d.purge(ctx); err != nil && ctx.Err() == nil {
+				d.log.Warn("WebhookPurgeFailed", zap.Error(err))
+			}
+			lastPurge = time.Now()
+		}
+
+		if err := d.enqueue(ctx); err != nil && ctx.Err() == nil {
+			d.log.Warn("WebhookEnqueueFailed", zap.Error(err))
+		}
+
+		next, err := d.deliverDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.log.Warn("WebhookDeliveryFailed", zap.Error(err))
+		}
+
+		wait := webhookIdleInterval
+		if !next.IsZero() {
+			wait = min(wait, time.Until(next))
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil
+		case <-updates:
+		case <-t.C:
+		}
+		t.Stop()
+	}
+}
+
+// purge removes the finished deliveries older than the retention period.
+func (d *webhookDispatcher) purge(ctx context.Context) error {
+	conn, release, err := d.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return sqlitex.Exec(conn, qPurgeWebhookDeliveries(), nil, time.Now().Add(-d.retention).Unix())
+}
+
+var qPurgeWebhookDeliveries = dqb.Str(`
+	DELETE FROM webhook_deliveries
+	WHERE status != 'pending'
+	AND update_time <= :cutoff;
+`)
+
+type pendingWebhook struct {
+	ID     int64
+	Cursor int64
+	Filter string
+}
+
+// enqueue creates the deliveries for the events that appeared since the last time we looked,
+// and moves the cursors of the webhooks forward.
+func (d *webhookDispatcher) enqueue(ctx context.Context) error {
+	conn, release, err := d.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return sqlitex.WithTx(conn, func() error {
+		var last int64
+		if err := sqlitex.Exec(conn, qLastBlobID(), func(stmt *sqlite.Stmt) error {
+			last = stmt.ColumnInt64(0)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		var hooks []pendingWebhook
+		if err := sqlitex.Exec(conn, qListOutdatedWebhooks(), func(stmt *sqlite.Stmt) error {
+			hooks = append(hooks, pendingWebhook{
+				ID:     stmt.ColumnInt64(0),
+				Cursor: stmt.ColumnInt64(1),
+				Filter: stmt.ColumnText(2),
+			})
+			return nil
+		}, last); err != nil {
+			return err
+		}
+
+		for _, wh := range hooks {
+			if err := d.enqueueWebhook(conn, wh, last); err != nil {
+				// The filter is validated when the webhook is created,
+				// so failing here means something is really wrong with this webhook.
+				// We still move the cursor to avoid getting stuck.
+				d.log.Warn("WebhookMatchFailed", zap.Int64("webhook", wh.ID), zap.Error(err))
+			}
+
+			if err := sqlitex.Exec(conn, qUpdateWebhookCursor(), nil, last, wh.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+var qListOutdatedWebhooks = dqb.Str(`
+	SELECT id, cursor, filter
+	FROM webhooks
+	WHERE cursor < :last;
+`)
+
+var qUpdateWebhookCursor = dqb.Str(`
+	UPDATE webhooks SET cursor = :cursor WHERE id = :id;
+`)
+
+func (d *webhookDispatcher) enqueueWebhook(conn *sqlite.Conn, wh pendingWebhook, last int64) error {
+	filter := &activity.WebhookFilter{}
+	if err := protojson.Unmarshal([]byte(wh.Filter), filter); err != nil {
+		return err
+	}
+
+	filtersStr, linksStr, err := webhookFilters(filter)
+	if err != nil {
+		return err
+	}
+
+	cursorStr := storage.BlobsID.String() + " > :idx AND " + storage.BlobsID.String() + " <= :last AND (" + storage.ResourcesIRI.String() + " IS NULL) AND " + storage.BlobsSize.String() + ">0 ORDER BY " + storage.BlobsID.String() + " asc"
+
+	type match struct {
+		blob    int64
+		payload []byte
+	}
+
+	var matches []match
+	if err := sqlitex.Exec(conn, dqb.Str(eventsQuery(filtersStr, linksStr, cursorStr))(), func(stmt *sqlite.Stmt) error {
+		blob, event := eventFromRow(stmt)
+		payload, err := protojson.Marshal(event)
+		if err != nil {
+			return err
+		}
+		matches = append(matches, match{blob: blob, payload: payload})
+		return nil
+	}, wh.Cursor, last); err != nil {
+		return err
+	}
+
+	for _, m := range matches {
+		if err := sqlitex.Exec(conn, qInsertWebhookDelivery(), nil, wh.ID, m.blob, string(m.payload)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var qInsertWebhookDelivery = dqb.Str(`
+	INSERT INTO webhook_deliveries (webhook, blob, payload)
+	VALUES (:webhook, :blob, :payload)
+	ON CONFLICT (webhook, blob) DO NOTHING;
+`)
+
+func webhookFilters(f *activity.WebhookFilter) (filtersStr, linksStr string, err error) {
+	return eventFilters(f.FilterUsers, f.FilterEventType, f.FilterResource, f.AddLinkedResource)
+}
+
+type webhookDelivery struct {
+	ID       int64
+	Webhook  int64
+	URL      string
+	Secret   string
+	Payload  []byte
+	Attempts int
+}
+
+// deliverDue sends the pending deliveries that are due,
+// and returns the time of the next pending attempt, or zero time if there's nothing pending.
+// Each webhook is delivered to concurrently, so a slow endpoint doesn't hold back the others.
+func (d *webhookDispatcher) deliverDue(ctx context.Context) (next time.Time, err error) {
+	for {
+		batch, err := d.listDue(ctx)
+		if err != nil {
+			return next, err
+		}
+
+		var (
+			hooks  []int64
+			byHook = make(map[int64][]webhookDelivery)
+		)
+		for _, dl := range batch {
+			if _, ok := byHook[dl.Webhook]; !ok {
+				hooks = append(hooks, dl.Webhook)
+			}
+			byHook[dl.Webhook] = append(byHook[dl.Webhook], dl)
+		}
+
+		g, gctx := errgroup.WithContext(ctx)
+		g.SetLimit(webhookMaxParallel)
+		for _, wh := range hooks {
+			g.Go(func() error {
+				return d.deliverWebhook(gctx, wh, byHook[wh])
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return next, err
+		}
+
+		if len(batch) < webhookBatchSize {
+			break
+		}
+	}
+
+	conn, release, err := d.db.Conn(ctx)
+	if err != nil {
+		return next, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qNextWebhookAttempt(), func(stmt *sqlite.Stmt) error {
+		if ts := stmt.ColumnInt64(0); ts > 0 {
+			next = time.Unix(ts, 0)
+		}
+		return nil
+	}); err != nil {
+		return next, err
+	}
+
+	return next, nil
+}
+
+var qNextWebhookAttempt = dqb.Str(`
+	SELECT ifnull(min(next_attempt_time), 0)
+	FROM webhook_deliveries
+	WHERE status = 'pending';
+`)
+
+func (d *webhookDispatcher) listDue(ctx context.Context) (out []webhookDelivery, err error) {
+	conn, release, err := d.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qListDueWebhookDeliveries(), func(stmt *sqlite.Stmt) error {
+		out = append(out, webhookDelivery{
+			ID:       stmt.ColumnInt64(0),
+			Webhook:  stmt.ColumnInt64(1),
+			URL:      stmt.ColumnText(2),
+			Secret:   stmt.ColumnText(3),
+			Payload:  stmt.ColumnBytes(4),
+			Attempts: stmt.ColumnInt(5),
+		})
+		return nil
+	}, time.Now().Unix(), webhookBatchSize); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var qListDueWebhookDeliveries = dqb.Str(`
+	SELECT
+		d.id,
+		d.webhook,
+		w.url,
+		w.secret,
+		d.payload,
+		d.attempts
+	FROM webhook_deliveries d
+	JOIN webhooks w ON w.id = d.webhook
+	WHERE d.status = 'pending'
+	AND d.next_attempt_time <= :now
+	ORDER BY d.id
+	LIMIT :limit;
+`)
+
+// deliverWebhook sends the due deliveries of a single webhook in order.
+// After a failed attempt the endpoint is backed off:
+// the remaining pending deliveries of the webhook are postponed until the next attempt of the failed one.
+func (d *webhookDispatcher) deliverWebhook(ctx context.Context, webhook int64, dls []webhookDelivery) error {
+	for _, dl := range dls {
+		retry, err := d.deliver(ctx, dl)
+		if err != nil {
+			return err
+		}
+
+		if retry.IsZero() {
+			continue
+		}
+
+		conn, release, err := d.db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		err = sqlitex.Exec(conn, qPostponeWebhookDeliveries(), nil, retry.Unix(), webhook)
+		release()
+		return err
+	}
+
+	return nil
+}
+
+var qPostponeWebhookDeliveries = dqb.Str(`
+	UPDATE webhook_deliveries SET
+		next_attempt_time = max(next_attempt_time, :next_attempt_time)
+	WHERE webhook = :webhook
+	AND status = 'pending';
+`)
+
+// deliver attempts to send the delivery and records the outcome.
+// If the attempt failed, it returns the time until which the webhook must be backed off.
+// Errors are only returned for database failures.
+func (d *webhookDispatcher) deliver(ctx context.Context, dl webhookDelivery) (retry time.Time, err error) {
+	code, sendErr := d.send(ctx, dl)
+	if sendErr != nil && ctx.Err() != nil {
+		return retry, ctx.Err()
+	}
+
+	var (
+		attempts = dl.Attempts + 1
+		now      = time.Now()
+		status   = "delivered"
+		errMsg   string
+		next     = now
+	)
+
+	if sendErr != nil {
+		errMsg = sendErr.Error()
+		retry = now.Add(d.backoff(attempts))
+		if attempts >= d.maxAttempts {
+			status = "failed"
+		} else {
+			status = "pending"
+			next = retry
+		}
+		d.log.Debug("WebhookAttemptFailed", zap.Int64("webhook", dl.Webhook), zap.Int64("delivery", dl.ID), zap.Int("attempts", attempts), zap.Error(sendErr))
+	}
+
+	conn, release, err := d.db.Conn(ctx)
+	if err != nil {
+		return retry, err
+	}
+	defer release()
+
+	return retry, sqlitex.Exec(conn, qUpdateWebhookDelivery(), nil, status, attempts, code, errMsg, now.Unix(), next.Unix(), dl.ID)
+}
+
+var qUpdateWebhookDelivery = dqb.Str(`
+	UPDATE webhook_deliveries SET
+		status = :status,
+		attempts = :attempts,
+		response_code = :code,
+		error = :error,
+		update_time = :update_time,
+		next_attempt_time = :next_attempt_time
+	WHERE id = :id;
+`)
+
+// backoff returns the delay before the next attempt,
+// doubling after each failed attempt up to the max backoff.
+func (d *webhookDispatcher) backoff(attempts int) time.Duration {
+	delay := d.minBackoff
+	for i := 1; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.maxBackoff)
+}
+
+// send the payload to the webhook endpoint.
+// Only 2xx responses are considered successful.
+func (d *webhookDispatcher) send(ctx context.Context, dl webhookDelivery) (code int, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "seed-webhooks")
+	req.Header.Set("X-Seed-Webhook", strconv.FormatInt(dl.Webhook, 10))
+	req.Header.Set("X-Seed-Delivery", strconv.FormatInt(dl.ID, 10))
+	req.Header.Set("X-Seed-Timestamp", ts)
+	req.Header.Set("X-Seed-Signature", "sha256="+webhookSignature(dl.Secret, ts, dl.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Draining the body to reuse the connection.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// webhookSignature returns the hex-encoded HMAC-SHA256 of the timestamp and the payload joined with a dot.
+// Signing the timestamp lets the receivers reject replayed requests.
+func webhookSignature(secret, ts string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,177 @@
+package activity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"seed/backend/core/coretest"
+	activity "seed/backend/genproto/activity/v1alpha"
+	"seed/backend/index"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type webhookRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+func TestWebhooks(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	srv := newTestServer(t, "alice")
+	srv.webhooks.minBackoff = 0
+	srv.webhooks.maxAttempts = 2
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The endpoint fails the first request, and accepts the rest.
+	var calls atomic.Int32
+	reqs := make(chan webhookRequest, 10)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		reqs <- webhookRequest{Header: r.Header, Body: body}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer endpoint.Close()
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+
+	_, err := srv.CreateWebhook(ctx, &activity.CreateWebhookRequest{Url: "ftp://example.com"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.CreateWebhook(ctx, &activity.CreateWebhookRequest{
+		Url:    endpoint.URL,
+		Filter: &activity.WebhookFilter{FilterEventType: []string{"Unknown"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	wh, err := srv.CreateWebhook(ctx, &activity.CreateWebhookRequest{
+		Url:    endpoint.URL,
+		Filter: &activity.WebhookFilter{FilterUsers: []string{alice.Account.Principal().String()}},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, wh.Secret)
+
+	failing, err := srv.CreateWebhook(ctx, &activity.CreateWebhookRequest{Url: broken.URL})
+	require.NoError(t, err)
+
+	list, err := srv.ListWebhooks(ctx, &activity.ListWebhooksRequest{PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, list.Webhooks, 1)
+	require.Equal(t, failing.Id, list.Webhooks[0].Id)
+	require.NotEmpty(t, list.NextPageToken)
+
+	list, err = srv.ListWebhooks(ctx, &activity.ListWebhooksRequest{PageSize: 1, PageToken: list.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, list.Webhooks, 1)
+	require.Equal(t, wh.Id, list.Webhooks[0].Id)
+	require.Equal(t, wh.Filter.FilterUsers, list.Webhooks[0].Filter.FilterUsers)
+	require.Empty(t, list.NextPageToken)
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- srv.DeliverWebhooks(ctx)
+	}()
+
+	idx := srv.blobs.(*index.Index)
+	var want string
+	for _, kp := range []*coretest.Tester{&bob, &alice} {
+		eb, err := index.NewChange(kp.Account, nil, "Create", nil, 1)
+		require.NoError(t, err)
+		blk, err := blocks.NewBlockWithCid(eb.Data, eb.CID)
+		require.NoError(t, err)
+		require.NoError(t, idx.Put(ctx, blk))
+		want = eb.CID.String()
+	}
+
+	// The first attempt fails, and the delivery is retried.
+	var got [2]webhookRequest
+	for i := range got {
+		select {
+		case got[i] = <-reqs:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for the webhook request")
+		}
+	}
+	require.Equal(t, got[0].Body, got[1].Body)
+
+	ts := got[1].Header.Get("X-Seed-Timestamp")
+	require.NotEmpty(t, ts)
+	mac := hmac.New(sha256.New, []byte(wh.Secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(got[1].Body)
+	require.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), got[1].Header.Get("X-Seed-Signature"))
+
+	event := &activity.Event{}
+	require.NoError(t, protojson.Unmarshal(got[1].Body, event))
+	require.Equal(t, want, event.GetNewBlob().Cid)
+	require.Equal(t, alice.Account.Principal().String(), event.Account)
+
+	require.Eventually(t, func() bool {
+		dls, err := srv.ListWebhookDeliveries(ctx, &activity.ListWebhookDeliveriesRequest{WebhookId: wh.Id})
+		require.NoError(t, err)
+		return len(dls.Deliveries) == 1 && dls.Deliveries[0].Status == "delivered"
+	}, 10*time.Second, 10*time.Millisecond)
+
+	dls, err := srv.ListWebhookDeliveries(ctx, &activity.ListWebhookDeliveriesRequest{WebhookId: wh.Id})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), dls.Deliveries[0].Attempts)
+	require.Equal(t, int32(http.StatusOK), dls.Deliveries[0].ResponseCode)
+	require.Equal(t, want, dls.Deliveries[0].Event.GetNewBlob().Cid)
+
+	// Deliveries to the broken endpoint are abandoned after the max number of attempts.
+	require.Eventually(t, func() bool {
+		dls, err := srv.ListWebhookDeliveries(ctx, &activity.ListWebhookDeliveriesRequest{WebhookId: failing.Id})
+		require.NoError(t, err)
+		if len(dls.Deliveries) != 2 {
+			return false
+		}
+		for _, d := range dls.Deliveries {
+			if d.Status != "failed" {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+
+	dls, err = srv.ListWebhookDeliveries(ctx, &activity.ListWebhookDeliveriesRequest{WebhookId: failing.Id})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), dls.Deliveries[0].Attempts)
+	require.Equal(t, int32(http.StatusServiceUnavailable), dls.Deliveries[0].ResponseCode)
+	require.NotEmpty(t, dls.Deliveries[0].Error)
+
+	// Finished deliveries are purged after the retention period.
+	srv.webhooks.retention = 0
+	require.NoError(t, srv.webhooks.purge(ctx))
+	dls, err = srv.ListWebhookDeliveries(ctx, &activity.ListWebhookDeliveriesRequest{WebhookId: wh.Id})
+	require.NoError(t, err)
+	require.Empty(t, dls.Deliveries)
+
+	_, err = srv.DeleteWebhook(ctx, &activity.DeleteWebhookRequest{Id: failing.Id})
+	require.NoError(t, err)
+	_, err = srv.GetWebhook(ctx, &activity.GetWebhookRequest{Id: failing.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = srv.ListWebhookDeliveries(ctx, &activity.ListWebhookDeliveriesRequest{WebhookId: failing.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = srv.DeleteWebhook(ctx, &activity.DeleteWebhookRequest{Id: failing.Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	cancel()
+	require.NoError(t, <-errc)
+}
@@ -6,6 +6,7 @@ import (
 	"seed/backend/core"
 	documents "seed/backend/genproto/documents/v3alpha"
 	"seed/backend/index"
+	"seed/backend/util/apiutil"
 	"seed/backend/util/errutil"
 	"time"
 
@@ -31,8 +32,8 @@ func (srv *Server) CreateCapability(ctx context.Context, in *documents.CreateCap
 			return nil, errutil.MissingArgument("account")
 		}
 
-		if in.NoRecursive {
-			return nil, status.Error(codes.Unimplemented, "TODO: no_recursive is not implemented yet")
+		if _, ok := documents.Role_name[int32(in.Role)]; !ok || in.Role == documents.Role_ROLE_UNSPECIFIED {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid role %s", in.Role)
 		}
 	}
 
@@ -51,18 +52,36 @@ func (srv *Server) CreateCapability(ctx context.Context, in *documents.CreateCap
 		return nil, err
 	}
 
-	// TODO(burdiyan): Implement nested capability delegations when we have roles which allow that.
-	if !acc.Equal(kp.Principal()) {
-		return nil, status.Errorf(codes.PermissionDenied, "signing key %s cannot create capabilities for account %s", kp.Principal(), acc)
-	}
-
 	// TODO(burdiyan): Get rid of this IRI stuff probably, and just make it a validation function.
 	// Still unsure whether we'll keep the idea of a resource and IRI in the database.
-	if _, err := makeIRI(acc, in.Path); err != nil {
+	iri, err := makeIRI(acc, in.Path)
+	if err != nil {
 		return nil, err
 	}
 
-	// TODO(burdiyan): Validate role according to the chain of capabilities.
+	// Editors can delegate lower roles under the path of their own capability.
+	if !acc.Equal(kp.Principal()) {
+		if in.Role == documents.Role_EDITOR {
+			return nil, status.Errorf(codes.PermissionDenied, "only the owner of the account can delegate the %s role", in.Role)
+		}
+
+		// Editors with a non-recursive capability can only delegate non-recursive capabilities,
+		// otherwise the delegate would get access to the subpaths the editor doesn't have access to.
+		var isEditor bool
+		if err := srv.idx.WalkCapabilities(ctx, iri, acc, func(_ cid.Cid, cpb *index.Capability) error {
+			if cpb.Delegate.Equal(kp.Principal()) && cpb.Role == documents.Role_EDITOR.String() && (!cpb.NoRecursive || in.NoRecursive) {
+				isEditor = true
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		if !isEditor {
+			return nil, status.Errorf(codes.PermissionDenied, "signing key %s cannot create capabilities for account %s", kp.Principal(), acc)
+		}
+	}
+
 	role := in.Role.String()
 
 	cpb, err := index.NewCapability(kp, del, acc, in.Path, role, time.Now().UnixMicro(), in.NoRecursive)
@@ -97,16 +116,73 @@ func (srv *Server) GetCapability(ctx context.Context, in *documents.GetCapabilit
 	return capToProto(blk.Cid(), cpb)
 }
 
-// ListCapabilities implements Access Control API.
-func (srv *Server) ListCapabilities(ctx context.Context, in *documents.ListCapabilitiesRequest) (*documents.ListCapabilitiesResponse, error) {
+// RevokeCapability implements Access Control API.
+func (srv *Server) RevokeCapability(ctx context.Context, in *documents.RevokeCapabilityRequest) (*documents.Revocation, error) {
 	{
-		if in.IgnoreInherited {
-			return nil, status.Error(codes.Unimplemented, "TODO: ignore_inherited is not implemented yet, permissions are inherited by default")
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
 		}
 
+		if in.Id == "" {
+			return nil, errutil.MissingArgument("id")
+		}
+	}
+
+	c, err := cid.Decode(in.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse capability ID %s as CID: %v", in.Id, err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	blk, err := srv.idx.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	cpb := &index.Capability{}
+	if err := cbornode.DecodeInto(blk.RawData(), cpb); err != nil {
+		return nil, err
+	}
+
+	if !cpb.Issuer.Equal(kp.Principal()) {
+		return nil, status.Errorf(codes.PermissionDenied, "signing key %s cannot revoke capabilities issued by %s", kp.Principal(), cpb.Issuer)
+	}
+
+	rev, err := index.NewRevocation(kp, c, cpb.Account, cpb.Path, time.Now().UnixMicro())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, rev); err != nil {
+		return nil, err
+	}
+
+	return &documents.Revocation{
+		Id:         rev.CID.String(),
+		Capability: c.String(),
+		Issuer:     rev.Decoded.Author.String(),
+		CreateTime: timestamppb.New(time.UnixMicro(rev.Decoded.Ts)),
+	}, nil
+}
+
+// ListCapabilities implements Access Control API.
+func (srv *Server) ListCapabilities(ctx context.Context, in *documents.ListCapabilitiesRequest) (*documents.ListCapabilitiesResponse, error) {
+	{
 		if in.Account == "" {
 			return nil, errutil.MissingArgument("account")
 		}
+
+		if _, ok := documents.Role_name[int32(in.Role)]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid role %s", in.Role)
+		}
+
+		if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+			return nil, err
+		}
 	}
 
 	acc, err := core.DecodePrincipal(in.Account)
@@ -119,14 +195,62 @@ func (srv *Server) ListCapabilities(ctx context.Context, in *documents.ListCapab
 		return nil, err
 	}
 
-	// TODO(burdiyan): implement pagination.
-	resp := &documents.ListCapabilitiesResponse{}
+	type Cursor struct {
+		ID int64 `json:"i"`
+	}
+
+	var lastCursor Cursor
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &lastCursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	opts := index.ListCapabilitiesOptions{
+		IgnoreInherited: in.IgnoreInherited,
+		Cursor:          lastCursor.ID,
+		Limit:           int(in.PageSize) + 1,
+	}
+
+	if in.Delegate != "" {
+		opts.Delegate, err = core.DecodePrincipal(in.Delegate)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse delegate '%s': %v", in.Delegate, err)
+		}
+	}
+
+	if in.Issuer != "" {
+		opts.Issuer, err = core.DecodePrincipal(in.Issuer)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse issuer '%s': %v", in.Issuer, err)
+		}
+	}
+
+	if in.Role != documents.Role_ROLE_UNSPECIFIED {
+		opts.Role = in.Role.String()
+	}
+
+	resp := &documents.ListCapabilitiesResponse{
+		Capabilities: make([]*documents.Capability, 0, in.PageSize),
+	}
+
+	var count int32
+	if err := srv.idx.ListCapabilities(ctx, iri, acc, opts, func(id int64, c cid.Cid, cpb *index.Capability, effectiveRole string) error {
+		if count == in.PageSize {
+			var err error
+			resp.NextPageToken, err = apiutil.EncodePageToken(lastCursor, nil)
+			return err
+		}
+		count++
+
+		lastCursor.ID = id
 
-	if err := srv.idx.WalkCapabilities(ctx, iri, acc, func(c cid.Cid, cpb *index.Capability) error {
 		pb, err := capToProto(c, cpb)
 		if err != nil {
 			return err
 		}
+		pb.EffectiveRole = documents.Role(documents.Role_value[effectiveRole])
+
 		resp.Capabilities = append(resp.Capabilities, pb)
 		return nil
 	}); err != nil {
@@ -2,10 +2,17 @@ package documents
 
 import (
 	"context"
+	"seed/backend/core"
 	"seed/backend/core/coretest"
 	pb "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/hlc"
+	"seed/backend/index"
+	"seed/backend/util/must"
 	"testing"
+	"time"
 
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -86,3 +93,504 @@ func TestCapabilities_Smoke(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, list.Capabilities, 1, "must return the capability")
 }
+
+func TestRevokeCapability(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+
+	cars, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "/cars",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Document about cars"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	cpb, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        cars.Account,
+		Path:           cars.Path,
+		Role:           pb.Role_WRITER,
+	})
+	require.NoError(t, err)
+
+	jp, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "bob",
+		Capability:     cpb.Id,
+		Account:        cars.Account,
+		Path:           "/cars/jp",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Catalogue of Japanese cars"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = alice.RevokeCapability(ctx, &pb.RevokeCapabilityRequest{SigningKeyName: "bob", Id: cpb.Id})
+	require.Error(t, err, "only the issuer can revoke the capability")
+
+	rev, err := alice.RevokeCapability(ctx, &pb.RevokeCapabilityRequest{SigningKeyName: "main", Id: cpb.Id})
+	require.NoError(t, err)
+	require.Equal(t, cpb.Id, rev.Capability)
+	require.Equal(t, cars.Account, rev.Issuer)
+
+	list, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: cars.Account, Path: "/cars/jp"})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 0, "revoked capabilities must not be listed")
+
+	_, err = alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "bob",
+		Capability:     cpb.Id,
+		Account:        cars.Account,
+		Path:           jp.Path,
+		BaseVersion:    jp.Version,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Changed after revocation"}}},
+		},
+	})
+	require.Error(t, err, "bob must not be allowed to write with a revoked capability")
+
+	// Bob's changes created after the revocation must be ignored even if they get to us.
+	{
+		doc, err := alice.loadDocument(ctx, alice.me.Account.Principal(), jp.Path, "", false)
+		require.NoError(t, err)
+		require.NoError(t, doc.SetMetadata("title", "Changed after revocation"))
+		change, err := doc.Change(bob.Account)
+		require.NoError(t, err)
+		ref, err := doc.Ref(bob.Account)
+		require.NoError(t, err)
+		require.NoError(t, alice.idx.PutMany(ctx, []blocks.Block{change, ref}))
+	}
+
+	got, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: cars.Account, Path: jp.Path})
+	require.NoError(t, err)
+	require.Equal(t, jp.Version, got.Version, "changes made before the revocation must remain")
+	require.Equal(t, "Catalogue of Japanese cars", got.Metadata["title"])
+
+	// Revocations must be honored when they arrive before the capability.
+	{
+		carol := newTestDocsAPI(t, "carol")
+		for _, id := range []string{rev.Id, cpb.Id} {
+			blk, err := alice.idx.Get(ctx, must.Do2(cid.Decode(id)))
+			require.NoError(t, err)
+			require.NoError(t, carol.idx.Put(ctx, blk))
+		}
+
+		revoked, err := carol.idx.IsCapabilityRevoked(ctx, must.Do2(cid.Decode(cpb.Id)))
+		require.NoError(t, err)
+		require.True(t, revoked)
+	}
+}
+
+func TestCapabilityRoles(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+	require.NoError(t, alice.keys.StoreKey(ctx, "carol", carol.Account))
+	account := alice.me.Account.Principal().String()
+
+	// Creates a new document with the given key and capability.
+	write := func(key, capc, path string) (*pb.Document, error) {
+		return alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+			SigningKeyName: key,
+			Capability:     capc,
+			Account:        account,
+			Path:           path,
+			Changes: []*pb.DocumentChange{
+				{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Document " + path}}},
+			},
+		})
+	}
+
+	_, err := write("main", "", "/team")
+	require.NoError(t, err)
+
+	bobCap, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team",
+		Role:           pb.Role_EDITOR,
+	})
+	require.NoError(t, err)
+
+	_, err = write("bob", bobCap.Id, "/team/bob")
+	require.NoError(t, err, "editors must be able to write")
+
+	// Editors can't delegate their own role, or delegate outside of their path.
+	{
+		_, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+			SigningKeyName: "bob",
+			Delegate:       carol.Account.Principal().String(),
+			Account:        account,
+			Path:           "/team/docs",
+			Role:           pb.Role_EDITOR,
+		})
+		require.Error(t, err)
+
+		_, err = alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+			SigningKeyName: "bob",
+			Delegate:       carol.Account.Principal().String(),
+			Account:        account,
+			Path:           "/other",
+			Role:           pb.Role_WRITER,
+		})
+		require.Error(t, err)
+	}
+
+	carolCollab, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       carol.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team",
+		Role:           pb.Role_COLLABORATOR,
+	})
+	require.NoError(t, err)
+
+	_, err = write("carol", carolCollab.Id, "/team/carol")
+	require.Error(t, err, "collaborators must not be able to write")
+
+	carolCap, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "bob",
+		Delegate:       carol.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team/docs",
+		Role:           pb.Role_WRITER,
+	})
+	require.NoError(t, err, "editors must be able to delegate writers under their path")
+	require.Equal(t, bob.Account.Principal().String(), carolCap.Issuer)
+
+	carolDoc, err := write("carol", carolCap.Id, "/team/docs/carol")
+	require.NoError(t, err, "writers delegated by editors must be able to write")
+
+	_, err = write("carol", carolCap.Id, "/team/carol")
+	require.Error(t, err, "writers must not be able to write outside of their path")
+
+	_, err = alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "carol",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team/docs",
+		Role:           pb.Role_WRITER,
+	})
+	require.Error(t, err, "writers must not be able to delegate")
+
+	list, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/docs/carol"})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 3)
+
+	// Revoking the editor capability invalidates the capabilities delegated by the editor.
+	_, err = alice.RevokeCapability(ctx, &pb.RevokeCapabilityRequest{SigningKeyName: "main", Id: bobCap.Id})
+	require.NoError(t, err)
+
+	_, err = write("carol", carolCap.Id, "/team/docs/carol2")
+	require.Error(t, err, "capabilities delegated by revoked editors must not be honored")
+
+	list, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/docs/carol"})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 1)
+	require.Equal(t, carolCollab.Id, list.Capabilities[0].Id)
+
+	got, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account, Path: carolDoc.Path})
+	require.NoError(t, err)
+	require.Equal(t, carolDoc.Version, got.Version, "changes made before the revocation must remain")
+}
+
+func TestCapabilityNoRecursive(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+	require.NoError(t, alice.keys.StoreKey(ctx, "carol", carol.Account))
+	account := alice.me.Account.Principal().String()
+
+	write := func(key, capc, path string) (*pb.Document, error) {
+		return alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+			SigningKeyName: key,
+			Capability:     capc,
+			Account:        account,
+			Path:           path,
+			Changes: []*pb.DocumentChange{
+				{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Document " + path}}},
+			},
+		})
+	}
+
+	_, err := write("main", "", "/team")
+	require.NoError(t, err)
+
+	bobCap, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team/page",
+		Role:           pb.Role_WRITER,
+		NoRecursive:    true,
+	})
+	require.NoError(t, err)
+	require.True(t, bobCap.IsExact)
+
+	carolCap, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       carol.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team",
+		Role:           pb.Role_EDITOR,
+		NoRecursive:    true,
+	})
+	require.NoError(t, err)
+
+	doc, err := write("bob", bobCap.Id, "/team/page")
+	require.NoError(t, err, "non-recursive capabilities must grant access to the exact path")
+
+	_, err = write("bob", bobCap.Id, "/team/page/sub")
+	require.Error(t, err, "non-recursive capabilities must not grant access to subpaths")
+
+	_, err = write("carol", carolCap.Id, "/team/page")
+	require.Error(t, err, "non-recursive capabilities must not grant access to subpaths")
+
+	got, err := alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account, Path: "/team/page"})
+	require.NoError(t, err)
+	require.Equal(t, doc.Version, got.Version)
+
+	// Non-recursive editors can only delegate non-recursive capabilities for their exact path.
+	{
+		_, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+			SigningKeyName: "carol",
+			Delegate:       bob.Account.Principal().String(),
+			Account:        account,
+			Path:           "/team",
+			Role:           pb.Role_WRITER,
+		})
+		require.Error(t, err)
+
+		_, err = alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+			SigningKeyName: "carol",
+			Delegate:       bob.Account.Principal().String(),
+			Account:        account,
+			Path:           "/team/other",
+			Role:           pb.Role_WRITER,
+			NoRecursive:    true,
+		})
+		require.Error(t, err)
+
+		_, err = alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+			SigningKeyName: "carol",
+			Delegate:       bob.Account.Principal().String(),
+			Account:        account,
+			Path:           "/team",
+			Role:           pb.Role_WRITER,
+			NoRecursive:    true,
+		})
+		require.NoError(t, err)
+	}
+
+	list, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/page"})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 1)
+	require.Equal(t, bobCap.Id, list.Capabilities[0].Id)
+
+	list, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/page/sub"})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 0)
+
+	// Inherited capabilities are listed by default, and skipped with ignore_inherited.
+	wideCap, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       carol.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team",
+		Role:           pb.Role_COLLABORATOR,
+	})
+	require.NoError(t, err)
+
+	list, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/page"})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 2)
+
+	list, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/page", IgnoreInherited: true})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 1)
+	require.Equal(t, bobCap.Id, list.Capabilities[0].Id)
+
+	list, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team", IgnoreInherited: true})
+	require.NoError(t, err)
+	require.Len(t, list.Capabilities, 3)
+	require.Equal(t, wideCap.Id, list.Capabilities[2].Id)
+}
+
+func TestListCapabilitiesPagination(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+	account := alice.me.Account.Principal().String()
+
+	_, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team",
+		Role:           pb.Role_COLLABORATOR,
+	})
+	require.NoError(t, err)
+
+	_, err = alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account,
+		Path:           "",
+		Role:           pb.Role_EDITOR,
+	})
+	require.NoError(t, err)
+
+	var delegated []string
+	for range 4 {
+		kp := must.Do2(core.NewKeyPairRandom())
+		c, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+			SigningKeyName: "bob",
+			Delegate:       kp.Principal().String(),
+			Account:        account,
+			Path:           "/team",
+			Role:           pb.Role_WRITER,
+		})
+		require.NoError(t, err)
+		delegated = append(delegated, c.Id)
+	}
+
+	var (
+		all   []*pb.Capability
+		pages int
+		token string
+	)
+	for {
+		resp, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/doc", PageSize: 4, PageToken: token})
+		require.NoError(t, err)
+		pages++
+		all = append(all, resp.Capabilities...)
+		token = resp.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+	require.Equal(t, 2, pages)
+	require.Len(t, all, 6)
+
+	for _, c := range all {
+		if c.Delegate == bob.Account.Principal().String() {
+			require.Equal(t, pb.Role_EDITOR, c.EffectiveRole, "effective role must be the highest role of the delegate")
+		} else {
+			require.Equal(t, pb.Role_WRITER, c.EffectiveRole)
+		}
+	}
+
+	resp, err := alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/doc", Delegate: bob.Account.Principal().String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Capabilities, 2)
+
+	resp, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/doc", Issuer: bob.Account.Principal().String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Capabilities, 4)
+	for i, c := range resp.Capabilities {
+		require.Equal(t, delegated[i], c.Id)
+	}
+
+	resp, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/doc", Role: pb.Role_COLLABORATOR})
+	require.NoError(t, err)
+	require.Len(t, resp.Capabilities, 1)
+	require.Equal(t, pb.Role_EDITOR, resp.Capabilities[0].EffectiveRole)
+
+	resp, err = alice.ListCapabilities(ctx, &pb.ListCapabilitiesRequest{Account: account, Path: "/team/doc", Delegate: coretest.NewTester("david").Account.Principal().String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Capabilities, 0)
+}
+
+func TestDeleteDocumentWithCapability(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+	account := alice.me.Account.Principal()
+
+	var docs []*pb.Document
+	for _, path := range []string{"/cars", "/cars/jp", "/books"} {
+		doc, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+			SigningKeyName: "main",
+			Account:        account.String(),
+			Path:           path,
+			Changes: []*pb.DocumentChange{
+				{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Document " + path}}},
+			},
+		})
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+
+	cpb, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account.String(),
+		Path:           "/cars",
+		Role:           pb.Role_WRITER,
+	})
+	require.NoError(t, err)
+	capc := must.Do2(cid.Decode(cpb.Id))
+
+	// Tombstones from delegates must be authorized by a capability that covers the path.
+	forged := must.Do2(index.NewTombstone(bob.Account, must.Do2(makeIRI(account, "/books")), capc, int64(hlc.FromTime(time.Now()))))
+	require.NoError(t, alice.idx.Put(ctx, forged), "unauthorized tombstones are stored but ignored")
+
+	notCap := must.Do2(cid.Decode(docs[2].Version))
+	forged = must.Do2(index.NewTombstone(bob.Account, must.Do2(makeIRI(account, "/cars/jp")), notCap, int64(hlc.FromTime(time.Now()))))
+	require.NoError(t, alice.idx.Put(ctx, forged), "unauthorized tombstones are stored but ignored")
+
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String(), Path: "/books"})
+	require.NoError(t, err, "document must not be deleted by forged tombstones")
+
+	_, err = alice.DeleteDocument(ctx, &pb.DeleteDocumentRequest{
+		SigningKeyName: "bob",
+		Capability:     cpb.Id,
+		Account:        account.String(),
+		Path:           "/cars/jp",
+	})
+	require.NoError(t, err, "bob must be allowed to delete with the capability")
+
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String(), Path: "/cars/jp"})
+	require.Error(t, err, "deleted document must not be found")
+
+	// Refs from unauthorized authors must not bring the document back.
+	carol := coretest.NewTester("carol")
+	head := must.Do2(cid.Decode(docs[1].Version))
+	ref := must.Do2(index.NewRef(carol.Account, head, must.Do2(makeIRI(account, "/cars/jp")), []cid.Cid{head}, int64(hlc.FromTime(time.Now().Add(time.Hour)))))
+	require.NoError(t, alice.idx.Put(ctx, ref))
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String(), Path: "/cars/jp"})
+	require.Error(t, err, "unauthorized refs must not undo the deletion")
+
+	// Tombstones can arrive before the capability that authorizes them.
+	lateCap := must.Do2(index.NewCapability(alice.me.Account, carol.Account.Principal(), account, "/books", "WRITER", time.Now().UnixMicro(), false))
+	tomb := must.Do2(index.NewTombstone(carol.Account, must.Do2(makeIRI(account, "/books")), lateCap.CID, int64(hlc.FromTime(time.Now()))))
+	require.NoError(t, alice.idx.Put(ctx, tomb))
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String(), Path: "/books"})
+	require.NoError(t, err, "tombstone must not be applied without the capability")
+
+	require.NoError(t, alice.idx.Put(ctx, lateCap))
+	_, err = alice.GetDocument(ctx, &pb.GetDocumentRequest{Account: account.String(), Path: "/books"})
+	require.Error(t, err, "tombstone must be applied when the capability arrives")
+}
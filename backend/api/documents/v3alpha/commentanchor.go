@@ -0,0 +1,353 @@
+package documents
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/index"
+	"slices"
+	"unicode/utf16"
+
+	"github.com/ipfs/go-cid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateCommentAnchor checks that the anchor points to an existing block of the document,
+// and that the spans are within the block text.
+func validateCommentAnchor(doc *documents.Document, anchor *documents.CommentAnchor) error {
+	if anchor.BlockId == "" {
+		return status.Errorf(codes.InvalidArgument, "comment anchor must have block ID")
+	}
+
+	if len(anchor.Starts) != len(anchor.Ends) {
+		return status.Errorf(codes.InvalidArgument, "comment anchor must have the same number of starts and ends")
+	}
+
+	_, blocks := flattenBlocks(doc.Content)
+	blk, ok := blocks[anchor.BlockId]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "block '%s' is not found in version '%s'", anchor.BlockId, doc.Version)
+	}
+
+	size := int32(len(utf16.Encode([]rune(blk.Block.Text))))
+	var prevEnd int32
+	for i := range anchor.Starts {
+		start, end := anchor.Starts[i], anchor.Ends[i]
+		if start < prevEnd || start >= end || end > size {
+			return status.Errorf(codes.InvalidArgument, "invalid comment anchor span [%d, %d) for block '%s' of %d characters", start, end, anchor.BlockId, size)
+		}
+		prevEnd = end
+	}
+
+	return nil
+}
+
+// commentAnchorMapper maps comment anchors to a version of the document.
+type commentAnchorMapper struct {
+	ctx    context.Context
+	srv    *Server
+	entity *docmodel.Entity
+	blocks map[string]flatBlock
+
+	// Blocks at the versions the comments were created at.
+	baseBlocks map[docmodel.Version]map[string]flatBlock
+
+	// Changes that are not part of the versions the comments were created at, ordered by timestamps.
+	changesSince map[docmodel.Version][]index.ChangeRecord
+}
+
+func newCommentAnchorMapper(ctx context.Context, srv *Server, doc *docmodel.Document) (*commentAnchorMapper, error) {
+	m := &commentAnchorMapper{
+		ctx:          ctx,
+		srv:          srv,
+		entity:       doc.Entity(),
+		baseBlocks:   make(map[docmodel.Version]map[string]flatBlock),
+		changesSince: make(map[docmodel.Version][]index.ChangeRecord),
+	}
+
+	docpb, err := m.hydrate(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	_, m.blocks = flattenBlocks(docpb.Content)
+
+	return m, nil
+}
+
+// hydrate the document, reusing the hydrated documents cached by the server.
+// The returned document must not be modified.
+func (m *commentAnchorMapper) hydrate(doc *docmodel.Document) (*documents.Document, error) {
+	key := docCacheKey(m.entity.ID(), doc.Entity().Version().String())
+	if docpb, ok := m.srv.docCache.Get(key); ok {
+		return docpb, nil
+	}
+
+	docpb, err := doc.Hydrate(m.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m.srv.docCache.Add(key, proto.Clone(docpb).(*documents.Document))
+
+	return docpb, nil
+}
+
+// Map the anchor created at the base version to the version of the mapper.
+// The text of the anchored block is re-mapped across all the changes that replaced the block
+// since the base version, in causal order. Anchors are orphaned when the block is deleted,
+// or when the anchored text is removed entirely.
+func (m *commentAnchorMapper) Map(anchor *documents.CommentAnchor, base []cid.Cid) (*documents.CommentAnchor, error) {
+	out := &documents.CommentAnchor{
+		BlockId: anchor.BlockId,
+		Starts:  slices.Clone(anchor.Starts),
+		Ends:    slices.Clone(anchor.Ends),
+	}
+
+	final, ok := m.blocks[anchor.BlockId]
+	if !ok {
+		out.IsOrphaned = true
+		return out, nil
+	}
+
+	baseBlocks, err := m.blocksAt(base)
+	if err != nil {
+		return nil, err
+	}
+
+	// The base version is not part of the history of the requested version.
+	if baseBlocks == nil {
+		out.IsOrphaned = true
+		return out, nil
+	}
+
+	baseBlock, ok := baseBlocks[anchor.BlockId]
+	if !ok {
+		out.IsOrphaned = true
+		return out, nil
+	}
+
+	texts, err := m.blockTextsSince(base, anchor.BlockId)
+	if err != nil {
+		return nil, err
+	}
+	texts = append(texts, final.Block.Text)
+
+	cur := utf16.Encode([]rune(baseBlock.Block.Text))
+	for _, text := range texts {
+		next := utf16.Encode([]rune(text))
+		if slices.Equal(cur, next) {
+			continue
+		}
+
+		starts, ends, ok := remapSpans(cur, next, out.Starts, out.Ends)
+		if !ok {
+			out.IsOrphaned = true
+			return out, nil
+		}
+		out.Starts, out.Ends = starts, ends
+		cur = next
+	}
+
+	return out, nil
+}
+
+// blocksAt returns the blocks of the document at the given version.
+// It returns nil if the version is not part of the history of the document.
+func (m *commentAnchorMapper) blocksAt(heads []cid.Cid) (map[string]flatBlock, error) {
+	ver := docmodel.NewVersion(heads...)
+	if blocks, ok := m.baseBlocks[ver]; ok {
+		return blocks, nil
+	}
+
+	entity, err := m.entity.Checkout(heads)
+	if err != nil {
+		if errors.Is(err, docmodel.ErrHeadNotFound) {
+			m.baseBlocks[ver] = nil
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to checkout version '%s': %w", ver, err)
+	}
+
+	doc, err := docmodel.New(entity, entity.NextTimestamp())
+	if err != nil {
+		return nil, err
+	}
+
+	docpb, err := m.hydrate(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	_, blocks := flattenBlocks(docpb.Content)
+	m.baseBlocks[ver] = blocks
+
+	return blocks, nil
+}
+
+// blockTextsSince returns the successive texts of the block set by the changes
+// that are not part of the base version, ordered by their timestamps.
+func (m *commentAnchorMapper) blockTextsSince(base []cid.Cid, block string) ([]string, error) {
+	changes, err := m.changesSinceVersion(base)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, rec := range changes {
+		text, ok, err := blockTextFromPayload(rec.Data.Payload, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block '%s' from change %s: %w", block, rec.CID, err)
+		}
+		if ok {
+			out = append(out, text)
+		}
+	}
+
+	return out, nil
+}
+
+// changesSinceVersion returns the changes that are not part of the base version, ordered by their timestamps.
+func (m *commentAnchorMapper) changesSinceVersion(base []cid.Cid) ([]index.ChangeRecord, error) {
+	ver := docmodel.NewVersion(base...)
+	if changes, ok := m.changesSince[ver]; ok {
+		return changes, nil
+	}
+
+	covered := make(map[cid.Cid]struct{})
+	ancestors, err := m.entity.BFTDeps(base)
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range ancestors {
+		covered[rec.CID] = struct{}{}
+	}
+
+	all, err := m.entity.BFTDeps(slices.Collect(maps.Keys(m.entity.Heads())))
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []index.ChangeRecord
+	for _, rec := range all {
+		if _, ok := covered[rec.CID]; ok {
+			continue
+		}
+		changes = append(changes, rec)
+	}
+
+	slices.SortFunc(changes, func(a, b index.ChangeRecord) int {
+		return cmp.Compare(a.Data.Ts, b.Data.Ts)
+	})
+
+	m.changesSince[ver] = changes
+
+	return changes, nil
+}
+
+// blockTextFromPayload returns the text of the block if it's replaced by the change payload.
+func blockTextFromPayload(payload map[string]any, block string) (text string, ok bool, err error) {
+	blocks, _ := payload["blocks"].(map[string]any)
+	if blocks == nil {
+		return "", false, nil
+	}
+
+	blk, _ := blocks[block].(map[string]any)
+	if blk == nil {
+		return "", false, nil
+	}
+
+	v, ok := blk["#map"]
+	if !ok {
+		return "", false, nil
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		return "", false, fmt.Errorf("unexpected block value type %T", v)
+	}
+
+	text, _ = m["text"].(string)
+	return text, true, nil
+}
+
+// remapSpans maps the spans from the old text to the new text.
+// The texts are compared by trimming their common prefix and suffix,
+// which is enough for the typical edits that touch a single region of text.
+// Starts inside the changed region move past the replacement, and ends move before it,
+// so spans only keep the text that survived the edit.
+// It returns false if the text of any of the spans was removed entirely.
+func remapSpans(old, new []uint16, starts, ends []int32) (newStarts, newEnds []int32, ok bool) {
+	var prefix int
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+
+	var suffix int
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+
+	var (
+		oldEnd = int32(len(old) - suffix)
+		newEnd = int32(len(new) - suffix)
+		delta  = int32(len(new) - len(old))
+		pre    = int32(prefix)
+	)
+
+	// Whole-block anchors have no spans, so they survive any edit.
+	if len(starts) == 0 {
+		return starts, ends, true
+	}
+
+	newStarts = make([]int32, len(starts))
+	newEnds = make([]int32, len(ends))
+	for i := range starts {
+		// The anchored text was entirely replaced.
+		if starts[i] >= pre && ends[i] <= oldEnd {
+			return nil, nil, false
+		}
+
+		switch s := starts[i]; {
+		case s <= pre:
+			newStarts[i] = s
+		case s >= oldEnd:
+			newStarts[i] = s + delta
+		default:
+			newStarts[i] = newEnd
+		}
+
+		switch e := ends[i]; {
+		case e <= pre:
+			newEnds[i] = e
+		case e >= oldEnd:
+			newEnds[i] = e + delta
+		default:
+			newEnds[i] = pre
+		}
+
+		if newStarts[i] >= newEnds[i] {
+			return nil, nil, false
+		}
+	}
+
+	return newStarts, newEnds, true
+}
+
+func commentAnchorToProto(t index.CommentTarget) *documents.CommentAnchor {
+	if t.Block == "" {
+		return nil
+	}
+
+	return &documents.CommentAnchor{
+		BlockId: t.Block,
+		Starts:  t.Starts,
+		Ends:    t.Ends,
+	}
+}
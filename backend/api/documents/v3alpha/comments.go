@@ -9,6 +9,7 @@ import (
 	documents "seed/backend/genproto/documents/v3alpha"
 	"seed/backend/hlc"
 	"seed/backend/index"
+	"seed/backend/util/apiutil"
 	"seed/backend/util/errutil"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -25,10 +27,6 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 		return nil, errutil.MissingArgument("signing_key")
 	}
 
-	if in.Capability != "" {
-		return nil, status.Errorf(codes.Unimplemented, "TODO: comments with capabilities are not supported yet")
-	}
-
 	if in.TargetVersion == "" {
 		return nil, errutil.MissingArgument("target_version")
 	}
@@ -48,6 +46,18 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target account: %v", err)
 	}
 
+	var capc cid.Cid
+	if in.Capability != "" {
+		capc, err = cid.Decode(in.Capability)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse capability '%s': %v", in.Capability, err)
+		}
+
+		if err := srv.checkCommentAccess(ctx, acc, in.TargetPath, kp, capc); err != nil {
+			return nil, err
+		}
+	}
+
 	clock := hlc.NewClock()
 
 	var (
@@ -90,7 +100,27 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 		Version: versionHeads,
 	}
 
-	blob, err := index.NewComment(kp, cid.Undef, target, threadRoot, replyParent, commentContentFromProto(in.Content), int64(clock.MustNow()))
+	if in.Anchor != nil {
+		doc, err := srv.loadDocument(ctx, acc, in.TargetPath, docmodel.Version(in.TargetVersion), false)
+		if err != nil {
+			return nil, err
+		}
+
+		docpb, err := doc.Hydrate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateCommentAnchor(docpb, in.Anchor); err != nil {
+			return nil, err
+		}
+
+		target.Block = in.Anchor.BlockId
+		target.Starts = in.Anchor.Starts
+		target.Ends = in.Anchor.Ends
+	}
+
+	blob, err := index.NewComment(kp, capc, target, threadRoot, replyParent, commentContentFromProto(in.Content), int64(clock.MustNow()))
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +132,45 @@ func (srv *Server) CreateComment(ctx context.Context, in *documents.CreateCommen
 	return srv.GetComment(ctx, &documents.GetCommentRequest{Id: blob.CID.String()})
 }
 
+// checkCommentAccess checks whether the capability allows the key to comment on the target document.
+// Any valid capability for the target path is enough for commenting, regardless of its role.
+func (srv *Server) checkCommentAccess(ctx context.Context, account core.Principal, path string, kp core.KeyPair, capc cid.Cid) error {
+	blk, err := srv.idx.Get(ctx, capc)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "capability %s not found: %v", capc, err)
+	}
+
+	cpb := &index.Capability{}
+	if err := cbornode.DecodeInto(blk.RawData(), cpb); err != nil {
+		return err
+	}
+
+	if !cpb.Account.Equal(account) {
+		return status.Errorf(codes.PermissionDenied, "capability %s is not from account %s", capc, account)
+	}
+
+	if !cpb.Delegate.Equal(kp.Principal()) {
+		return status.Errorf(codes.PermissionDenied, "capability %s is not delegated to key %s", capc, kp.Principal())
+	}
+
+	iri, err := makeIRI(account, path)
+	if err != nil {
+		return err
+	}
+
+	// Walking the capabilities takes care of the path, revocations, and the validity of the delegation chain.
+	valid, err := srv.isValidCapability(ctx, iri, account, capc)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
+		return status.Errorf(codes.PermissionDenied, "capability %s doesn't grant access to '%s'", capc, iri)
+	}
+
+	return nil
+}
+
 // GetComment implements Comments API.
 func (srv *Server) GetComment(ctx context.Context, in *documents.GetCommentRequest) (*documents.Comment, error) {
 	c, err := cid.Decode(in.Id)
@@ -109,21 +178,110 @@ func (srv *Server) GetComment(ctx context.Context, in *documents.GetCommentReque
 		return nil, status.Errorf(codes.InvalidArgument, "failed to parse comment ID %s as CID: %v", in.Id, err)
 	}
 
-	blk, err := srv.idx.Get(ctx, c)
+	original, rec, err := srv.idx.GetComment(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return commentToProto(original, rec)
+}
+
+// UpdateComment implements Comments API.
+func (srv *Server) UpdateComment(ctx context.Context, in *documents.UpdateCommentRequest) (*documents.Comment, error) {
+	{
+		if in.Id == "" {
+			return nil, errutil.MissingArgument("id")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+
+		if len(in.Content) == 0 {
+			return nil, errutil.MissingArgument("content")
+		}
+	}
+
+	original, err := srv.createCommentVersion(ctx, in.Id, in.SigningKeyName, commentContentFromProto(in.Content), false)
 	if err != nil {
 		return nil, err
 	}
 
-	cp := &index.Comment{}
-	if err := cbornode.DecodeInto(blk.RawData(), cp); err != nil {
+	return srv.GetComment(ctx, &documents.GetCommentRequest{Id: original.String()})
+}
+
+// DeleteComment implements Comments API.
+func (srv *Server) DeleteComment(ctx context.Context, in *documents.DeleteCommentRequest) (*emptypb.Empty, error) {
+	{
+		if in.Id == "" {
+			return nil, errutil.MissingArgument("id")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+	}
+
+	if _, err := srv.createCommentVersion(ctx, in.Id, in.SigningKeyName, nil, true); err != nil {
 		return nil, err
 	}
 
-	return commentToProto(c, cp)
+	return &emptypb.Empty{}, nil
+}
+
+// createCommentVersion creates a new version of the comment, replacing its content, or marking it as deleted.
+// It returns the CID of the original comment.
+func (srv *Server) createCommentVersion(ctx context.Context, id, signingKeyName string, body []index.CommentBlock, deleted bool) (original cid.Cid, err error) {
+	c, err := cid.Decode(id)
+	if err != nil {
+		return original, status.Errorf(codes.InvalidArgument, "failed to parse comment ID %s as CID: %v", id, err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, signingKeyName)
+	if err != nil {
+		return original, err
+	}
+
+	original, rec, err := srv.idx.GetComment(ctx, c)
+	if err != nil {
+		return original, err
+	}
+
+	if !rec.Comment.Author.Equal(kp.Principal()) {
+		return original, status.Errorf(codes.PermissionDenied, "only the author of the comment %s can modify it", original)
+	}
+
+	if rec.Comment.Deleted {
+		return original, status.Errorf(codes.FailedPrecondition, "comment %s is already deleted", original)
+	}
+
+	clock := hlc.NewClock()
+	if err := clock.Track(hlc.Timestamp(rec.Comment.Ts)); err != nil {
+		return original, err
+	}
+
+	blob, err := index.NewCommentVersion(kp, original, rec.Comment, body, deleted, int64(clock.MustNow()))
+	if err != nil {
+		return original, err
+	}
+
+	if err := srv.idx.Put(ctx, blob); err != nil {
+		return original, err
+	}
+
+	return original, nil
 }
 
 // ListComments implements Comments API.
 func (srv *Server) ListComments(ctx context.Context, in *documents.ListCommentsRequest) (*documents.ListCommentsResponse, error) {
+	// Comments are only paginated when requested explicitly.
+	paginate := in.PageSize != 0 || in.PageToken != ""
+	if paginate {
+		if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+			return nil, err
+		}
+	}
+
 	acc, err := core.DecodePrincipal(in.TargetAccount)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target account '%s': %v", in.TargetAccount, err)
@@ -134,13 +292,81 @@ func (srv *Server) ListComments(ctx context.Context, in *documents.ListCommentsR
 		return nil, status.Errorf(codes.InvalidArgument, "failed to parse target path '%s': %v", in.TargetPath, err)
 	}
 
-	// TODO(burdiyan): implement pagination.
+	var authors index.CommentAuthors
+	switch in.AuthorFilter {
+	case documents.CommentAuthorFilter_COMMENT_AUTHOR_FILTER_UNSPECIFIED:
+		authors = index.CommentAuthorsAll
+	case documents.CommentAuthorFilter_MEMBERS:
+		authors = index.CommentAuthorsMembers
+	case documents.CommentAuthorFilter_PUBLIC:
+		authors = index.CommentAuthorsPublic
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "invalid author filter %s", in.AuthorFilter)
+	}
+
+	type Cursor struct {
+		Ts int64 `json:"t"`
+		ID int64 `json:"i"`
+	}
+
+	var lastCursor Cursor
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &lastCursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	lc := &commentLister{srv: srv, owner: acc}
+	if in.AnchorVersion != "" {
+		doc, err := srv.loadDocument(ctx, acc, in.TargetPath, docmodel.Version(in.AnchorVersion), false)
+		if err != nil {
+			return nil, err
+		}
+
+		lc.anchors, err = newCommentAnchorMapper(ctx, srv, doc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	resp := &documents.ListCommentsResponse{}
 
-	var outErr error
-	comments, check := srv.idx.IterComments(ctx, iri)
-	for c, cp := range comments {
-		pb, err := commentToProto(c, cp)
+	var limit int
+	if paginate {
+		limit = int(in.PageSize) + 1
+	}
+
+	var (
+		count  int32
+		outErr error
+	)
+	comments, check := srv.idx.IterComments(ctx, iri, acc, index.IterCommentsOptions{
+		Authors:   authors,
+		RootsOnly: in.Threaded,
+		CursorTs:  lastCursor.Ts,
+		CursorID:  lastCursor.ID,
+		Limit:     limit,
+	})
+	for c, rec := range comments {
+		if paginate && count == in.PageSize {
+			resp.NextPageToken, outErr = apiutil.EncodePageToken(lastCursor, nil)
+			break
+		}
+		count++
+
+		lastCursor = Cursor{Ts: rec.CreateTime.UnixMicro(), ID: rec.ID}
+
+		if in.Threaded {
+			thread, err := lc.thread(ctx, iri, c, rec)
+			if err != nil {
+				outErr = err
+				break
+			}
+			resp.Threads = append(resp.Threads, thread)
+			continue
+		}
+
+		pb, err := lc.comment(c, rec)
 		if err != nil {
 			outErr = err
 			break
@@ -155,7 +381,160 @@ func (srv *Server) ListComments(ctx context.Context, in *documents.ListCommentsR
 	return resp, nil
 }
 
-func commentToProto(c cid.Cid, cmt *index.Comment) (*documents.Comment, error) {
+// ResolveThread implements Comments API.
+func (srv *Server) ResolveThread(ctx context.Context, in *documents.ResolveThreadRequest) (*documents.CommentThread, error) {
+	{
+		if in.ThreadRoot == "" {
+			return nil, errutil.MissingArgument("thread_root")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+	}
+
+	c, err := cid.Decode(in.ThreadRoot)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse thread root '%s' as CID: %v", in.ThreadRoot, err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	root, rec, err := srv.idx.GetComment(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.Comment.ThreadRoot.Defined() {
+		return nil, status.Errorf(codes.InvalidArgument, "comment %s is a reply, and not the root of a thread", root)
+	}
+
+	target := rec.Comment.Target
+	if !kp.Principal().Equal(target.Account) && !kp.Principal().Equal(rec.Comment.Author) {
+		return nil, status.Errorf(codes.PermissionDenied, "only the owner of the document or the author of the thread can resolve it")
+	}
+
+	state, err := srv.idx.GetThreadState(ctx, root, target.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	clock := hlc.NewClock()
+	if err := clock.Track(hlc.Timestamp(rec.Comment.Ts)); err != nil {
+		return nil, err
+	}
+
+	if !state.Time.IsZero() {
+		if err := clock.Track(hlc.Timestamp(state.Time.UnixMicro())); err != nil {
+			return nil, err
+		}
+	}
+
+	blob, err := index.NewThreadResolution(kp, root, target.Account, target.Path, in.Resolved, int64(clock.MustNow()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, blob); err != nil {
+		return nil, err
+	}
+
+	iri, err := index.NewIRI(target.Account, target.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	lc := &commentLister{srv: srv, owner: target.Account}
+	return lc.thread(ctx, iri, root, rec)
+}
+
+// commentLister converts comments and threads into their API representation.
+type commentLister struct {
+	srv     *Server
+	owner   core.Principal
+	anchors *commentAnchorMapper
+}
+
+func (lc *commentLister) comment(c cid.Cid, rec index.CommentRecord) (*documents.Comment, error) {
+	pb, err := commentToProto(c, rec)
+	if err != nil {
+		return nil, err
+	}
+
+	if lc.anchors != nil && pb.Anchor != nil {
+		pb.Anchor, err = lc.anchors.Map(pb.Anchor, rec.Comment.Target.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pb, nil
+}
+
+// thread loads the replies and the resolution state of the thread with the given root comment.
+func (lc *commentLister) thread(ctx context.Context, iri index.IRI, root cid.Cid, rec index.CommentRecord) (*documents.CommentThread, error) {
+	rootpb, err := lc.comment(root, rec)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &documents.CommentThread{Root: rootpb}
+
+	// Replies come ordered by their creation time,
+	// so we only need to group them by their reply parents to get the sibling order.
+	var all []*documents.Comment
+	children := make(map[string][]*documents.Comment)
+	replies, check := lc.srv.idx.IterComments(ctx, iri, lc.owner, index.IterCommentsOptions{ThreadRoot: root})
+	for c, rec := range replies {
+		pb, err := lc.comment(c, rec)
+		if err != nil {
+			return nil, errors.Join(err, check())
+		}
+		all = append(all, pb)
+		children[pb.ReplyParent] = append(children[pb.ReplyParent], pb)
+	}
+	if err := check(); err != nil {
+		return nil, err
+	}
+	out.ReplyCount = int32(len(all))
+
+	visited := make(map[string]struct{}, len(all))
+	var walk func(parent string)
+	walk = func(parent string) {
+		for _, pb := range children[parent] {
+			out.Replies = append(out.Replies, pb)
+			visited[pb.Id] = struct{}{}
+			walk(pb.Id)
+		}
+	}
+	walk(rootpb.Id)
+
+	// Replies to comments we don't have yet are put at the end.
+	for _, pb := range all {
+		if _, ok := visited[pb.Id]; !ok {
+			out.Replies = append(out.Replies, pb)
+		}
+	}
+
+	state, err := lc.srv.idx.GetThreadState(ctx, root, lc.owner)
+	if err != nil {
+		return nil, err
+	}
+
+	out.IsResolved = state.Resolved
+	if state.Author != nil {
+		out.ResolvedBy = state.Author.String()
+		out.ResolveTime = timestamppb.New(state.Time)
+	}
+
+	return out, nil
+}
+
+func commentToProto(c cid.Cid, rec index.CommentRecord) (*documents.Comment, error) {
+	cmt := rec.Comment
 	pb := &documents.Comment{
 		Id:            c.String(),
 		TargetAccount: cmt.Target.Account.String(),
@@ -163,7 +542,13 @@ func commentToProto(c cid.Cid, cmt *index.Comment) (*documents.Comment, error) {
 		TargetVersion: docmodel.NewVersion(cmt.Target.Version...).String(),
 		Author:        cmt.Author.String(),
 		Content:       commentContentToProto(cmt.Body),
-		CreateTime:    timestamppb.New(time.UnixMicro(cmt.Ts)),
+		CreateTime:    timestamppb.New(rec.CreateTime),
+		IsDeleted:     cmt.Deleted,
+		Anchor:        commentAnchorToProto(cmt.Target),
+	}
+
+	if rec.IsEdited() {
+		pb.EditTime = timestamppb.New(time.UnixMicro(cmt.Ts))
 	}
 
 	if cmt.ReplyParent.Defined() {
@@ -174,6 +559,10 @@ func commentToProto(c cid.Cid, cmt *index.Comment) (*documents.Comment, error) {
 		pb.ThreadRoot = cmt.ThreadRoot.String()
 	}
 
+	if cmt.Capability.Defined() {
+		pb.Capability = cmt.Capability.String()
+	}
+
 	if pb.ThreadRoot != "" && pb.ReplyParent == "" {
 		pb.ReplyParent = pb.ThreadRoot
 	}
@@ -6,6 +6,7 @@ import (
 	pb "seed/backend/genproto/documents/v3alpha"
 	"seed/backend/testutil"
 	"seed/backend/util/debugx"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -92,3 +93,416 @@ func TestComments_Smoke(t *testing.T) {
 
 	debugx.Dump(list)
 }
+
+func TestCommentsWithCapability(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+	require.NoError(t, alice.keys.StoreKey(ctx, "carol", carol.Account))
+	account := alice.me.Account.Principal().String()
+
+	doc, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/team/doc",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Team Document"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	bobCap, err := alice.CreateCapability(ctx, &pb.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account,
+		Path:           "/team",
+		Role:           pb.Role_COLLABORATOR,
+	})
+	require.NoError(t, err)
+
+	comment := func(key, capc, text string) (*pb.Comment, error) {
+		return alice.CreateComment(ctx, &pb.CreateCommentRequest{
+			SigningKeyName: key,
+			Capability:     capc,
+			TargetAccount:  account,
+			TargetPath:     doc.Path,
+			TargetVersion:  doc.Version,
+			Content: []*pb.BlockNode{
+				{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: text}},
+			},
+		})
+	}
+
+	ownerCmt, err := comment("main", "", "Owner comment")
+	require.NoError(t, err)
+
+	bobCmt, err := comment("bob", bobCap.Id, "Member comment")
+	require.NoError(t, err, "collaborators must be able to comment with their capability")
+	require.Equal(t, bobCap.Id, bobCmt.Capability)
+
+	carolCmt, err := comment("carol", "", "Public comment")
+	require.NoError(t, err)
+
+	_, err = comment("carol", bobCap.Id, "Stolen capability")
+	require.Error(t, err, "capabilities must only be used by their delegates")
+
+	_, err = alice.CreateComment(ctx, &pb.CreateCommentRequest{
+		SigningKeyName: "bob",
+		Capability:     bobCap.Id,
+		TargetAccount:  account,
+		TargetPath:     "",
+		TargetVersion:  doc.Version,
+		Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: "Outside"}}},
+	})
+	require.Error(t, err, "capabilities must not be used outside of their path")
+
+	listIDs := func(filter pb.CommentAuthorFilter) []string {
+		list, err := alice.ListComments(ctx, &pb.ListCommentsRequest{
+			TargetAccount: account,
+			TargetPath:    doc.Path,
+			AuthorFilter:  filter,
+		})
+		require.NoError(t, err)
+		var out []string
+		for _, c := range list.Comments {
+			out = append(out, c.Id)
+		}
+		return out
+	}
+
+	require.Equal(t, []string{ownerCmt.Id, bobCmt.Id, carolCmt.Id}, listIDs(pb.CommentAuthorFilter_COMMENT_AUTHOR_FILTER_UNSPECIFIED))
+	require.Equal(t, []string{ownerCmt.Id, bobCmt.Id}, listIDs(pb.CommentAuthorFilter_MEMBERS))
+	require.Equal(t, []string{carolCmt.Id}, listIDs(pb.CommentAuthorFilter_PUBLIC))
+
+	// Comments created before the revocation remain authorized.
+	_, err = alice.RevokeCapability(ctx, &pb.RevokeCapabilityRequest{SigningKeyName: "main", Id: bobCap.Id})
+	require.NoError(t, err)
+
+	_, err = comment("bob", bobCap.Id, "After revocation")
+	require.Error(t, err, "revoked capabilities must not allow commenting")
+
+	require.Equal(t, []string{ownerCmt.Id, bobCmt.Id}, listIDs(pb.CommentAuthorFilter_MEMBERS))
+}
+
+func TestCommentsUpdateDelete(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+	account := alice.me.Account.Principal().String()
+
+	doc, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Alice's Home Page"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	cmt, err := alice.CreateComment(ctx, &pb.CreateCommentRequest{
+		SigningKeyName: "bob",
+		TargetAccount:  account,
+		TargetVersion:  doc.Version,
+		Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: "Helo, Alice!"}}},
+	})
+	require.NoError(t, err)
+	require.Nil(t, cmt.EditTime, "new comments must not have edit time")
+
+	reply, err := alice.CreateComment(ctx, &pb.CreateCommentRequest{
+		SigningKeyName: "main",
+		TargetAccount:  account,
+		TargetVersion:  doc.Version,
+		ReplyParent:    cmt.Id,
+		Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hi, Bob!"}}},
+	})
+	require.NoError(t, err)
+
+	_, err = alice.UpdateComment(ctx, &pb.UpdateCommentRequest{
+		Id:             cmt.Id,
+		SigningKeyName: "main",
+		Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hijacked"}}},
+	})
+	require.Error(t, err, "only the author must be able to update the comment")
+
+	updated, err := alice.UpdateComment(ctx, &pb.UpdateCommentRequest{
+		Id:             cmt.Id,
+		SigningKeyName: "bob",
+		Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello, Alice!"}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, cmt.Id, updated.Id, "updated comment must keep the original ID")
+	require.Equal(t, "Hello, Alice!", updated.Content[0].Block.Text)
+	require.Equal(t, cmt.CreateTime.AsTime(), updated.CreateTime.AsTime())
+	require.NotNil(t, updated.EditTime)
+	require.True(t, updated.EditTime.AsTime().After(cmt.CreateTime.AsTime()))
+
+	got, err := alice.GetComment(ctx, &pb.GetCommentRequest{Id: cmt.Id})
+	require.NoError(t, err)
+	testutil.StructsEqual(updated, got).Compare(t, "get comment must resolve to the latest version")
+
+	_, err = alice.DeleteComment(ctx, &pb.DeleteCommentRequest{Id: cmt.Id, SigningKeyName: "main"})
+	require.Error(t, err, "only the author must be able to delete the comment")
+
+	_, err = alice.DeleteComment(ctx, &pb.DeleteCommentRequest{Id: cmt.Id, SigningKeyName: "bob"})
+	require.NoError(t, err)
+
+	_, err = alice.UpdateComment(ctx, &pb.UpdateCommentRequest{
+		Id:             cmt.Id,
+		SigningKeyName: "bob",
+		Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: "Back again"}}},
+	})
+	require.Error(t, err, "deleted comments must not be updated")
+
+	list, err := alice.ListComments(ctx, &pb.ListCommentsRequest{TargetAccount: account})
+	require.NoError(t, err)
+	require.Len(t, list.Comments, 2, "deleted comments must be kept as tombstones")
+
+	tombstone := list.Comments[0]
+	require.Equal(t, cmt.Id, tombstone.Id)
+	require.True(t, tombstone.IsDeleted)
+	require.Len(t, tombstone.Content, 0)
+	require.Equal(t, cmt.Author, tombstone.Author)
+
+	testutil.StructsEqual(reply, list.Comments[1]).Compare(t, "replies to deleted comments must remain in the thread")
+}
+
+func TestCommentAnchors(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal().String()
+
+	v1, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/review",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello world, this is Seed"}}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b2", LeftSibling: "b1"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b2", Type: "paragraph", Text: "Second block"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	comment := func(anchor *pb.CommentAnchor) (*pb.Comment, error) {
+		return alice.CreateComment(ctx, &pb.CreateCommentRequest{
+			SigningKeyName: "main",
+			TargetAccount:  account,
+			TargetPath:     v1.Path,
+			TargetVersion:  v1.Version,
+			Anchor:         anchor,
+			Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "c1", Type: "paragraph", Text: "Comment"}}},
+		})
+	}
+
+	_, err = comment(&pb.CommentAnchor{BlockId: "missing"})
+	require.Error(t, err, "anchors must point to existing blocks")
+
+	_, err = comment(&pb.CommentAnchor{BlockId: "b2", Starts: []int32{0}, Ends: []int32{100}})
+	require.Error(t, err, "anchors must be within the block text")
+
+	world, err := comment(&pb.CommentAnchor{BlockId: "b1", Starts: []int32{6}, Ends: []int32{11}})
+	require.NoError(t, err)
+	require.Equal(t, &pb.CommentAnchor{BlockId: "b1", Starts: []int32{6}, Ends: []int32{11}}, world.Anchor)
+
+	hello, err := comment(&pb.CommentAnchor{BlockId: "b1", Starts: []int32{0}, Ends: []int32{5}})
+	require.NoError(t, err)
+
+	second, err := comment(&pb.CommentAnchor{BlockId: "b2"})
+	require.NoError(t, err)
+
+	v2, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           v1.Path,
+		BaseVersion:    v1.Version,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hi world, this is Seed"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	v3, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           v1.Path,
+		BaseVersion:    v2.Version,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hi there, this is Seed"}}},
+			{Op: &pb.DocumentChange_DeleteBlock{DeleteBlock: "b2"}},
+		},
+	})
+	require.NoError(t, err)
+
+	anchorsAt := func(version string) map[string]*pb.CommentAnchor {
+		list, err := alice.ListComments(ctx, &pb.ListCommentsRequest{
+			TargetAccount: account,
+			TargetPath:    v1.Path,
+			AnchorVersion: version,
+		})
+		require.NoError(t, err)
+		out := make(map[string]*pb.CommentAnchor, len(list.Comments))
+		for _, c := range list.Comments {
+			out[c.Id] = c.Anchor
+		}
+		return out
+	}
+
+	require.Equal(t, map[string]*pb.CommentAnchor{
+		world.Id:  {BlockId: "b1", Starts: []int32{6}, Ends: []int32{11}},
+		hello.Id:  {BlockId: "b1", Starts: []int32{0}, Ends: []int32{5}},
+		second.Id: {BlockId: "b2"},
+	}, anchorsAt(""), "anchors must be returned as created by default")
+
+	require.Equal(t, map[string]*pb.CommentAnchor{
+		world.Id:  {BlockId: "b1", Starts: []int32{3}, Ends: []int32{8}},
+		hello.Id:  {BlockId: "b1", Starts: []int32{0}, Ends: []int32{2}},
+		second.Id: {BlockId: "b2"},
+	}, anchorsAt(v2.Version), "anchors must follow the edits of the block")
+
+	require.Equal(t, map[string]*pb.CommentAnchor{
+		world.Id:  {BlockId: "b1", Starts: []int32{3}, Ends: []int32{8}, IsOrphaned: true},
+		hello.Id:  {BlockId: "b1", Starts: []int32{0}, Ends: []int32{2}},
+		second.Id: {BlockId: "b2", IsOrphaned: true},
+	}, anchorsAt(v3.Version), "anchors must be orphaned when their text or block is removed")
+}
+
+func TestCommentThreads(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	bob := coretest.NewTester("bob")
+	carol := coretest.NewTester("carol")
+	ctx := context.Background()
+	require.NoError(t, alice.keys.StoreKey(ctx, "bob", bob.Account))
+	require.NoError(t, alice.keys.StoreKey(ctx, "carol", carol.Account))
+	account := alice.me.Account.Principal().String()
+
+	doc, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/threads",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Threads"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	comment := func(key, replyParent, text string) *pb.Comment {
+		cmt, err := alice.CreateComment(ctx, &pb.CreateCommentRequest{
+			SigningKeyName: key,
+			TargetAccount:  account,
+			TargetPath:     doc.Path,
+			TargetVersion:  doc.Version,
+			ReplyParent:    replyParent,
+			Content:        []*pb.BlockNode{{Block: &pb.Block{Id: "b1", Type: "paragraph", Text: text}}},
+		})
+		require.NoError(t, err)
+		return cmt
+	}
+
+	t1 := comment("bob", "", "Thread 1")
+	r1 := comment("main", t1.Id, "Reply 1")
+	t2 := comment("carol", "", "Thread 2")
+	r2 := comment("carol", t1.Id, "Reply 2")
+	r11 := comment("bob", r1.Id, "Reply 1.1")
+	t3 := comment("main", "", "Thread 3")
+
+	var (
+		threads []*pb.CommentThread
+		token   string
+		pages   int
+	)
+	for {
+		resp, err := alice.ListComments(ctx, &pb.ListCommentsRequest{
+			TargetAccount: account,
+			TargetPath:    doc.Path,
+			Threaded:      true,
+			PageSize:      2,
+			PageToken:     token,
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Comments, 0)
+		threads = append(threads, resp.Threads...)
+		pages++
+		token = resp.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+	require.Equal(t, 2, pages)
+	require.Len(t, threads, 3)
+
+	require.Equal(t, t1.Id, threads[0].Root.Id)
+	require.Equal(t, int32(3), threads[0].ReplyCount)
+	var replyIDs []string
+	for _, r := range threads[0].Replies {
+		replyIDs = append(replyIDs, r.Id)
+	}
+	require.Equal(t, []string{r1.Id, r11.Id, r2.Id}, replyIDs, "replies must follow the reply parents depth-first")
+
+	require.Equal(t, t2.Id, threads[1].Root.Id)
+	require.Equal(t, int32(0), threads[1].ReplyCount)
+	require.Equal(t, t3.Id, threads[2].Root.Id)
+
+	// Flat listing is paginated too.
+	flat, err := alice.ListComments(ctx, &pb.ListCommentsRequest{TargetAccount: account, TargetPath: doc.Path, PageSize: 4})
+	require.NoError(t, err)
+	require.Len(t, flat.Comments, 4)
+	require.NotEmpty(t, flat.NextPageToken)
+	flat, err = alice.ListComments(ctx, &pb.ListCommentsRequest{TargetAccount: account, TargetPath: doc.Path, PageSize: 4, PageToken: flat.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, flat.Comments, 2)
+	require.Equal(t, r11.Id, flat.Comments[0].Id)
+	require.Equal(t, t3.Id, flat.Comments[1].Id)
+	require.Empty(t, flat.NextPageToken)
+
+	// Resolving threads.
+	_, err = alice.ResolveThread(ctx, &pb.ResolveThreadRequest{ThreadRoot: t1.Id, SigningKeyName: "carol", Resolved: true})
+	require.Error(t, err, "only the owner and the thread author can resolve threads")
+
+	_, err = alice.ResolveThread(ctx, &pb.ResolveThreadRequest{ThreadRoot: r1.Id, SigningKeyName: "main", Resolved: true})
+	require.Error(t, err, "replies can't be resolved")
+
+	resolved, err := alice.ResolveThread(ctx, &pb.ResolveThreadRequest{ThreadRoot: t1.Id, SigningKeyName: "bob", Resolved: true})
+	require.NoError(t, err)
+	require.True(t, resolved.IsResolved)
+	require.Equal(t, bob.Account.Principal().String(), resolved.ResolvedBy)
+	require.Equal(t, int32(3), resolved.ReplyCount)
+
+	reopened, err := alice.ResolveThread(ctx, &pb.ResolveThreadRequest{ThreadRoot: t1.Id, SigningKeyName: "main", Resolved: false})
+	require.NoError(t, err)
+	require.False(t, reopened.IsResolved)
+	require.Equal(t, account, reopened.ResolvedBy)
+	require.True(t, reopened.ResolveTime.AsTime().After(resolved.ResolveTime.AsTime()))
+
+	_, err = alice.ResolveThread(ctx, &pb.ResolveThreadRequest{ThreadRoot: t2.Id, SigningKeyName: "main", Resolved: true})
+	require.NoError(t, err)
+
+	resp, err := alice.ListComments(ctx, &pb.ListCommentsRequest{TargetAccount: account, TargetPath: doc.Path, Threaded: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Threads, 3)
+	require.False(t, resp.Threads[0].IsResolved)
+	require.True(t, resp.Threads[1].IsResolved)
+	require.False(t, resp.Threads[2].IsResolved)
+	require.Empty(t, resp.Threads[2].ResolvedBy)
+
+	// Without page size and page token all the comments are returned.
+	for i := range 30 {
+		comment("main", "", "More "+strconv.Itoa(i))
+	}
+	all, err := alice.ListComments(ctx, &pb.ListCommentsRequest{TargetAccount: account, TargetPath: doc.Path})
+	require.NoError(t, err)
+	require.Len(t, all.Comments, 36)
+	require.Empty(t, all.NextPageToken)
+}
@@ -0,0 +1,216 @@
+package documents
+
+import (
+	"context"
+	"maps"
+	"seed/backend/api/documents/v3alpha/docmodel"
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/errutil"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DiffDocument implements Documents API v3.
+func (srv *Server) DiffDocument(ctx context.Context, in *documents.DiffDocumentRequest) (*documents.DocumentDiff, error) {
+	{
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		if in.FromVersion == "" {
+			return nil, errutil.MissingArgument("from_version")
+		}
+	}
+
+	acc, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	from, err := srv.loadDocument(ctx, acc, in.Path, docmodel.Version(in.FromVersion), false)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := srv.loadDocument(ctx, acc, in.Path, docmodel.Version(in.ToVersion), false)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDoc, err := from.Hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	toDoc, err := to.Hydrate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffDocuments(fromDoc, toDoc), nil
+}
+
+// flatBlock is a block with its position in the document tree.
+type flatBlock struct {
+	Block    *documents.Block
+	Position *documents.BlockPosition
+}
+
+// flattenBlocks returns the list of block IDs in the document order,
+// and the index of blocks with their positions.
+func flattenBlocks(content []*documents.BlockNode) (order []string, index map[string]flatBlock) {
+	index = make(map[string]flatBlock)
+
+	var walk func(parent string, nodes []*documents.BlockNode)
+	walk = func(parent string, nodes []*documents.BlockNode) {
+		var left string
+		for _, n := range nodes {
+			id := n.Block.Id
+			order = append(order, id)
+			index[id] = flatBlock{
+				Block:    n.Block,
+				Position: &documents.BlockPosition{Parent: parent, LeftSibling: left},
+			}
+			left = id
+			walk(id, n.Children)
+		}
+	}
+	walk("", content)
+
+	return order, index
+}
+
+func diffDocuments(from, to *documents.Document) *documents.DocumentDiff {
+	out := &documents.DocumentDiff{
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+	}
+
+	keys := slices.Collect(maps.Keys(from.Metadata))
+	for k := range to.Metadata {
+		if _, ok := from.Metadata[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		if from.Metadata[k] == to.Metadata[k] {
+			continue
+		}
+
+		out.Metadata = append(out.Metadata, &documents.MetadataDiff{
+			Key:      k,
+			OldValue: from.Metadata[k],
+			NewValue: to.Metadata[k],
+		})
+	}
+
+	fromOrder, fromBlocks := flattenBlocks(from.Content)
+	toOrder, toBlocks := flattenBlocks(to.Content)
+
+	// Blocks are only considered moved if their position changed relative to the blocks that exist in both versions.
+	// Otherwise removing or adding a block would make its next sibling look moved too.
+	fromStable := stablePositions(from.Content, toBlocks)
+	toStable := stablePositions(to.Content, fromBlocks)
+
+	for _, id := range toOrder {
+		nb := toBlocks[id]
+		ob, ok := fromBlocks[id]
+		if !ok {
+			out.Blocks = append(out.Blocks, &documents.BlockDiff{
+				BlockId:     id,
+				Kind:        documents.BlockDiffKind_BLOCK_DIFF_KIND_ADDED,
+				NewBlock:    nb.Block,
+				NewPosition: nb.Position,
+			})
+			continue
+		}
+
+		bd := diffBlocks(ob, nb, !proto.Equal(fromStable[id], toStable[id]))
+		if bd != nil {
+			out.Blocks = append(out.Blocks, bd)
+		}
+	}
+
+	for _, id := range fromOrder {
+		if _, ok := toBlocks[id]; ok {
+			continue
+		}
+
+		ob := fromBlocks[id]
+		out.Blocks = append(out.Blocks, &documents.BlockDiff{
+			BlockId:     id,
+			Kind:        documents.BlockDiffKind_BLOCK_DIFF_KIND_REMOVED,
+			OldBlock:    ob.Block,
+			OldPosition: ob.Position,
+		})
+	}
+
+	return out
+}
+
+// stablePositions returns the positions of the blocks that also exist in the other version,
+// ignoring the blocks that don't exist there.
+func stablePositions(content []*documents.BlockNode, other map[string]flatBlock) map[string]*documents.BlockPosition {
+	out := make(map[string]*documents.BlockPosition)
+
+	var walk func(parent string, nodes []*documents.BlockNode)
+	walk = func(parent string, nodes []*documents.BlockNode) {
+		var left string
+		for _, n := range nodes {
+			id := n.Block.Id
+			if _, ok := other[id]; ok {
+				out[id] = &documents.BlockPosition{Parent: parent, LeftSibling: left}
+				left = id
+			}
+			walk(id, n.Children)
+		}
+	}
+	walk("", content)
+
+	return out
+}
+
+// diffBlocks compares two states of the same block.
+// It returns nil if there's no difference.
+// Revisions are ignored, because they change even when the content stays the same.
+func diffBlocks(from, to flatBlock, moved bool) *documents.BlockDiff {
+	ob, nb := from.Block, to.Block
+
+	bd := &documents.BlockDiff{
+		BlockId:            nb.Id,
+		Kind:               documents.BlockDiffKind_BLOCK_DIFF_KIND_MODIFIED,
+		OldBlock:           ob,
+		NewBlock:           nb,
+		OldPosition:        from.Position,
+		NewPosition:        to.Position,
+		Moved:              moved,
+		TypeChanged:        ob.Type != nb.Type,
+		TextChanged:        ob.Text != nb.Text,
+		RefChanged:         ob.Ref != nb.Ref,
+		AnnotationsChanged: !slices.EqualFunc(ob.Annotations, nb.Annotations, func(a, b *documents.Annotation) bool { return proto.Equal(a, b) }),
+	}
+
+	for k, v := range ob.Attributes {
+		if nv, ok := nb.Attributes[k]; !ok || nv != v {
+			bd.ChangedAttributes = append(bd.ChangedAttributes, k)
+		}
+	}
+	for k := range nb.Attributes {
+		if _, ok := ob.Attributes[k]; !ok {
+			bd.ChangedAttributes = append(bd.ChangedAttributes, k)
+		}
+	}
+	slices.Sort(bd.ChangedAttributes)
+
+	if !bd.Moved && !bd.TypeChanged && !bd.TextChanged && !bd.RefChanged && !bd.AnnotationsChanged && len(bd.ChangedAttributes) == 0 {
+		return nil
+	}
+
+	return bd
+}
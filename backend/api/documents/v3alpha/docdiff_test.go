@@ -0,0 +1,148 @@
+package documents
+
+import (
+	"context"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffDocument(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	d1, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "/diff",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "First title"}}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello"}}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b2", LeftSibling: "b1"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b2", Type: "paragraph", Text: "World"}}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b3", LeftSibling: "b2"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b3", Type: "paragraph", Text: "Unchanged"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	d2, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        d1.Account,
+		Path:           d1.Path,
+		BaseVersion:    d1.Version,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Second title"}}},
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "cover", Value: "ipfs://foo"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{
+				Id:         "b1",
+				Type:       "heading",
+				Text:       "Hello!",
+				Attributes: map[string]string{"level": "1"},
+				Annotations: []*pb.Annotation{
+					{Type: "bold", Starts: []int32{0}, Ends: []int32{5}},
+				},
+			}}},
+			{Op: &pb.DocumentChange_DeleteBlock{DeleteBlock: "b2"}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b4", Parent: "b1"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b4", Type: "paragraph", Text: "Child"}}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b3", Parent: "b1", LeftSibling: "b4"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	diff, err := alice.DiffDocument(ctx, &pb.DiffDocumentRequest{
+		Account:     d1.Account,
+		Path:        d1.Path,
+		FromVersion: d1.Version,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, d1.Version, diff.FromVersion)
+	require.Equal(t, d2.Version, diff.ToVersion, "empty to_version must diff to the latest version")
+
+	require.Len(t, diff.Metadata, 2)
+	require.Equal(t, &pb.MetadataDiff{Key: "cover", NewValue: "ipfs://foo"}, diff.Metadata[0])
+	require.Equal(t, &pb.MetadataDiff{Key: "title", OldValue: "First title", NewValue: "Second title"}, diff.Metadata[1])
+
+	require.Len(t, diff.Blocks, 4)
+
+	b1 := diff.Blocks[0]
+	require.Equal(t, "b1", b1.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_MODIFIED, b1.Kind)
+	require.False(t, b1.Moved)
+	require.True(t, b1.TypeChanged)
+	require.True(t, b1.TextChanged)
+	require.False(t, b1.RefChanged)
+	require.True(t, b1.AnnotationsChanged)
+	require.Equal(t, []string{"level"}, b1.ChangedAttributes)
+
+	b4 := diff.Blocks[1]
+	require.Equal(t, "b4", b4.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_ADDED, b4.Kind)
+	require.Equal(t, "b1", b4.NewPosition.Parent)
+
+	b3 := diff.Blocks[2]
+	require.Equal(t, "b3", b3.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_MODIFIED, b3.Kind)
+	require.True(t, b3.Moved)
+	require.Equal(t, "", b3.OldPosition.Parent)
+	require.Equal(t, "b2", b3.OldPosition.LeftSibling)
+	require.Equal(t, "b1", b3.NewPosition.Parent)
+	require.Equal(t, "b4", b3.NewPosition.LeftSibling)
+	require.False(t, b3.TextChanged)
+
+	b2 := diff.Blocks[3]
+	require.Equal(t, "b2", b2.BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_REMOVED, b2.Kind)
+	require.Equal(t, "World", b2.OldBlock.Text)
+
+	// Deleting a block must not make its next sibling look moved.
+	d3, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        d1.Account,
+		Path:           d1.Path,
+		BaseVersion:    d2.Version,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_DeleteBlock{DeleteBlock: "b4"}},
+		},
+	})
+	require.NoError(t, err)
+
+	diff, err = alice.DiffDocument(ctx, &pb.DiffDocumentRequest{
+		Account:     d1.Account,
+		Path:        d1.Path,
+		FromVersion: d2.Version,
+		ToVersion:   d3.Version,
+	})
+	require.NoError(t, err)
+	require.Len(t, diff.Blocks, 1)
+	require.Equal(t, "b4", diff.Blocks[0].BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_REMOVED, diff.Blocks[0].Kind)
+
+	// Same for adding a block.
+	diff, err = alice.DiffDocument(ctx, &pb.DiffDocumentRequest{
+		Account:     d1.Account,
+		Path:        d1.Path,
+		FromVersion: d3.Version,
+		ToVersion:   d2.Version,
+	})
+	require.NoError(t, err)
+	require.Len(t, diff.Blocks, 1)
+	require.Equal(t, "b4", diff.Blocks[0].BlockId)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_ADDED, diff.Blocks[0].Kind)
+
+	same, err := alice.DiffDocument(ctx, &pb.DiffDocumentRequest{
+		Account:     d1.Account,
+		Path:        d1.Path,
+		FromVersion: d2.Version,
+		ToVersion:   d2.Version,
+	})
+	require.NoError(t, err)
+	require.Len(t, same.Metadata, 0, "same versions must have no metadata diff")
+	require.Len(t, same.Blocks, 0, "same versions must have no block diff")
+}
@@ -0,0 +1,25 @@
+package documents
+
+import (
+	"context"
+	documents "seed/backend/genproto/documents/v3alpha"
+)
+
+// ExportDocument implements Documents API v3.
+func (srv *Server) ExportDocument(ctx context.Context, in *documents.ExportDocumentRequest) (*documents.ExportDocumentResponse, error) {
+	doc, err := srv.GetDocument(ctx, &documents.GetDocumentRequest{
+		Account: in.Account,
+		Path:    in.Path,
+		Version: in.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mr := markdownRenderer{ipfsGateway: in.IpfsGatewayUrl}
+
+	return &documents.ExportDocumentResponse{
+		Markdown: mr.Render(doc),
+		Version:  doc.Version,
+	}, nil
+}
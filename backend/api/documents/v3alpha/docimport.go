@@ -0,0 +1,484 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"maps"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// ImportMarkdown implements Documents API v3.
+func (srv *Server) ImportMarkdown(ctx context.Context, in *documents.ImportMarkdownRequest) (*documents.Document, error) {
+	metadata, content, err := parseMarkdown(in.Markdown, newBlockID)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to parse markdown: %v", err)
+	}
+
+	var changes []*documents.DocumentChange
+
+	for _, k := range slices.Sorted(maps.Keys(metadata)) {
+		changes = append(changes, &documents.DocumentChange{
+			Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: k, Value: metadata[k]},
+			},
+		})
+	}
+
+	// Replacing the existing content. Deleting top-level blocks deletes their children too.
+	if in.BaseVersion != "" {
+		base, err := srv.GetDocument(ctx, &documents.GetDocumentRequest{
+			Account: in.Account,
+			Path:    in.Path,
+			Version: in.BaseVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range base.Content {
+			changes = append(changes, &documents.DocumentChange{
+				Op: &documents.DocumentChange_DeleteBlock{DeleteBlock: n.Block.Id},
+			})
+		}
+	}
+
+	var walk func(parent string, nodes []*documents.BlockNode)
+	walk = func(parent string, nodes []*documents.BlockNode) {
+		var left string
+		for _, n := range nodes {
+			changes = append(changes,
+				&documents.DocumentChange{Op: &documents.DocumentChange_MoveBlock_{
+					MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: n.Block.Id, Parent: parent, LeftSibling: left},
+				}},
+				&documents.DocumentChange{Op: &documents.DocumentChange_ReplaceBlock{
+					ReplaceBlock: n.Block,
+				}},
+			)
+			left = n.Block.Id
+			walk(n.Block.Id, n.Children)
+		}
+	}
+	walk("", content)
+
+	return srv.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		Account:        in.Account,
+		Path:           in.Path,
+		BaseVersion:    in.BaseVersion,
+		Changes:        changes,
+		SigningKeyName: in.SigningKeyName,
+		Capability:     in.Capability,
+	})
+}
+
+const blockIDAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz_-"
+
+// newBlockID generates a random block ID similar to the ones generated by the frontend editor.
+func newBlockID() string {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(fmt.Errorf("BUG: failed to read random bytes: %w", err))
+	}
+
+	for i, b := range buf {
+		buf[i] = blockIDAlphabet[int(b)%len(blockIDAlphabet)]
+	}
+
+	return string(buf[:])
+}
+
+// parseMarkdown converts Markdown text into document metadata and a tree of blocks,
+// following the same conventions the markdownRenderer uses for export.
+// Headings at the top level become sections containing the following content.
+// Lists and block quotes become children of the preceding block.
+func parseMarkdown(md string, newID func() string) (metadata map[string]string, content []*documents.BlockNode, err error) {
+	frontMatter, body := splitFrontMatter(md)
+	if frontMatter != "" {
+		var fm map[string]yaml.Node
+		if err := yaml.Unmarshal([]byte(frontMatter), &fm); err != nil {
+			return nil, nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+
+		metadata = make(map[string]string, len(fm))
+		for k, v := range fm {
+			value, ok, err := frontMatterValue(&v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid front matter value for key '%s': %w", k, err)
+			}
+			if ok {
+				metadata[k] = value
+			}
+		}
+	}
+
+	src := []byte(body)
+	md2 := goldmark.New(goldmark.WithExtensions(extension.Strikethrough))
+	doc := md2.Parser().Parse(text.NewReader(src))
+
+	mi := &markdownImporter{src: src, newID: newID}
+	root := &documents.BlockNode{}
+	mi.convertChildren(root, doc.FirstChild(), true)
+
+	return metadata, root.Children, nil
+}
+
+// frontMatterValue converts the front matter value into a metadata value.
+// Scalars are kept as written, and nested maps and lists are encoded as JSON.
+// It returns false for null values.
+func frontMatterValue(n *yaml.Node) (value string, ok bool, err error) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	if n.Kind == yaml.ScalarNode {
+		if n.Tag == "!!null" {
+			return "", false, nil
+		}
+		return n.Value, true, nil
+	}
+
+	var v any
+	if err := n.Decode(&v); err != nil {
+		return "", false, err
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", false, err
+	}
+
+	return string(data), true, nil
+}
+
+// splitFrontMatter separates YAML front matter delimited by "---" lines from the rest of the text.
+func splitFrontMatter(md string) (frontMatter, body string) {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+
+	if !strings.HasPrefix(md, "---\n") {
+		return "", md
+	}
+
+	rest := md[len("---\n"):]
+	if strings.HasPrefix(rest, "---\n") || rest == "---" {
+		return "", strings.TrimPrefix(rest, "---")
+	}
+
+	end := strings.Index(rest, "\n---\n")
+	if end == -1 {
+		if !strings.HasSuffix(rest, "\n---") {
+			return "", md
+		}
+		end = len(rest) - len("\n---")
+		return rest[:end], ""
+	}
+
+	return rest[:end], rest[end+len("\n---\n"):]
+}
+
+type markdownImporter struct {
+	src   []byte
+	newID func() string
+}
+
+func (mi *markdownImporter) newNode(blockType string) *documents.BlockNode {
+	return &documents.BlockNode{
+		Block: &documents.Block{
+			Id:         mi.newID(),
+			Type:       blockType,
+			Attributes: map[string]string{},
+		},
+	}
+}
+
+// convertChildren converts the sibling Markdown nodes starting from first, and appends them to the container.
+// Root container has no block.
+func (mi *markdownImporter) convertChildren(container *documents.BlockNode, first ast.Node, sections bool) {
+	type section struct {
+		Level int
+		Node  *documents.BlockNode
+	}
+
+	var stack []section
+
+	for n := first; n != nil; n = n.NextSibling() {
+		target := container
+		if len(stack) > 0 {
+			target = stack[len(stack)-1].Node
+		}
+
+		switch n := n.(type) {
+		case *ast.Heading:
+			node := mi.newNode("heading")
+			node.Block.Text, node.Block.Annotations = mi.convertInline(n)
+			node.Block.Attributes["level"] = strconv.Itoa(n.Level)
+
+			if !sections {
+				target.Children = append(target.Children, node)
+				continue
+			}
+
+			for len(stack) > 0 && stack[len(stack)-1].Level >= n.Level {
+				stack = stack[:len(stack)-1]
+			}
+
+			target = container
+			if len(stack) > 0 {
+				target = stack[len(stack)-1].Node
+			}
+
+			node.Block.Attributes["childrenType"] = "group"
+			target.Children = append(target.Children, node)
+			stack = append(stack, section{Level: n.Level, Node: node})
+		case *ast.List:
+			holder := mi.holder(target)
+			if n.IsOrdered() {
+				holder.Block.Attributes["childrenType"] = "ol"
+			} else {
+				holder.Block.Attributes["childrenType"] = "ul"
+			}
+
+			for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+				holder.Children = append(holder.Children, mi.convertListItem(item))
+			}
+		case *ast.Blockquote:
+			holder := mi.holder(target)
+			holder.Block.Attributes["childrenType"] = "blockquote"
+			mi.convertChildren(holder, n.FirstChild(), false)
+		case *ast.FencedCodeBlock:
+			node := mi.newNode("codeBlock")
+			node.Block.Text = mi.linesText(n)
+			if lang := n.Language(mi.src); len(lang) > 0 {
+				node.Block.Attributes["language"] = string(lang)
+			}
+			target.Children = append(target.Children, node)
+		case *ast.CodeBlock:
+			node := mi.newNode("codeBlock")
+			node.Block.Text = mi.linesText(n)
+			target.Children = append(target.Children, node)
+		case *ast.HTMLBlock:
+			node := mi.newNode("paragraph")
+			node.Block.Text = strings.TrimSuffix(mi.linesText(n), "\n")
+			target.Children = append(target.Children, node)
+		case *ast.Paragraph, *ast.TextBlock:
+			target.Children = append(target.Children, mi.convertParagraph(n))
+		}
+	}
+}
+
+// holder returns the block that should hold lists and quotes appended to the container.
+// It's the last block in the container, if it doesn't have children yet,
+// or the container itself if it's empty, like the paragraph of a list item.
+// Headings, and blocks that already hold some other kind of children, are never reused,
+// because their children type would be overwritten. In that case a new empty paragraph is created.
+func (mi *markdownImporter) holder(container *documents.BlockNode) *documents.BlockNode {
+	canHold := func(n *documents.BlockNode) bool {
+		return n.Block.Type != "heading" && n.Block.Type != "codeBlock" && n.Block.Attributes["childrenType"] == ""
+	}
+
+	if len(container.Children) > 0 {
+		last := container.Children[len(container.Children)-1]
+		if len(last.Children) == 0 && canHold(last) {
+			return last
+		}
+	} else if container.Block != nil && canHold(container) {
+		return container
+	}
+
+	node := mi.newNode("paragraph")
+	container.Children = append(container.Children, node)
+	return node
+}
+
+func (mi *markdownImporter) convertListItem(item ast.Node) *documents.BlockNode {
+	first := item.FirstChild()
+
+	var node *documents.BlockNode
+	switch first.(type) {
+	case *ast.Paragraph, *ast.TextBlock:
+		node = mi.convertParagraph(first)
+		first = first.NextSibling()
+	default:
+		node = mi.newNode("paragraph")
+	}
+
+	mi.convertChildren(node, first, false)
+
+	return node
+}
+
+func (mi *markdownImporter) convertParagraph(n ast.Node) *documents.BlockNode {
+	if n.ChildCount() == 1 {
+		switch c := n.FirstChild().(type) {
+		case *ast.Image:
+			node := mi.newNode("image")
+			node.Block.Text = string(c.Text(mi.src))
+			node.Block.Ref = linkDestination(c.Destination)
+			return node
+		case *ast.Link:
+			dest := linkDestination(c.Destination)
+			if strings.HasPrefix(dest, "hm://") && string(c.Text(mi.src)) == dest {
+				node := mi.newNode("embed")
+				node.Block.Ref = dest
+				return node
+			}
+		}
+	}
+
+	if raw := strings.TrimSpace(mi.linesText(n)); len(raw) >= 4 && strings.HasPrefix(raw, "$$") && strings.HasSuffix(raw, "$$") {
+		node := mi.newNode("math")
+		node.Block.Text = strings.TrimSpace(raw[2 : len(raw)-2])
+		return node
+	}
+
+	node := mi.newNode("paragraph")
+	node.Block.Text, node.Block.Annotations = mi.convertInline(n)
+	return node
+}
+
+func (mi *markdownImporter) linesText(n ast.Node) string {
+	var buf bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		buf.Write(seg.Value(mi.src))
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// convertInline converts inline Markdown nodes into text with annotations.
+// Annotation offsets are in UTF-16 code units, as expected by the frontend.
+func (mi *markdownImporter) convertInline(n ast.Node) (string, []*documents.Annotation) {
+	ib := &inlineBuilder{src: mi.src}
+	ib.walk(n, false)
+	return string(utf16.Decode(ib.text)), ib.annotations
+}
+
+type inlineBuilder struct {
+	src         []byte
+	text        []uint16
+	annotations []*documents.Annotation
+	underlines  []int
+}
+
+func (ib *inlineBuilder) write(s string) {
+	ib.text = append(ib.text, utf16.Encode([]rune(s))...)
+}
+
+// annotate adds an annotation for the text written since start.
+// Adjacent spans of the same annotation are merged together.
+func (ib *inlineBuilder) annotate(annotationType, ref string, start int) {
+	end := len(ib.text)
+	if start >= end {
+		return
+	}
+
+	for _, a := range ib.annotations {
+		last := len(a.Ends) - 1
+		if a.Type == annotationType && a.Ref == ref && a.Ends[last] == int32(start) {
+			a.Ends[last] = int32(end)
+			return
+		}
+	}
+
+	ib.annotations = append(ib.annotations, &documents.Annotation{
+		Type:   annotationType,
+		Ref:    ref,
+		Starts: []int32{int32(start)},
+		Ends:   []int32{int32(end)},
+	})
+}
+
+func (ib *inlineBuilder) walk(n ast.Node, raw bool) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		start := len(ib.text)
+
+		switch c := c.(type) {
+		case *ast.Text:
+			v := c.Segment.Value(ib.src)
+			if !raw {
+				v = util.ResolveEntityNames(util.ResolveNumericReferences(util.UnescapePunctuations(v)))
+			}
+			ib.write(string(v))
+			switch {
+			case c.HardLineBreak():
+				ib.write("\n")
+			case c.SoftLineBreak():
+				ib.write(" ")
+			}
+		case *ast.String:
+			ib.write(string(c.Value))
+		case *ast.CodeSpan:
+			ib.walk(c, true)
+			ib.annotate("code", "", start)
+		case *ast.Emphasis:
+			ib.walk(c, raw)
+			if c.Level == 1 {
+				ib.annotate("emphasis", "", start)
+			} else {
+				ib.annotate("strong", "", start)
+			}
+		case *east.Strikethrough:
+			ib.walk(c, raw)
+			ib.annotate("strike", "", start)
+		case *ast.Link:
+			ib.walk(c, raw)
+			ib.annotate("link", linkDestination(c.Destination), start)
+		case *ast.Image:
+			ib.walk(c, raw)
+			ib.annotate("link", linkDestination(c.Destination), start)
+		case *ast.AutoLink:
+			url := string(c.URL(ib.src))
+			if strings.HasPrefix(url, "hm://") {
+				// Inline embeds take a single character of text, as in the frontend editor.
+				ib.write(" ")
+				ib.annotate("inline-embed", url, start)
+			} else {
+				ib.write(string(c.Label(ib.src)))
+				ib.annotate("link", url, start)
+			}
+		case *ast.RawHTML:
+			var buf bytes.Buffer
+			for i := 0; i < c.Segments.Len(); i++ {
+				seg := c.Segments.At(i)
+				buf.Write(seg.Value(ib.src))
+			}
+
+			switch tag := buf.String(); tag {
+			case "<u>":
+				ib.underlines = append(ib.underlines, start)
+			case "</u>":
+				if len(ib.underlines) > 0 {
+					ustart := ib.underlines[len(ib.underlines)-1]
+					ib.underlines = ib.underlines[:len(ib.underlines)-1]
+					ib.annotate("underline", "", ustart)
+				}
+			default:
+				ib.write(tag)
+			}
+		default:
+			ib.walk(c, raw)
+		}
+	}
+}
+
+// linkDestination returns the URL of the link with the backslash escapes removed.
+func linkDestination(dest []byte) string {
+	return string(util.UnescapePunctuations(dest))
+}
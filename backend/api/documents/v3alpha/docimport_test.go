@@ -0,0 +1,196 @@
+package documents
+
+import (
+	"context"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	t.Parallel()
+
+	in := `---
+title: "Hello: World"
+tags: foo
+---
+
+# Intro
+
+**Bold and _ita_**_lic_ with [link](https://example.com) and 1\*2 ~~gone~~ <u>under</u>.
+
+List:
+
+1. 😀 ` + "`first`" + `
+2. second
+
+   - nested
+
+## Subsection
+
+Mention <hm://bob> here
+
+` + "```go\nfmt.Println(\"hi\")\n```" + `
+
+# Outro
+
+![A cat](ipfs://bafy-cat)
+
+[hm://alice/foo](hm://alice/foo)
+
+$$
+E = mc^2
+$$
+`
+
+	var n int
+	newID := func() string {
+		n++
+		return "b" + strconv.Itoa(n)
+	}
+
+	meta, content, err := parseMarkdown(in, newID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"title": "Hello: World", "tags": "foo"}, meta)
+
+	require.Len(t, content, 2, "top-level headings must become sections")
+	intro, outro := content[0], content[1]
+
+	require.Equal(t, "heading", intro.Block.Type)
+	require.Equal(t, "Intro", intro.Block.Text)
+	require.Equal(t, "1", intro.Block.Attributes["level"])
+	require.Len(t, intro.Children, 3)
+
+	p := intro.Children[0].Block
+	require.Equal(t, "Bold and italic with link and 1*2 gone under.", p.Text)
+	require.Equal(t, []*pb.Annotation{
+		{Type: "emphasis", Starts: []int32{9}, Ends: []int32{15}},
+		{Type: "strong", Starts: []int32{0}, Ends: []int32{12}},
+		{Type: "link", Ref: "https://example.com", Starts: []int32{21}, Ends: []int32{25}},
+		{Type: "strike", Starts: []int32{34}, Ends: []int32{38}},
+		{Type: "underline", Starts: []int32{39}, Ends: []int32{44}},
+	}, p.Annotations)
+
+	list := intro.Children[1]
+	require.Equal(t, "List:", list.Block.Text)
+	require.Equal(t, "ol", list.Block.Attributes["childrenType"])
+	require.Len(t, list.Children, 2)
+	require.Equal(t, "😀 first", list.Children[0].Block.Text)
+	require.Equal(t, []*pb.Annotation{{Type: "code", Starts: []int32{3}, Ends: []int32{8}}}, list.Children[0].Block.Annotations)
+	require.Equal(t, "ul", list.Children[1].Block.Attributes["childrenType"])
+	require.Equal(t, "nested", list.Children[1].Children[0].Block.Text)
+
+	sub := intro.Children[2]
+	require.Equal(t, "heading", sub.Block.Type)
+	require.Equal(t, "2", sub.Block.Attributes["level"])
+	require.Len(t, sub.Children, 2)
+	require.Equal(t, "Mention   here", sub.Children[0].Block.Text)
+	require.Equal(t, []*pb.Annotation{{Type: "inline-embed", Ref: "hm://bob", Starts: []int32{8}, Ends: []int32{9}}}, sub.Children[0].Block.Annotations)
+	require.Equal(t, "codeBlock", sub.Children[1].Block.Type)
+	require.Equal(t, "go", sub.Children[1].Block.Attributes["language"])
+	require.Equal(t, "fmt.Println(\"hi\")", sub.Children[1].Block.Text)
+
+	require.Len(t, outro.Children, 3)
+	require.Equal(t, &pb.Block{Id: outro.Children[0].Block.Id, Type: "image", Text: "A cat", Ref: "ipfs://bafy-cat", Attributes: map[string]string{}}, outro.Children[0].Block)
+	require.Equal(t, &pb.Block{Id: outro.Children[1].Block.Id, Type: "embed", Ref: "hm://alice/foo", Attributes: map[string]string{}}, outro.Children[1].Block)
+	require.Equal(t, &pb.Block{Id: outro.Children[2].Block.Id, Type: "math", Text: "E = mc^2", Attributes: map[string]string{}}, outro.Children[2].Block)
+
+	// Exporting the imported document must produce the same Markdown, apart from the formatting details.
+	out := markdownRenderer{}.Render(&pb.Document{Metadata: meta, Content: content})
+	_, content2, err := parseMarkdown(out, newID)
+	require.NoError(t, err)
+	out2 := markdownRenderer{}.Render(&pb.Document{Metadata: meta, Content: content2})
+	require.Equal(t, out, out2, "export and import must round-trip")
+}
+
+func TestParseMarkdownSectionLists(t *testing.T) {
+	t.Parallel()
+
+	in := `---
+tags: [foo, bar]
+author: {name: Alice, links: [a, b]}
+date: 2024-01-01
+draft:
+---
+
+# T
+
+- a
+- b
+
+Para
+
+> - quoted
+`
+
+	var n int
+	meta, content, err := parseMarkdown(in, func() string {
+		n++
+		return "b" + strconv.Itoa(n)
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"tags":   `["foo","bar"]`,
+		"author": `{"links":["a","b"],"name":"Alice"}`,
+		"date":   "2024-01-01",
+	}, meta)
+
+	require.Len(t, content, 1)
+	section := content[0]
+	require.Equal(t, "heading", section.Block.Type)
+	require.Equal(t, "group", section.Block.Attributes["childrenType"], "heading children type must not be overwritten by lists")
+	require.Len(t, section.Children, 2)
+
+	list := section.Children[0]
+	require.Equal(t, "paragraph", list.Block.Type)
+	require.Equal(t, "", list.Block.Text)
+	require.Equal(t, "ul", list.Block.Attributes["childrenType"])
+	require.Len(t, list.Children, 2)
+	require.Equal(t, "a", list.Children[0].Block.Text)
+	require.Equal(t, "b", list.Children[1].Block.Text)
+
+	para := section.Children[1]
+	require.Equal(t, "Para", para.Block.Text, "paragraphs after the list must not become list items")
+	require.Equal(t, "blockquote", para.Block.Attributes["childrenType"])
+	require.Len(t, para.Children, 1)
+	require.Equal(t, "ul", para.Children[0].Block.Attributes["childrenType"], "quote children type must not be overwritten by lists")
+	require.Equal(t, "quoted", para.Children[0].Children[0].Block.Text)
+}
+
+func TestImportMarkdown(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	doc, err := alice.ImportMarkdown(ctx, &pb.ImportMarkdownRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "/wiki/page",
+		Markdown:       "---\ntitle: Wiki page\n---\n\nHello\n\n- one\n- two\n",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Wiki page", doc.Metadata["title"])
+	require.Len(t, doc.Content, 1)
+	require.Equal(t, "Hello", doc.Content[0].Block.Text)
+	require.Len(t, doc.Content[0].Children, 2)
+	require.Equal(t, "two", doc.Content[0].Children[1].Block.Text)
+
+	doc2, err := alice.ImportMarkdown(ctx, &pb.ImportMarkdownRequest{
+		SigningKeyName: "main",
+		Account:        doc.Account,
+		Path:           doc.Path,
+		BaseVersion:    doc.Version,
+		Markdown:       "Replaced content\n",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Wiki page", doc2.Metadata["title"], "metadata must be preserved")
+	require.Len(t, doc2.Content, 1, "old content must be replaced")
+	require.Equal(t, "Replaced content", doc2.Content[0].Block.Text)
+
+	md, err := alice.ExportDocument(ctx, &pb.ExportDocumentRequest{Account: doc.Account, Path: doc.Path})
+	require.NoError(t, err)
+	require.Equal(t, "---\ntitle: \"Wiki page\"\n---\n\nReplaced content\n", md.Markdown)
+}
@@ -12,7 +12,6 @@ import (
 	"seed/backend/index"
 	"seed/backend/util/colx"
 	"sort"
-	"strings"
 
 	"github.com/ipfs/boxo/blockstore"
 	blocks "github.com/ipfs/go-block-format"
@@ -70,7 +69,6 @@ func originFromCID(c cid.Cid) string {
 func New(e *Entity, nextHLC hlc.Timestamp) (*Document, error) {
 	dm := &Document{
 		e:             e,
-		tree:          newTreeCRDT(),
 		patch:         map[string]any{},
 		origins:       make(map[string]cid.Cid),
 		createdBlocks: make(map[string]struct{}),
@@ -78,44 +76,26 @@ func New(e *Entity, nextHLC hlc.Timestamp) (*Document, error) {
 		nextHLC:       nextHLC,
 	}
 
+	if e.base != nil {
+		for _, c := range e.base.Origins {
+			dm.origins[originFromCID(c)] = c
+		}
+	}
+
 	for _, c := range e.cids {
 		o := originFromCID(c)
 		dm.origins[o] = c
 	}
 
-	if err := dm.replayMoves(); err != nil {
+	tree, err := e.buildTree()
+	if err != nil {
 		return nil, err
 	}
+	dm.tree = tree
 
 	return dm, nil
 }
 
-func (dm *Document) replayMoves() (err error) {
-	dm.e.State().ForEachListChunk([]string{"moves"}, func(time int64, origin string, items []any) bool {
-		for idx, move := range items {
-			mm := move.(map[string]any)
-			block := mm["b"].(string)
-			parent := mm["p"].(string)
-			leftShadow := mm["l"].(string)
-			left, leftOrigin, _ := strings.Cut(leftShadow, "@")
-			if left != "" && leftOrigin == "" {
-				leftOrigin = origin
-			}
-
-			if err = dm.tree.integrate(newOpID(origin, time, idx), block, parent, left, leftOrigin); err != nil {
-				err = fmt.Errorf("failed move %v: %w", move, err)
-				return false
-			}
-		}
-		return true
-	})
-	if err != nil {
-		return fmt.Errorf("failed to replay previous moves: %w", err)
-	}
-
-	return nil
-}
-
 // SetMetadata sets the title of the document.
 func (dm *Document) SetMetadata(key, value string) error {
 	v, ok := dm.e.Get("metadata", key)
@@ -131,7 +111,7 @@ func (dm *Document) SetMetadata(key, value string) error {
 // DeleteBlock deletes a block.
 func (dm *Document) DeleteBlock(block string) error {
 	mut := dm.ensureMutation()
-	me, err := mut.move(block, TrashNodeID, "")
+	me, err := mut.move(block, index.TrashNodeID, "")
 	if err != nil {
 		return err
 	}
@@ -166,7 +146,7 @@ func (dm *Document) ReplaceBlock(blk *documents.Block) error {
 
 // MoveBlock moves a block.
 func (dm *Document) MoveBlock(block, parent, left string) error {
-	if parent == TrashNodeID {
+	if parent == index.TrashNodeID {
 		panic("BUG: use DeleteBlock to delete a block")
 	}
 
@@ -228,12 +208,16 @@ func (dm *Document) Change(kp core.KeyPair) (hb index.EncodedBlob[*index.Change]
 // Ref creates a Ref blob for the current heads.
 func (dm *Document) Ref(kp core.KeyPair) (ref index.EncodedBlob[*index.Ref], err error) {
 	// TODO(hm24): make genesis detection more reliable.
-	genesis := dm.e.cids[0]
+	genesis := dm.e.Genesis()
 
 	if len(dm.e.heads) != 1 {
 		return ref, fmt.Errorf("TODO: creating refs for multiple heads is not supported yet")
 	}
 
+	if len(dm.e.cids) == 0 {
+		return ref, fmt.Errorf("no changes to create a ref for")
+	}
+
 	headCID := dm.e.cids[len(dm.e.cids)-1]
 	head := dm.e.changes[len(dm.e.cids)-1]
 
@@ -311,7 +295,7 @@ func (dm *Document) Entity() *Entity {
 
 // Hydrate hydrates a document.
 func (dm *Document) Hydrate(ctx context.Context) (*documents.Document, error) {
-	if len(dm.e.changes) == 0 {
+	if len(dm.e.heads) == 0 {
 		return nil, fmt.Errorf("no changes in the entity")
 	}
 
@@ -321,9 +305,6 @@ func (dm *Document) Hydrate(ctx context.Context) (*documents.Document, error) {
 
 	e := dm.e
 
-	first := e.changes[0]
-	last := e.changes[len(e.changes)-1]
-
 	// TODO(burdiyan): this is ugly and needs to be refactored.
 	u, err := url.Parse(string(e.ID()))
 	if err != nil {
@@ -337,11 +318,11 @@ func (dm *Document) Hydrate(ctx context.Context) (*documents.Document, error) {
 		Account:    account,
 		Path:       path,
 		Metadata:   make(map[string]string),
-		CreateTime: timestamppb.New(hlc.Timestamp(first.Ts).Time()),
+		CreateTime: timestamppb.New(e.CreateTime().Time()),
 		Version:    e.Version().String(),
 	}
 
-	docpb.UpdateTime = timestamppb.New(hlc.Timestamp(last.Ts).Time())
+	docpb.UpdateTime = timestamppb.New(e.UpdateTime().Time())
 
 	for _, key := range e.state.Keys("metadata") {
 		v, ok := e.state.GetAny("metadata", key).(string)
@@ -1,10 +1,12 @@
 package docmodel
 
 import (
+	"bytes"
 	"cmp"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"iter"
 	"seed/backend/core"
@@ -15,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
 	"github.com/multiformats/go-multibase"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
@@ -33,6 +36,7 @@ type Entity struct {
 	maxClock     *hlc.Clock
 	actorsIntern map[string]string
 	vectorClock  map[string]int64
+	base         *index.Snapshot // snapshot the entity was restored from, if any.
 }
 
 // NewEntity creates a new entity with a given ID.
@@ -78,11 +82,336 @@ func (e *Entity) Heads() map[cid.Cid]struct{} {
 	return e.heads
 }
 
-// NumChanges returns the number of changes applied to the entity.
+// NumChanges returns the number of changes applied to the entity,
+// including the ones covered by the snapshot the entity was restored from.
 func (e *Entity) NumChanges() int {
+	if e.base != nil {
+		return e.base.NumChanges + len(e.cids)
+	}
 	return len(e.cids)
 }
 
+// Genesis returns the CID of the first change of the entity.
+func (e *Entity) Genesis() cid.Cid {
+	if e.base != nil {
+		return e.base.GenesisBlob
+	}
+
+	if len(e.cids) == 0 {
+		return cid.Undef
+	}
+
+	return e.cids[0]
+}
+
+// CreateTime returns the timestamp of the first change of the entity.
+func (e *Entity) CreateTime() hlc.Timestamp {
+	if e.base != nil {
+		return hlc.Timestamp(e.base.CreateTime)
+	}
+
+	if len(e.changes) == 0 {
+		return 0
+	}
+
+	return hlc.Timestamp(e.changes[0].Ts)
+}
+
+// UpdateTime returns the timestamp of the last change of the entity.
+func (e *Entity) UpdateTime() hlc.Timestamp {
+	var ts int64
+	if e.base != nil {
+		ts = e.base.UpdateTime
+	}
+
+	if len(e.changes) > 0 {
+		ts = max(ts, e.changes[len(e.changes)-1].Ts)
+	}
+
+	return hlc.Timestamp(ts)
+}
+
+// RestoreSnapshot initializes the state of an empty entity from a snapshot.
+// The remaining changes are expected to be applied on top of it with ApplyChange.
+// Dependencies of those changes that are not applied to the entity
+// are assumed to be covered by the snapshot.
+// Entities restored from snapshots can't checkout previous versions,
+// because they don't have the full history of changes.
+func (e *Entity) RestoreSnapshot(snap *index.Snapshot) error {
+	if len(e.cids) != 0 || e.base != nil {
+		return fmt.Errorf("snapshots can only be restored into empty entities")
+	}
+
+	if snap.Resource != e.id {
+		return fmt.Errorf("snapshot is for resource %s, not %s", snap.Resource, e.id)
+	}
+
+	if len(snap.Heads) == 0 {
+		return fmt.Errorf("snapshot must have heads")
+	}
+
+	if err := e.state.LoadNodes(snap.MapNodes()); err != nil {
+		return fmt.Errorf("failed to load snapshot state: %w", err)
+	}
+
+	if err := e.maxClock.Track(hlc.Timestamp(snap.UpdateTime)); err != nil {
+		return err
+	}
+
+	for _, h := range snap.Heads {
+		e.heads[h] = struct{}{}
+	}
+
+	for _, a := range snap.Authors {
+		au := a.UnsafeString()
+		e.actorsIntern[au] = au
+	}
+
+	e.base = snap
+
+	return nil
+}
+
+// Snapshot returns the unsigned snapshot of the current state of the entity.
+func (e *Entity) Snapshot() (index.SnapshotUnsigned, error) {
+	if len(e.heads) == 0 {
+		return index.SnapshotUnsigned{}, fmt.Errorf("can't snapshot entity without changes")
+	}
+
+	nodes := e.state.Nodes()
+
+	// We only need to keep the origins that are still referenced by the state.
+	origins := make(map[string]struct{}, len(nodes))
+	for _, n := range nodes {
+		origins[n.Origin] = struct{}{}
+	}
+
+	var originCIDs []cid.Cid
+	if e.base != nil {
+		for _, c := range e.base.Origins {
+			if _, ok := origins[OriginFromCID(c)]; ok {
+				originCIDs = append(originCIDs, c)
+			}
+		}
+	}
+	for _, c := range e.cids {
+		if _, ok := origins[OriginFromCID(c)]; ok {
+			originCIDs = append(originCIDs, c)
+		}
+	}
+
+	tree, err := e.buildTree()
+	if err != nil {
+		return index.SnapshotUnsigned{}, err
+	}
+
+	moves := make([]index.SnapshotMove, 0, tree.log.Len())
+	tree.log.Scan(func(m *move) bool {
+		moves = append(moves, index.SnapshotMove{
+			Block:      m.Block,
+			Parent:     m.Parent,
+			Left:       m.Left,
+			LeftOrigin: m.LeftOrigin,
+			Origin:     m.OpID.Origin,
+			Ts:         m.OpID.Ts,
+			Idx:        m.OpID.Idx,
+			Fracdex:    m.Fracdex,
+		})
+		return true
+	})
+
+	actors := maps.Keys(e.actorsIntern)
+	sort.Strings(actors)
+	authors := make([]core.Principal, len(actors))
+	for i, a := range actors {
+		authors[i] = core.Principal(a)
+	}
+
+	return index.SnapshotUnsigned{
+		Resource:    e.id,
+		GenesisBlob: e.Genesis(),
+		Heads:       SortCIDs(maps.Keys(e.heads)),
+		State:       index.NewSnapshotState(nodes),
+		Tree:        moves,
+		Origins:     SortCIDs(originCIDs),
+		Authors:     authors,
+		NumChanges:  e.NumChanges(),
+		CreateTime:  int64(e.CreateTime()),
+		UpdateTime:  int64(e.UpdateTime()),
+	}, nil
+}
+
+// CreateSnapshot creates a signed snapshot blob of the current state of the entity.
+func (e *Entity) CreateSnapshot(ts hlc.Timestamp, signer core.KeyPair) (eb index.EncodedBlob[*index.Snapshot], err error) {
+	su, err := e.Snapshot()
+	if err != nil {
+		return eb, err
+	}
+
+	return index.NewSnapshot(signer, su, int64(ts))
+}
+
+// errMoveBeforeSnapshot is returned when a change applied on top of a snapshot
+// has moves that must be ordered before some of the moves covered by the snapshot.
+var errMoveBeforeSnapshot = errors.New("move is ordered before the snapshot")
+
+// buildTree builds the block tree CRDT from the moves in the state of the entity.
+// If the entity was restored from a snapshot, it starts from the tree stored in the snapshot,
+// and only integrates the moves of the changes applied after it.
+func (e *Entity) buildTree() (*treeCRDT, error) {
+	if e.base != nil && len(e.base.Tree) > 0 {
+		tree, err := e.restoreTree()
+		if err == nil {
+			return tree, nil
+		}
+
+		// Concurrent changes could have moves that must go before the ones in the snapshot,
+		// in which case we have to integrate all the moves from scratch.
+		if !errors.Is(err, errMoveBeforeSnapshot) {
+			return nil, err
+		}
+	}
+
+	tree := newTreeCRDT()
+	if err := replayMoves(e.state, tree, nil); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func (e *Entity) restoreTree() (*treeCRDT, error) {
+	tree := newTreeCRDT()
+
+	var last opID
+	for i, m := range e.base.Tree {
+		op := &move{
+			OpID:       newOpID(m.Origin, m.Ts, m.Idx),
+			Block:      m.Block,
+			Parent:     m.Parent,
+			Left:       m.Left,
+			LeftOrigin: m.LeftOrigin,
+			Fracdex:    m.Fracdex,
+		}
+
+		if i > 0 && !last.Less(op.OpID) {
+			return nil, fmt.Errorf("snapshot moves must be sorted")
+		}
+		last = op.OpID
+
+		if err := tree.restore(op); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := replayMoves(e.state, tree, func(op opID, block string) (bool, error) {
+		if _, ok := tree.origins[[2]string{block, op.Origin}]; ok {
+			return false, nil
+		}
+
+		if !last.Less(op) {
+			return false, errMoveBeforeSnapshot
+		}
+
+		return true, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+// replayMoves integrates the moves from the CRDT state into the tree.
+// The optional filter decides which moves must be integrated.
+func replayMoves(state *crdt2.Map, tree *treeCRDT, filter func(op opID, block string) (bool, error)) (err error) {
+	state.ForEachListChunk([]string{"moves"}, func(time int64, origin string, items []any) bool {
+		for idx, move := range items {
+			mm := move.(map[string]any)
+			block := mm["b"].(string)
+			parent := mm["p"].(string)
+			leftShadow := mm["l"].(string)
+			left, leftOrigin, _ := strings.Cut(leftShadow, "@")
+			if left != "" && leftOrigin == "" {
+				leftOrigin = origin
+			}
+
+			op := newOpID(origin, time, idx)
+			if filter != nil {
+				var ok bool
+				ok, err = filter(op, block)
+				if err != nil {
+					return false
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			if err = tree.integrate(op, block, parent, left, leftOrigin); err != nil {
+				err = fmt.Errorf("failed move %v: %w", move, err)
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to replay previous moves: %w", err)
+	}
+
+	return nil
+}
+
+// HasChanges checks whether all the given changes are applied to the entity.
+func (e *Entity) HasChanges(cids ...cid.Cid) bool {
+	for _, c := range cids {
+		if _, ok := e.applied[c]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// VerifySnapshot checks that the state of the snapshot matches the state
+// recomputed from the changes of the entity up to the heads of the snapshot.
+// The entity must have the full history of changes.
+func (e *Entity) VerifySnapshot(snap *index.Snapshot) error {
+	if e.base != nil {
+		return fmt.Errorf("can't verify snapshots with entities restored from snapshots")
+	}
+
+	ee, err := e.Checkout(snap.Heads)
+	if err != nil {
+		return fmt.Errorf("failed to checkout snapshot heads: %w", err)
+	}
+
+	want, err := ee.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	got := snap.SnapshotUnsigned
+	want.Type, want.Author, want.Ts = got.Type, got.Author, got.Ts
+
+	wantData, err := cbornode.DumpObject(want)
+	if err != nil {
+		return err
+	}
+
+	gotData, err := cbornode.DumpObject(got)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(wantData, gotData) {
+		return fmt.Errorf("snapshot state doesn't match the recomputed state")
+	}
+
+	return nil
+}
+
+// ErrHeadNotFound is returned when checking out a version that includes changes unknown to the entity.
+var ErrHeadNotFound = errors.New("head not found")
+
 // Checkout returns an entity with the state filtered up to the given heads.
 // If no heads are given it returns the same instance of the Entity.
 // If heads given are the same as the current heads, the same instance is returned as well.
@@ -100,6 +429,10 @@ func (e *Entity) Checkout(heads []cid.Cid) (*Entity, error) {
 		}
 	}
 
+	if e.base != nil {
+		return nil, fmt.Errorf("can't checkout previous versions of entities restored from snapshots")
+	}
+
 	// We walk the DAG of changes backwards starting from the heads.
 	// And then we apply those changes to the cloned entity.
 
@@ -110,7 +443,7 @@ func (e *Entity) Checkout(heads []cid.Cid) (*Entity, error) {
 	for _, h := range heads {
 		hh, ok := e.applied[h]
 		if !ok {
-			return nil, fmt.Errorf("head '%s' not found", h)
+			return nil, fmt.Errorf("%w: '%s'", ErrHeadNotFound, h)
 		}
 
 		queue = append(queue, hh)
@@ -272,6 +605,11 @@ func (e *Entity) ApplyChange(rec index.ChangeRecord) error {
 	for i, dep := range rec.Data.Deps {
 		depIdx, ok := e.applied[dep]
 		if !ok {
+			if e.base != nil {
+				// The dependency is covered by the snapshot.
+				deps[i] = -1
+				continue
+			}
 			return fmt.Errorf("missing dependency %s of change %s", dep, rec.CID)
 		}
 
@@ -300,6 +638,10 @@ func (e *Entity) ApplyChange(rec index.ChangeRecord) error {
 		// If any of the deps was a head, then it's no longer the case.
 		delete(e.heads, dep)
 
+		if deps[i] == -1 {
+			continue
+		}
+
 		// Keeping the DAG edges between deps in both directions.
 		e.deps[curIdx] = addUnique(e.deps[curIdx], deps[i])
 		e.rdeps[deps[i]] = addUnique(e.rdeps[deps[i]], curIdx)
@@ -332,7 +674,13 @@ func (e *Entity) Deps() []cid.Cid {
 			break
 		}
 
-		return slices.Clone(e.changes[e.applied[head]].Deps)
+		ihead, ok := e.applied[head]
+		if !ok {
+			// The head is covered by the snapshot, so we don't know its deps.
+			return nil
+		}
+
+		return slices.Clone(e.changes[ihead].Deps)
 	}
 
 	// These two sets initially will contain all deps of the heads
@@ -344,6 +692,9 @@ func (e *Entity) Deps() []cid.Cid {
 	for head := range e.heads {
 		ihead, ok := e.applied[head]
 		if !ok {
+			if e.base != nil {
+				continue
+			}
 			panic("BUG: head change not applied")
 		}
 
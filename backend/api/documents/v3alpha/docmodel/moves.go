@@ -2,6 +2,7 @@ package docmodel
 
 import (
 	"fmt"
+	"seed/backend/index"
 
 	"github.com/tidwall/btree"
 	"golang.org/x/exp/maps"
@@ -16,8 +17,6 @@ const (
 	moveEffectMoved   moveEffect = 2
 )
 
-const TrashNodeID = "◊"
-
 type opID struct {
 	Origin string
 	Ts     int64
@@ -82,7 +81,7 @@ func (state *treeCRDT) integrate(opID opID, block, parent, left, leftOrigin stri
 		return fmt.Errorf("duplicate move operation per block and origin: %s@%s", block, opID.Origin)
 	}
 
-	if left == TrashNodeID {
+	if left == index.TrashNodeID {
 		return fmt.Errorf("left must not be trash")
 	}
 
@@ -117,6 +116,20 @@ func (state *treeCRDT) integrate(opID opID, block, parent, left, leftOrigin stri
 	return nil
 }
 
+// restore puts an already integrated move operation into the tree.
+func (state *treeCRDT) restore(op *move) error {
+	origin := [2]string{op.Block, op.OpID.Origin}
+	if _, ok := state.origins[origin]; ok {
+		return fmt.Errorf("duplicate move operation per block and origin: %s@%s", op.Block, op.OpID.Origin)
+	}
+
+	state.log.SetHint(op, &state.logHint)
+	state.tree.SetHint(op, &state.treeHint)
+	state.origins[origin] = op
+
+	return nil
+}
+
 func (state *treeCRDT) findInsertionPoint(opID opID, parent, block, origin string) (left string, right string, err error) {
 	pivot := &move{Parent: parent}
 
@@ -213,7 +226,7 @@ func (state *treeCRDT) mutate() *treeMutation {
 func (mut *treeMutation) isAncestor(a, b string) bool {
 	c := mut.parents[b]
 	for {
-		if c == "" || c == TrashNodeID {
+		if c == "" || c == index.TrashNodeID {
 			return false
 		}
 
@@ -234,7 +247,7 @@ func (mut *treeMutation) move(block, parent, left string) (moveEffect, error) {
 		return moveEffectNone, fmt.Errorf("block and left must not be the same")
 	}
 
-	if left == TrashNodeID {
+	if left == index.TrashNodeID {
 		panic("BUG: trash can't be left")
 	}
 
@@ -243,7 +256,7 @@ func (mut *treeMutation) move(block, parent, left string) (moveEffect, error) {
 	}
 
 	// Check if parent is in the tree.
-	if parent != "" && parent != TrashNodeID {
+	if parent != "" && parent != index.TrashNodeID {
 		if _, ok := mut.parents[parent]; !ok {
 			return moveEffectNone, fmt.Errorf("desired parent block %s is not in the tree", parent)
 		}
@@ -280,7 +293,7 @@ func (mut *treeMutation) move(block, parent, left string) (moveEffect, error) {
 		me = moveEffectCreated
 	case prevWinner != nil:
 		// When we're moving to trash we don't care about the sibling order.
-		if prevWinner.Parent == TrashNodeID && parent == TrashNodeID {
+		if prevWinner.Parent == index.TrashNodeID && parent == index.TrashNodeID {
 			return moveEffectNone, nil
 		}
 
@@ -333,7 +346,7 @@ func (mut *treeMutation) move(block, parent, left string) (moveEffect, error) {
 
 	// Maybe do the naive cleanup. We can only do it if we move within the same parent.
 	original, ok := mut.originalWinners.Get(block)
-	if !ok && m.Parent == TrashNodeID {
+	if !ok && m.Parent == index.TrashNodeID {
 		// If we're moving a block to trash,
 		// and this block didn't exist in the original tree,
 		// we can just discard this move all together.
@@ -379,7 +392,7 @@ func (mut *treeMutation) forEachMove(fn func(block, parent, left, leftOrigin str
 			return true
 		}
 
-		if m.Parent == TrashNodeID {
+		if m.Parent == index.TrashNodeID {
 			panic("BUG: cleanup must only walk the visible block tree")
 		}
 
@@ -389,7 +402,7 @@ func (mut *treeMutation) forEachMove(fn func(block, parent, left, leftOrigin str
 	})
 
 	// Now walk the deleted blocks.
-	pivot := &move{Parent: TrashNodeID}
+	pivot := &move{Parent: index.TrashNodeID}
 	mut.tree.AscendHint(pivot, func(m *move) bool {
 		if m == pivot || m.Parent != pivot.Parent {
 			return true
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"seed/backend/index"
 	"strings"
 	"testing"
 
@@ -163,7 +164,7 @@ func TestRedundantMoveMutation(t *testing.T) {
 	move(bob, moveEffectMoved, "b2", "b1", "")
 	move(bob, moveEffectMoved, "b3", "b1", "b2")
 	move(bob, moveEffectCreated, "b4", "b1", "")
-	move(bob, moveEffectMoved, "b4", TrashNodeID, "")
+	move(bob, moveEffectMoved, "b4", index.TrashNodeID, "")
 
 	require.NoError(t, alice.commit("alice-1", 2, state))
 	require.NoError(t, bob.commit("bob", 2, state))
@@ -3,8 +3,10 @@ package documents
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"math"
 	"net/url"
 	"seed/backend/api/documents/v3alpha/docmodel"
@@ -15,9 +17,11 @@ import (
 	"seed/backend/util/apiutil"
 	"seed/backend/util/dqb"
 	"seed/backend/util/errutil"
+	"seed/backend/util/must"
 	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
 
+	lru "github.com/hashicorp/golang-lru/v2"
 	blocks "github.com/ipfs/go-block-format"
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
@@ -25,7 +29,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Server implements Documents API v3.
@@ -34,15 +40,23 @@ type Server struct {
 	idx  *index.Index
 	db   *sqlitex.Pool
 	log  *zap.Logger
+
+	// Hydrated documents keyed by IRI and version.
+	// Versions are immutable, so cached documents never become stale.
+	docCache *lru.Cache[string, *documents.Document]
 }
 
+// docCacheSize is the maximum number of hydrated documents to keep in memory.
+const docCacheSize = 256
+
 // NewServer creates a new Documents API v3 server.
 func NewServer(keys core.KeyStore, idx *index.Index, db *sqlitex.Pool, log *zap.Logger) *Server {
 	return &Server{
-		keys: keys,
-		idx:  idx,
-		db:   db,
-		log:  log,
+		keys:     keys,
+		idx:      idx,
+		db:       db,
+		log:      log,
+		docCache: must.Do2(lru.New[string, *documents.Document](docCacheSize)),
 	}
 }
 
@@ -66,14 +80,86 @@ func (srv *Server) GetDocument(ctx context.Context, in *documents.GetDocumentReq
 		return nil, err
 	}
 
-	doc, err := srv.loadDocument(ctx, ns, in.Path, docmodel.Version(in.Version), false)
+	iri, err := makeIRI(ns, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	version := in.Version
+
+	// Deleted documents are only available when the exact version is requested.
+	if version == "" {
+		deleted, err := srv.isDeleted(ctx, iri)
+		if err != nil {
+			return nil, err
+		}
+
+		if deleted {
+			return nil, status.Errorf(codes.NotFound, "document '%s' is deleted", iri)
+		}
+
+		version, err = srv.latestVersion(ctx, iri)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if version != "" {
+		if doc, ok := srv.docCache.Get(docCacheKey(iri, version)); ok {
+			return proto.Clone(doc).(*documents.Document), nil
+		}
+	}
+
+	var doc *docmodel.Document
+	if in.Version == "" {
+		doc, err = srv.loadLatestDocument(ctx, ns, in.Path)
+	} else {
+		doc, err = srv.loadDocument(ctx, ns, in.Path, docmodel.Version(in.Version), false)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := doc.Hydrate(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return doc.Hydrate(ctx)
+	srv.docCache.Add(docCacheKey(iri, out.Version), proto.Clone(out).(*documents.Document))
+
+	return out, nil
 }
 
+func docCacheKey(iri index.IRI, version string) string {
+	return string(iri) + "?v=" + version
+}
+
+// latestVersion returns the version of the materialized latest state of the document.
+// It returns an empty string if the state is unknown.
+func (srv *Server) latestVersion(ctx context.Context, iri index.IRI) (version string, err error) {
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qLatestVersion(), func(stmt *sqlite.Stmt) error {
+		version = stmt.ColumnText(0)
+		return nil
+	}, iri); err != nil {
+		return "", err
+	}
+
+	return version, nil
+}
+
+var qLatestVersion = dqb.Str(`
+	SELECT ds.version
+	FROM document_states ds
+	JOIN resources r ON r.id = ds.resource
+	WHERE r.iri = :iri;
+`)
+
 // CreateDocumentChange implements Documents API v3.
 func (srv *Server) CreateDocumentChange(ctx context.Context, in *documents.CreateDocumentChangeRequest) (*documents.Document, error) {
 	{
@@ -155,10 +241,27 @@ func (srv *Server) CreateDocumentChange(ctx context.Context, in *documents.Creat
 	}
 	newBlobs = append(newBlobs, ref)
 
+	var snapc cid.Cid
+	if doc.Entity().NumChanges()%snapshotInterval == 0 {
+		snap, err := doc.Entity().CreateSnapshot(hlc.Timestamp(docChange.Decoded.Ts), kp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create snapshot: %w", err)
+		}
+		newBlobs = append(newBlobs, snap)
+		snapc = snap.CID
+	}
+
 	if err := srv.idx.PutMany(ctx, newBlobs); err != nil {
 		return nil, err
 	}
 
+	// Our own snapshots are created from the verified state, so they don't need to be checked again.
+	if snapc.Defined() {
+		if err := srv.idx.MarkSnapshotVerified(ctx, snapc, true); err != nil {
+			return nil, err
+		}
+	}
+
 	return srv.GetDocument(ctx, &documents.GetDocumentRequest{
 		Account: in.Account,
 		Path:    in.Path,
@@ -207,33 +310,14 @@ func (srv *Server) ListRootDocuments(ctx context.Context, in *documents.ListRoot
 		}
 		count++
 
-		var (
-			id  = stmt.ColumnInt64(0)
-			iri = stmt.ColumnText(1)
-		)
+		lastCursor.ID = stmt.ColumnInt64(0)
 
-		u, err := url.Parse(iri)
+		item, err := documentListItemFromRow(stmt)
 		if err != nil {
 			return err
 		}
 
-		ns, err := core.DecodePrincipal(u.Host)
-		if err != nil {
-			return err
-		}
-		lastCursor.ID = id
-
-		doc, err := srv.GetDocument(ctx, &documents.GetDocumentRequest{
-			Account: ns.String(),
-			Path:    "",
-		})
-		if err != nil {
-			srv.log.Error("Partial or corrupted root document", zap.Error(err))
-			return nil
-		}
-
-		// TODO: use indexed data instead of loading the entire document.
-		out.Documents = append(out.Documents, DocumentToListItem(doc))
+		out.Documents = append(out.Documents, item)
 
 		return nil
 	}, lastCursor.ID, in.PageSize); err != nil {
@@ -245,13 +329,20 @@ func (srv *Server) ListRootDocuments(ctx context.Context, in *documents.ListRoot
 
 var qListRootDocuments = dqb.Str(`
 	SELECT
-		id,
-		iri
-	FROM resources
-	WHERE id < :last_cursor
-	AND iri NOT GLOB 'hm://*/**'
-	ORDER BY id DESC
-	LIMIT :page_size;
+		r.id,
+		r.iri,
+		ds.version,
+		ds.metadata,
+		ds.authors,
+		ds.create_time,
+		ds.update_time
+	FROM document_states ds
+	JOIN resources r ON r.id = ds.resource
+	WHERE r.id < :last_cursor
+	AND r.iri NOT GLOB 'hm://*/**'
+	AND r.iri NOT IN (SELECT iri FROM deleted_resources)
+	ORDER BY r.id DESC
+	LIMIT :page_size + 1;
 `)
 
 // ListDocuments implements Documents API v3.
@@ -308,31 +399,14 @@ func (srv *Server) ListDocuments(ctx context.Context, in *documents.ListDocument
 		}
 		count++
 
-		var (
-			id  = stmt.ColumnInt64(0)
-			iri = stmt.ColumnText(1)
-		)
-
-		lastCursor.ID = id
+		lastCursor.ID = stmt.ColumnInt64(0)
 
-		// TODO(burdiyan): This is a hack to get the account from the IRI.
-		u, err := url.Parse(iri)
+		item, err := documentListItemFromRow(stmt)
 		if err != nil {
 			return err
 		}
 
-		path := u.Path
-
-		doc, err := srv.GetDocument(ctx, &documents.GetDocumentRequest{
-			Account: u.Host,
-			Path:    path,
-		})
-		if err != nil {
-			return nil
-		}
-
-		// TODO: use indexed data instead of loading the entire document.
-		out.Documents = append(out.Documents, DocumentToListItem(doc))
+		out.Documents = append(out.Documents, item)
 
 		return nil
 	}, lastCursor.ID, namespaceGlob, in.PageSize); err != nil {
@@ -344,20 +418,140 @@ func (srv *Server) ListDocuments(ctx context.Context, in *documents.ListDocument
 
 var qListDocuments = dqb.Str(`
 	SELECT
-		id,
-		iri
-	FROM resources
-	WHERE id < :last_cursor
-	AND iri GLOB :namespace_glob
-	ORDER BY id DESC
+		r.id,
+		r.iri,
+		ds.version,
+		ds.metadata,
+		ds.authors,
+		ds.create_time,
+		ds.update_time
+	FROM document_states ds
+	JOIN resources r ON r.id = ds.resource
+	WHERE r.id < :last_cursor
+	AND r.iri GLOB :namespace_glob
+	AND r.iri NOT IN (SELECT iri FROM deleted_resources)
+	ORDER BY r.id DESC
 	LIMIT :page_size + 1;
 `)
 
+// documentListItemFromRow reads the list item from the materialized document state.
+// Columns must be in the same order as in the list queries, starting from the IRI.
+func documentListItemFromRow(stmt *sqlite.Stmt) (*documents.DocumentListItem, error) {
+	var (
+		iri        = stmt.ColumnText(1)
+		version    = stmt.ColumnText(2)
+		metadata   = stmt.ColumnBytesUnsafe(3)
+		authors    = stmt.ColumnBytesUnsafe(4)
+		createTime = stmt.ColumnInt64(5)
+		updateTime = stmt.ColumnInt64(6)
+	)
+
+	// TODO(burdiyan): This is a hack to get the account from the IRI.
+	u, err := url.Parse(iri)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &documents.DocumentListItem{
+		Account:    u.Host,
+		Path:       u.Path,
+		CreateTime: timestamppb.New(hlc.Timestamp(createTime).Time()),
+		UpdateTime: timestamppb.New(hlc.Timestamp(updateTime).Time()),
+		Version:    version,
+	}
+
+	if err := json.Unmarshal(metadata, &item.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode metadata of document %s: %w", iri, err)
+	}
+
+	if err := json.Unmarshal(authors, &item.Authors); err != nil {
+		return nil, fmt.Errorf("failed to decode authors of document %s: %w", iri, err)
+	}
+
+	return item, nil
+}
+
 // DeleteDocument implements Documents API v3.
 func (srv *Server) DeleteDocument(ctx context.Context, in *documents.DeleteDocumentRequest) (*emptypb.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, "DeleteDocument is not implemented yet")
+	{
+		if in.Account == "" {
+			return nil, errutil.MissingArgument("account")
+		}
+
+		if in.SigningKeyName == "" {
+			return nil, errutil.MissingArgument("signing_key_name")
+		}
+	}
+
+	ns, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode account %s: %v", in.Account, err)
+	}
+
+	kp, err := srv.keys.GetKey(ctx, in.SigningKeyName)
+	if err != nil {
+		return nil, err
+	}
+
+	var capc cid.Cid
+	if in.Capability != "" {
+		capc, err = cid.Decode(in.Capability)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := srv.checkWriteAccess(ctx, ns, in.Path, kp, capc); err != nil {
+		return nil, err
+	}
+
+	doc, err := srv.loadDocument(ctx, ns, in.Path, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	iri, err := makeIRI(ns, in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Using the document's clock to make sure the tombstone is newer than any of the existing changes.
+	tomb, err := index.NewTombstone(kp, iri, capc, int64(doc.Entity().NextTimestamp()))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := srv.idx.Put(ctx, tomb); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
 }
 
+func (srv *Server) isDeleted(ctx context.Context, iri index.IRI) (deleted bool, err error) {
+	conn, release, err := srv.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qIsDeleted(), func(*sqlite.Stmt) error {
+		deleted = true
+		return nil
+	}, iri); err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+var qIsDeleted = dqb.Str(`
+	SELECT 1
+	FROM deleted_resources
+	WHERE iri = :iri
+	LIMIT 1;
+`)
+
 func (srv *Server) ensureProfileGenesis(ctx context.Context, kp core.KeyPair) error {
 	ebc, err := index.NewChange(kp, nil, "Create", nil, index.ProfileGenesisEpoch)
 	if err != nil {
@@ -394,17 +588,9 @@ func (srv *Server) loadDocument(ctx context.Context, account core.Principal, pat
 	clock := hlc.NewClock()
 	entity := docmodel.NewEntityWithClock(iri, clock)
 
-	var outErr error
 	changes, check := srv.idx.IterChanges(ctx, iri, account)
-	for _, ch := range changes {
-		if err := entity.ApplyChange(ch); err != nil {
-			outErr = errors.Join(outErr, err)
-			break
-		}
-	}
-	outErr = errors.Join(outErr, check())
-	if outErr != nil {
-		return nil, outErr
+	if err := applyChangeRecords(entity, changes, check); err != nil {
+		return nil, err
 	}
 
 	if !ensurePath && len(entity.Heads()) == 0 {
@@ -431,6 +617,77 @@ func (srv *Server) loadDocument(ctx context.Context, account core.Principal, pat
 	return doc, nil
 }
 
+// snapshotInterval is the number of changes after which we create a new snapshot of the document.
+const snapshotInterval = 50
+
+// loadLatestDocument loads the latest state of the document.
+// It starts from the most recent snapshot if there's any, and only applies the changes that came after it.
+// Snapshots are checked against the full history of changes the first time they are used.
+// The resulting document doesn't have the full history of changes, so it can't be used to checkout previous versions.
+func (srv *Server) loadLatestDocument(ctx context.Context, account core.Principal, path string) (*docmodel.Document, error) {
+	iri, err := makeIRI(account, path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapc, snap, verified, err := srv.idx.LatestSnapshot(ctx, iri, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if snap == nil {
+		return srv.loadDocument(ctx, account, path, "", false)
+	}
+
+	if !verified {
+		doc, err := srv.loadDocument(ctx, account, path, "", false)
+		if err != nil {
+			return nil, err
+		}
+
+		// We can't check the snapshot until we have all the changes it covers.
+		if !doc.Entity().HasChanges(snap.Heads...) {
+			return doc, nil
+		}
+
+		verr := doc.Entity().VerifySnapshot(snap)
+		if verr != nil {
+			srv.log.Warn("InvalidSnapshot", zap.String("snapshot", snapc.String()), zap.Error(verr))
+		}
+
+		if err := srv.idx.MarkSnapshotVerified(ctx, snapc, verr == nil); err != nil {
+			return nil, err
+		}
+
+		return doc, nil
+	}
+
+	clock := hlc.NewClock()
+	entity := docmodel.NewEntityWithClock(iri, clock)
+
+	if err := entity.RestoreSnapshot(snap); err != nil {
+		return nil, fmt.Errorf("failed to restore snapshot %s: %w", snapc, err)
+	}
+
+	changes, check := srv.idx.IterChangesSince(ctx, iri, account, snapc)
+	if err := applyChangeRecords(entity, changes, check); err != nil {
+		return nil, err
+	}
+
+	return docmodel.New(entity, clock.MustNow())
+}
+
+func applyChangeRecords(entity *docmodel.Entity, changes iter.Seq2[int, index.ChangeRecord], check func() error) error {
+	var outErr error
+	for _, ch := range changes {
+		if err := entity.ApplyChange(ch); err != nil {
+			outErr = errors.Join(outErr, err)
+			break
+		}
+	}
+	return errors.Join(outErr, check())
+}
+
 func applyChanges(doc *docmodel.Document, ops []*documents.DocumentChange) error {
 	for _, op := range ops {
 		switch o := op.Op.(type) {
@@ -485,6 +742,15 @@ func (srv *Server) checkWriteAccess(ctx context.Context, account core.Principal,
 		return status.Errorf(codes.PermissionDenied, "capability %s is not delegated to key %s", capc, kp.Principal())
 	}
 
+	revoked, err := srv.idx.IsCapabilityRevoked(ctx, capc)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return status.Errorf(codes.PermissionDenied, "capability %s is revoked", capc)
+	}
+
 	grantedIRI, err := makeIRI(cpb.Account, cpb.Path)
 	if err != nil {
 		return err
@@ -495,17 +761,67 @@ func (srv *Server) checkWriteAccess(ctx context.Context, account core.Principal,
 		return err
 	}
 
-	if !(wantIRI >= grantedIRI && wantIRI < grantedIRI+"~~~~~~~") {
+	if !capabilityCovers(cpb, grantedIRI, wantIRI) {
 		return status.Errorf(codes.PermissionDenied, "capability %s grants path '%s' which doesn't cover '%s'", capc, grantedIRI, wantIRI)
 	}
 
-	if documents.Role(documents.Role_value[cpb.Role]) != documents.Role_WRITER {
+	if !roleCanWrite(cpb.Role) {
 		return status.Errorf(codes.PermissionDenied, "capability role %s is not allowed to write", cpb.Role)
 	}
 
+	// Nested capabilities are only valid if the chain of capabilities up to the owner is valid.
+	if !cpb.Issuer.Equal(account) {
+		valid, err := srv.isValidCapability(ctx, wantIRI, account, capc)
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			return status.Errorf(codes.PermissionDenied, "capability %s is not backed by a valid editor capability", capc)
+		}
+	}
+
 	return nil
 }
 
+// capabilityCovers checks whether the capability granted for the granted IRI covers the wanted IRI.
+// Non-recursive capabilities only cover their exact path.
+func capabilityCovers(cpb *index.Capability, granted, want index.IRI) bool {
+	if want == granted {
+		return true
+	}
+
+	if cpb.NoRecursive {
+		return false
+	}
+
+	return want >= granted && want < granted+"~~~~~~~"
+}
+
+// roleCanWrite checks whether the role grants write access.
+func roleCanWrite(role string) bool {
+	switch documents.Role(documents.Role_value[role]) {
+	case documents.Role_EDITOR, documents.Role_WRITER:
+		return true
+	default:
+		return false
+	}
+}
+
+// isValidCapability checks whether the capability is among the valid capabilities for the resource.
+func (srv *Server) isValidCapability(ctx context.Context, iri index.IRI, account core.Principal, capc cid.Cid) (valid bool, err error) {
+	if err := srv.idx.WalkCapabilities(ctx, iri, account, func(c cid.Cid, _ *index.Capability) error {
+		if c.Equals(capc) {
+			valid = true
+		}
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return valid, nil
+}
+
 // DocumentToListItem converts a document to a document list item.
 func DocumentToListItem(doc *documents.Document) *documents.DocumentListItem {
 	return &documents.DocumentListItem{
@@ -2,6 +2,7 @@ package documents
 
 import (
 	"context"
+	"seed/backend/api/documents/v3alpha/docmodel"
 	"seed/backend/core"
 	"seed/backend/core/coretest"
 	documents "seed/backend/genproto/documents/v3alpha"
@@ -10,6 +11,8 @@ import (
 	"seed/backend/storage"
 	"seed/backend/testutil"
 	"seed/backend/util/must"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -223,6 +226,147 @@ func TestListDocument(t *testing.T) {
 	testutil.StructsEqual(want[2], list.Documents[2]).Compare(t, "profile doc must be the last element in the list")
 }
 
+func TestListDocumentsAfterUpdate(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	doc, err := alice.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "/foo",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "First title"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	updated, err := alice.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        doc.Account,
+		Path:           doc.Path,
+		BaseVersion:    doc.Version,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Second title"},
+			}},
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "cover", Value: "ipfs://foo"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, doc.Version, updated.Version)
+
+	list, err := alice.ListDocuments(ctx, &documents.ListDocumentsRequest{Account: doc.Account})
+	require.NoError(t, err)
+	require.Len(t, list.Documents, 1)
+	testutil.StructsEqual(DocumentToListItem(updated), list.Documents[0]).Compare(t, "list must reflect the latest version")
+
+	latest, err := alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: doc.Account, Path: doc.Path})
+	require.NoError(t, err)
+	testutil.StructsEqual(updated, latest).Compare(t, "latest document must match")
+
+	// Mutating the returned document must not affect the cached one.
+	latest.Metadata["title"] = "Changed"
+	latest, err = alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: doc.Account, Path: doc.Path})
+	require.NoError(t, err)
+	require.Equal(t, "Second title", latest.Metadata["title"])
+}
+
+func TestDeleteDocument(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	profile, err := alice.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Alice's profile"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	namedDoc, err := alice.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "/named/foo",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Named document"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = alice.DeleteDocument(ctx, &documents.DeleteDocumentRequest{
+		SigningKeyName: "main",
+		Account:        namedDoc.Account,
+		Path:           namedDoc.Path,
+	})
+	require.NoError(t, err)
+
+	list, err := alice.ListDocuments(ctx, &documents.ListDocumentsRequest{Account: alice.me.Account.Principal().String()})
+	require.NoError(t, err)
+	require.Len(t, list.Documents, 1, "deleted document must not be listed")
+	testutil.StructsEqual(DocumentToListItem(profile), list.Documents[0]).Compare(t, "only profile doc must be listed")
+
+	_, err = alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: namedDoc.Account, Path: namedDoc.Path})
+	require.Error(t, err, "deleted document must not be found")
+
+	old, err := alice.GetDocument(ctx, &documents.GetDocumentRequest{Account: namedDoc.Account, Path: namedDoc.Path, Version: namedDoc.Version})
+	require.NoError(t, err, "deleted document must be available with exact version")
+	testutil.StructsEqual(namedDoc, old).Compare(t, "old version of deleted document must match")
+
+	// Bob gets all of Alice's blobs and must see the deletion too.
+	{
+		bob := newTestDocsAPI(t, "bob")
+
+		cids, err := alice.idx.AllKeysChan(ctx)
+		require.NoError(t, err)
+		for c := range cids {
+			blk, err := alice.idx.Get(ctx, c)
+			require.NoError(t, err)
+			require.NoError(t, bob.idx.Put(ctx, blk))
+		}
+
+		list, err := bob.ListDocuments(ctx, &documents.ListDocumentsRequest{Account: alice.me.Account.Principal().String()})
+		require.NoError(t, err)
+		require.Len(t, list.Documents, 1, "deleted document must not be listed after syncing")
+
+		roots, err := bob.ListRootDocuments(ctx, &documents.ListRootDocumentsRequest{})
+		require.NoError(t, err)
+		require.Len(t, roots.Documents, 1)
+	}
+
+	// Creating new changes after deletion must bring the document back.
+	recreated, err := alice.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        namedDoc.Account,
+		Path:           namedDoc.Path,
+		BaseVersion:    namedDoc.Version,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Named document is back"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	list, err = alice.ListDocuments(ctx, &documents.ListDocumentsRequest{Account: alice.me.Account.Principal().String()})
+	require.NoError(t, err)
+	require.Len(t, list.Documents, 2, "recreated document must be listed")
+	testutil.StructsEqual(DocumentToListItem(recreated), list.Documents[0]).Compare(t, "recreated document must match")
+}
+
 func TestGetDocumentWithVersion(t *testing.T) {
 	t.Parallel()
 
@@ -372,6 +516,17 @@ func TestConcurrentChanges(t *testing.T) {
 	})
 	require.NoError(t, err)
 	require.False(t, strings.Contains(merged.Version, "."), "merged version must not be composite")
+
+	list, err := alice.ListDocuments(ctx, &documents.ListDocumentsRequest{Account: merged.Account})
+	require.NoError(t, err)
+	require.Len(t, list.Documents, 1)
+	testutil.StructsEqual(DocumentToListItem(merged), list.Documents[0]).Compare(t, "list must reflect the merged version")
+
+	// The state updated incrementally must match the one rebuilt from scratch.
+	require.NoError(t, alice.idx.Reindex(ctx))
+	rebuilt, err := alice.ListDocuments(ctx, &documents.ListDocumentsRequest{Account: merged.Account})
+	require.NoError(t, err)
+	testutil.StructsEqual(list, rebuilt).Compare(t, "rebuilt state must match the incremental one")
 }
 
 type testServer struct {
@@ -388,3 +543,116 @@ func newTestDocsAPI(t *testing.T, name string) testServer {
 	srv := NewServer(ks, idx, db, logging.New("seed/documents"+"/"+name, "debug"))
 	return testServer{Server: srv, me: u}
 }
+
+func TestDocumentSnapshots(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+	account := alice.me.Account.Principal()
+
+	doc, err := alice.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account.String(),
+		Path:           "/snapshots",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Title 0"}}},
+			{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b1", Type: "paragraph", Text: "Hello"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	update := func(i int) {
+		blk := "b" + strconv.Itoa(i%5+2)
+		doc, err = alice.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+			SigningKeyName: "main",
+			Account:        doc.Account,
+			Path:           doc.Path,
+			BaseVersion:    doc.Version,
+			Changes: []*documents.DocumentChange{
+				{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Title " + strconv.Itoa(i)}}},
+				{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: blk, LeftSibling: "b1"}}},
+				{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: blk, Type: "paragraph", Text: "Text " + strconv.Itoa(i)}}},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	iri := must.Do2(makeIRI(account, doc.Path))
+
+	for i := 1; i < snapshotInterval-1; i++ {
+		update(i)
+	}
+
+	c, snap, _, err := alice.idx.LatestSnapshot(ctx, iri, account)
+	require.NoError(t, err)
+	require.False(t, c.Defined(), "must not have snapshots before the interval")
+	require.Nil(t, snap)
+
+	update(snapshotInterval - 1)
+
+	c, snap, verified, err := alice.idx.LatestSnapshot(ctx, iri, account)
+	require.NoError(t, err)
+	require.True(t, c.Defined(), "must create a snapshot after the interval")
+	require.True(t, verified, "own snapshots must be trusted")
+	require.NotEmpty(t, snap.Tree, "snapshot must include the block tree")
+	require.Equal(t, doc.Version, docmodel.NewVersion(snap.Heads...).String())
+	require.Equal(t, snapshotInterval, snap.NumChanges)
+
+	full, err := alice.loadDocument(ctx, account, doc.Path, "", false)
+	require.NoError(t, err)
+	require.NoError(t, full.Entity().VerifySnapshot(snap), "snapshot must match the recomputed state")
+
+	// Tampered snapshots must fail the verification.
+	{
+		tampered := *snap
+		tampered.State = slices.Clone(snap.State)
+		tampered.State[len(tampered.State)-1].Value = "tampered"
+		require.Error(t, full.Entity().VerifySnapshot(&tampered))
+	}
+
+	// Make more changes on top of the snapshot.
+	update(snapshotInterval)
+	update(snapshotInterval + 1)
+
+	full, err = alice.loadDocument(ctx, account, doc.Path, "", false)
+	require.NoError(t, err)
+	want, err := full.Hydrate(ctx)
+	require.NoError(t, err)
+
+	latest, err := alice.loadLatestDocument(ctx, account, doc.Path)
+	require.NoError(t, err)
+	require.Equal(t, snapshotInterval+2, latest.Entity().NumChanges())
+	got, err := latest.Hydrate(ctx)
+	require.NoError(t, err)
+
+	testutil.StructsEqual(want, got).Compare(t, "document loaded from snapshot must match the full document")
+	testutil.StructsEqual(doc, got).Compare(t, "document loaded from snapshot must match the latest version")
+
+	// Snapshots we didn't create must be verified before being used.
+	{
+		bad := snap.SnapshotUnsigned
+		bad.State = slices.Clone(snap.State)
+		bad.State[len(bad.State)-1].Value = "tampered"
+		badSnap, err := index.NewSnapshot(alice.me.Account, bad, snap.Ts+1)
+		require.NoError(t, err)
+		require.NoError(t, alice.idx.Put(ctx, badSnap))
+
+		bc, _, verified, err := alice.idx.LatestSnapshot(ctx, iri, account)
+		require.NoError(t, err)
+		require.Equal(t, badSnap.CID, bc)
+		require.False(t, verified)
+
+		latest, err := alice.loadLatestDocument(ctx, account, doc.Path)
+		require.NoError(t, err)
+		got, err := latest.Hydrate(ctx)
+		require.NoError(t, err)
+		testutil.StructsEqual(want, got).Compare(t, "invalid snapshot must not be used")
+
+		bc, _, verified, err = alice.idx.LatestSnapshot(ctx, iri, account)
+		require.NoError(t, err)
+		require.Equal(t, c, bc, "must fall back to the valid snapshot")
+		require.True(t, verified)
+	}
+}
@@ -0,0 +1,97 @@
+package documents
+
+import (
+	"seed/backend/core"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"seed/backend/util/errutil"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchDocument implements Documents API v3.
+func (srv *Server) WatchDocument(in *documents.WatchDocumentRequest, stream documents.Documents_WatchDocumentServer) error {
+	{
+		if in.Account == "" {
+			return errutil.MissingArgument("account")
+		}
+	}
+
+	acc, err := core.DecodePrincipal(in.Account)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to parse account '%s': %v", in.Account, err)
+	}
+
+	iri, err := makeIRI(acc, in.Path)
+	if err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+
+	// Subscribing before loading the initial state to avoid missing updates in between.
+	updates, cancel := srv.idx.WatchDocument(iri)
+	defer cancel()
+
+	// Diffs for the first update are computed against an empty document.
+	prev := &documents.Document{}
+
+	emit := func() error {
+		doc, err := srv.GetDocument(ctx, &documents.GetDocumentRequest{
+			Account: in.Account,
+			Path:    in.Path,
+		})
+		if err != nil {
+			if status.Code(err) != codes.NotFound {
+				return err
+			}
+
+			// The document may not exist yet, or may be deleted.
+			// We keep watching in case it appears later,
+			// and the diffs are computed against an empty document again.
+			if prev.Version == "" {
+				return nil
+			}
+
+			if err := stream.Send(&documents.DocumentUpdate{
+				Diff:    diffDocuments(prev, &documents.Document{}),
+				Deleted: true,
+			}); err != nil {
+				return err
+			}
+
+			prev = &documents.Document{}
+			return nil
+		}
+
+		if doc.Version == prev.Version {
+			return nil
+		}
+
+		if err := stream.Send(&documents.DocumentUpdate{
+			Version:    doc.Version,
+			Diff:       diffDocuments(prev, doc),
+			UpdateTime: doc.UpdateTime,
+		}); err != nil {
+			return err
+		}
+
+		prev = doc
+		return nil
+	}
+
+	if err := emit(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-updates:
+			if err := emit(); err != nil {
+				return err
+			}
+		}
+	}
+}
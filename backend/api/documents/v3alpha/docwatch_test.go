@@ -0,0 +1,109 @@
+package documents
+
+import (
+	"context"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type watchDocumentStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *pb.DocumentUpdate
+}
+
+func (s *watchDocumentStream) Context() context.Context { return s.ctx }
+
+func (s *watchDocumentStream) Send(u *pb.DocumentUpdate) error {
+	s.updates <- u
+	return nil
+}
+
+func TestWatchDocument(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d1, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "/watch",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "First title"}}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	stream := &watchDocumentStream{ctx: ctx, updates: make(chan *pb.DocumentUpdate, 10)}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- alice.WatchDocument(&pb.WatchDocumentRequest{Account: d1.Account, Path: d1.Path}, stream)
+	}()
+
+	recv := func() *pb.DocumentUpdate {
+		select {
+		case u := <-stream.updates:
+			return u
+		case err := <-errc:
+			require.FailNow(t, "watch finished unexpectedly", err)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for document update")
+		}
+		return nil
+	}
+
+	u := recv()
+	require.Equal(t, d1.Version, u.Version, "first update must reflect the current state")
+	require.Len(t, u.Diff.Blocks, 1)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_ADDED, u.Diff.Blocks[0].Kind)
+
+	d2, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        d1.Account,
+		Path:           d1.Path,
+		BaseVersion:    d1.Version,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello world"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	u = recv()
+	require.Equal(t, d2.Version, u.Version)
+	require.Equal(t, d1.Version, u.Diff.FromVersion)
+	require.Len(t, u.Diff.Metadata, 0)
+	require.Len(t, u.Diff.Blocks, 1)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_MODIFIED, u.Diff.Blocks[0].Kind)
+	require.True(t, u.Diff.Blocks[0].TextChanged)
+	require.Equal(t, d2.UpdateTime.AsTime(), u.UpdateTime.AsTime())
+
+	_, err = alice.DeleteDocument(ctx, &pb.DeleteDocumentRequest{
+		SigningKeyName: "main",
+		Account:        d1.Account,
+		Path:           d1.Path,
+	})
+	require.NoError(t, err)
+
+	u = recv()
+	require.True(t, u.Deleted, "deletion must be emitted")
+	require.Equal(t, "", u.Version)
+	require.Equal(t, d2.Version, u.Diff.FromVersion)
+	require.Len(t, u.Diff.Blocks, 1)
+	require.Equal(t, pb.BlockDiffKind_BLOCK_DIFF_KIND_REMOVED, u.Diff.Blocks[0].Kind)
+
+	cancel()
+	select {
+	case err := <-errc:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "watch must finish when the context is canceled")
+	}
+}
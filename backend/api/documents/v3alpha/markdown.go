@@ -0,0 +1,396 @@
+package documents
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	documents "seed/backend/genproto/documents/v3alpha"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// markdownRenderer renders documents as CommonMark text.
+// Block types and annotations follow the conventions of the frontend editor.
+type markdownRenderer struct {
+	// Optional base URL of the IPFS gateway to rewrite ipfs:// links.
+	ipfsGateway string
+}
+
+// Render the document with its metadata as YAML front matter.
+func (mr markdownRenderer) Render(doc *documents.Document) string {
+	var sb strings.Builder
+
+	if len(doc.Metadata) > 0 {
+		sb.WriteString("---\n")
+		for _, k := range slices.Sorted(maps.Keys(doc.Metadata)) {
+			sb.WriteString(k)
+			sb.WriteString(": ")
+			sb.WriteString(yamlQuote(doc.Metadata[k]))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("---\n")
+		if len(doc.Content) > 0 {
+			sb.WriteString("\n")
+		}
+	}
+
+	if body := mr.renderChildren(doc.Content, "", 0); body != "" {
+		sb.WriteString(body)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (mr markdownRenderer) renderNode(n *documents.BlockNode, depth int) string {
+	out := mr.renderBlock(n.Block, depth)
+
+	children := mr.renderChildren(n.Children, n.Block.Attributes["childrenType"], depth+1)
+	if children == "" {
+		return out
+	}
+
+	if out == "" {
+		return children
+	}
+
+	return out + "\n\n" + children
+}
+
+func (mr markdownRenderer) renderChildren(nodes []*documents.BlockNode, childrenType string, depth int) string {
+	if len(nodes) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(nodes))
+
+	switch childrenType {
+	case "ol":
+		for i, n := range nodes {
+			marker := strconv.Itoa(i+1) + ". "
+			parts = append(parts, indentLines(mr.renderNode(n, depth), marker, strings.Repeat(" ", len(marker))))
+		}
+		return strings.Join(parts, "\n")
+	case "ul":
+		for _, n := range nodes {
+			parts = append(parts, indentLines(mr.renderNode(n, depth), "- ", "  "))
+		}
+		return strings.Join(parts, "\n")
+	case "blockquote":
+		for _, n := range nodes {
+			parts = append(parts, mr.renderNode(n, depth))
+		}
+		return indentLines(strings.Join(parts, "\n\n"), "> ", "> ")
+	default:
+		for _, n := range nodes {
+			if out := mr.renderNode(n, depth); out != "" {
+				parts = append(parts, out)
+			}
+		}
+		return strings.Join(parts, "\n\n")
+	}
+}
+
+func (mr markdownRenderer) renderBlock(blk *documents.Block, depth int) string {
+	switch blk.Type {
+	case "heading":
+		level, err := strconv.Atoi(blk.Attributes["level"])
+		if err != nil || level < 1 {
+			level = depth + 1
+		}
+		level = min(level, 6)
+		return strings.Repeat("#", level) + " " + mr.renderInline(blk.Text, blk.Annotations)
+	case "code", "codeBlock":
+		lang := blk.Attributes["language"]
+		if lang == "" {
+			lang = blk.Attributes["lang"]
+		}
+		fence := strings.Repeat("`", max(3, longestRun(blk.Text, '`')+1))
+		return fence + lang + "\n" + blk.Text + "\n" + fence
+	case "math", "equation":
+		return "$$\n" + blk.Text + "\n$$"
+	case "image":
+		return "![" + escapeMarkdown(blk.Text) + "](" + escapeURL(mr.rewriteURL(blk.Ref)) + ")"
+	case "file", "video":
+		name := blk.Attributes["name"]
+		if name == "" {
+			name = blk.Ref
+		}
+		return "[" + escapeMarkdown(name) + "](" + escapeURL(mr.rewriteURL(blk.Ref)) + ")"
+	case "embed", "web-embed", "nostr":
+		text := blk.Text
+		if text == "" {
+			text = blk.Ref
+		}
+		return "[" + escapeMarkdown(text) + "](" + escapeURL(mr.rewriteURL(blk.Ref)) + ")"
+	default:
+		return escapeLineStarts(mr.renderInline(blk.Text, blk.Annotations))
+	}
+}
+
+func (mr markdownRenderer) rewriteURL(u string) string {
+	if mr.ipfsGateway == "" || !strings.HasPrefix(u, "ipfs://") {
+		return u
+	}
+
+	return strings.TrimSuffix(mr.ipfsGateway, "/") + "/" + strings.TrimPrefix(u, "ipfs://")
+}
+
+type inlineSpan struct {
+	Annotation *documents.Annotation
+	Start      int
+	End        int
+}
+
+// renderInline renders the text with the annotations applied as inline markup.
+// Annotation offsets are in UTF-16 code units, as produced by the frontend.
+// Overlapping spans are split to keep the markup properly nested.
+func (mr markdownRenderer) renderInline(text string, annotations []*documents.Annotation) string {
+	units := utf16.Encode([]rune(text))
+
+	var (
+		starts = make(map[int][]inlineSpan)
+		ends   = make(map[int][]inlineSpan)
+		embeds = make(map[int]inlineSpan)
+	)
+	for _, a := range annotations {
+		for i := range min(len(a.Starts), len(a.Ends)) {
+			span := inlineSpan{Annotation: a, Start: int(a.Starts[i]), End: int(a.Ends[i])}
+			if span.Start >= span.End || span.Start < 0 || span.End > len(units) {
+				continue
+			}
+
+			if a.Type == "inline-embed" {
+				embeds[span.Start] = span
+				continue
+			}
+
+			if _, _, ok := mr.inlineMarkers(a); !ok {
+				continue
+			}
+
+			starts[span.Start] = append(starts[span.Start], span)
+			ends[span.End] = append(ends[span.End], span)
+		}
+	}
+
+	// Markup is not recognized inside code spans, so only the markers of the outermost code span are written.
+	// The spans opened inside code spans are reopened with their markers when the code span is closed.
+	type openedSpan struct {
+		inlineSpan
+		closing string
+	}
+
+	var (
+		sb     strings.Builder
+		stack  []openedSpan
+		inCode int
+	)
+
+	closeSpan := func(s openedSpan) {
+		sb.WriteString(s.closing)
+		if s.Annotation.Type == "code" {
+			inCode--
+		}
+	}
+
+	openSpan := func(s inlineSpan) {
+		opening, closing, _ := mr.inlineMarkers(s.Annotation)
+		if s.Annotation.Type == "code" {
+			opening, closing = codeSpanMarkers(string(utf16.Decode(units[s.Start:s.End])))
+		}
+		if inCode > 0 {
+			opening, closing = "", ""
+		}
+		sb.WriteString(opening)
+		if s.Annotation.Type == "code" {
+			inCode++
+		}
+		stack = append(stack, openedSpan{inlineSpan: s, closing: closing})
+	}
+
+	for i := 0; i <= len(units); i++ {
+		// Closing the spans that end here. If some span to be closed
+		// is not on the top of the stack, we close all the spans above it,
+		// and reopen them afterwards.
+		// Spans starting inside inline embeds are never opened, so we skip them.
+		closing := slices.DeleteFunc(slices.Clone(ends[i]), func(s inlineSpan) bool {
+			return !slices.ContainsFunc(stack, func(o openedSpan) bool { return o.inlineSpan == s })
+		})
+		if len(closing) > 0 {
+			var reopen []inlineSpan
+			for len(closing) > 0 {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				closeSpan(top)
+
+				idx := slices.Index(closing, top.inlineSpan)
+				if idx >= 0 {
+					closing = slices.Delete(closing, idx, idx+1)
+				} else {
+					reopen = append(reopen, top.inlineSpan)
+				}
+			}
+
+			for _, s := range slices.Backward(reopen) {
+				openSpan(s)
+			}
+		}
+
+		if i == len(units) {
+			break
+		}
+
+		for _, s := range starts[i] {
+			openSpan(s)
+		}
+
+		if embed, ok := embeds[i]; ok {
+			sb.WriteString("<" + mr.rewriteURL(embed.Annotation.Ref) + ">")
+			i = embed.End - 1
+			continue
+		}
+
+		// Finding the end of the run of text without any span boundaries.
+		j := i + 1
+		for ; j < len(units); j++ {
+			if len(starts[j]) > 0 || len(ends[j]) > 0 {
+				break
+			}
+			if _, ok := embeds[j]; ok {
+				break
+			}
+		}
+
+		chunk := string(utf16.Decode(units[i:j]))
+		if inCode > 0 {
+			sb.WriteString(chunk)
+		} else {
+			sb.WriteString(escapeMarkdown(chunk))
+		}
+		i = j - 1
+	}
+
+	return sb.String()
+}
+
+// inlineMarkers returns opening and closing markup for the annotation.
+// It returns false if the annotation has no markup representation.
+func (mr markdownRenderer) inlineMarkers(a *documents.Annotation) (opening, closing string, ok bool) {
+	switch a.Type {
+	case "strong":
+		return "**", "**", true
+	case "emphasis":
+		return "_", "_", true
+	case "strike":
+		return "~~", "~~", true
+	case "code":
+		return "`", "`", true
+	case "underline":
+		return "<u>", "</u>", true
+	case "link":
+		return "[", "](" + escapeURL(mr.rewriteURL(a.Ref)) + ")", true
+	default:
+		return "", "", false
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	">", `\>`,
+	"#", `\#`,
+	"~", `\~`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+// lineStartMarker matches the text at the start of a line that would be parsed as a list item or a setext heading underline.
+var lineStartMarker = regexp.MustCompile(`(?m)^([ \t]*)([-+=]|[0-9]+[.)])`)
+
+// escapeLineStarts escapes the block markers at the start of the lines,
+// to avoid turning plain text into lists or headings.
+func escapeLineStarts(s string) string {
+	return lineStartMarker.ReplaceAllStringFunc(s, func(m string) string {
+		return m[:len(m)-1] + `\` + m[len(m)-1:]
+	})
+}
+
+var urlEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"(", `\(`,
+	")", `\)`,
+	"<", `\<`,
+	">", `\>`,
+)
+
+// escapeURL escapes the URL to be used as a link destination.
+// URLs with spaces must be enclosed in angle brackets.
+func escapeURL(u string) string {
+	u = urlEscaper.Replace(u)
+	if strings.ContainsAny(u, " \t") {
+		return "<" + u + ">"
+	}
+	return u
+}
+
+// codeSpanMarkers returns the markers for the code span with the given content.
+// The backtick fence must be longer than any run of backticks in the content,
+// and the content must be padded with spaces if it starts or ends with a backtick.
+func codeSpanMarkers(content string) (opening, closing string) {
+	fence := strings.Repeat("`", longestRun(content, '`')+1)
+	if strings.HasPrefix(content, "`") || strings.HasSuffix(content, "`") {
+		return fence + " ", " " + fence
+	}
+	return fence, fence
+}
+
+// indentLines prefixes the first line of s with first, and the rest of non-empty lines with rest.
+func indentLines(s, first, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		switch {
+		case i == 0:
+			lines[i] = first + l
+		case l == "":
+			lines[i] = strings.TrimRight(rest, " ")
+		default:
+			lines[i] = rest + l
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func longestRun(s string, c rune) int {
+	var longest, cur int
+	for _, r := range s {
+		if r == c {
+			cur++
+			longest = max(longest, cur)
+		} else {
+			cur = 0
+		}
+	}
+	return longest
+}
+
+// yamlQuote returns a double-quoted YAML scalar.
+// JSON strings are valid YAML double-quoted scalars.
+func yamlQuote(s string) string {
+	data, err := json.Marshal(s)
+	if err != nil {
+		panic(fmt.Errorf("BUG: failed to encode string: %w", err))
+	}
+	return string(data)
+}
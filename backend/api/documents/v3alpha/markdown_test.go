@@ -0,0 +1,194 @@
+package documents
+
+import (
+	"context"
+	pb "seed/backend/genproto/documents/v3alpha"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownRender(t *testing.T) {
+	t.Parallel()
+
+	doc := &pb.Document{
+		Metadata: map[string]string{
+			"title": "Hello: World",
+			"cover": "ipfs://bafy-cover",
+		},
+		Content: []*pb.BlockNode{
+			{
+				Block: &pb.Block{Id: "h1", Type: "heading", Text: "Intro", Attributes: map[string]string{"childrenType": "group"}},
+				Children: []*pb.BlockNode{
+					{Block: &pb.Block{
+						Id:   "p1",
+						Type: "paragraph",
+						Text: "Bold and italic with link and 1*2.",
+						Annotations: []*pb.Annotation{
+							{Type: "strong", Starts: []int32{0}, Ends: []int32{12}},
+							{Type: "emphasis", Starts: []int32{9}, Ends: []int32{15}},
+							{Type: "link", Ref: "https://example.com", Starts: []int32{21}, Ends: []int32{25}},
+						},
+					}},
+				},
+			},
+			{
+				Block: &pb.Block{Id: "l", Type: "paragraph", Text: "List:", Attributes: map[string]string{"childrenType": "ol"}},
+				Children: []*pb.BlockNode{
+					{Block: &pb.Block{Id: "l1", Type: "paragraph", Text: "😀 first", Annotations: []*pb.Annotation{
+						// Offsets are in UTF-16 code units, so emoji takes 2 units.
+						{Type: "code", Starts: []int32{3}, Ends: []int32{8}},
+					}}},
+					{
+						Block: &pb.Block{Id: "l2", Type: "paragraph", Text: "second", Attributes: map[string]string{"childrenType": "ul"}},
+						Children: []*pb.BlockNode{
+							{Block: &pb.Block{Id: "l21", Type: "paragraph", Text: "nested"}},
+						},
+					},
+				},
+			},
+			{Block: &pb.Block{Id: "c", Type: "codeBlock", Text: "fmt.Println(\"hi\")", Attributes: map[string]string{"language": "go"}}},
+			{Block: &pb.Block{Id: "i", Type: "image", Text: "A cat", Ref: "ipfs://bafy-cat"}},
+			{Block: &pb.Block{Id: "e", Type: "embed", Ref: "hm://alice/foo"}},
+			{Block: &pb.Block{Id: "m", Type: "paragraph", Text: "Mention   here", Annotations: []*pb.Annotation{
+				{Type: "inline-embed", Ref: "hm://bob", Starts: []int32{8}, Ends: []int32{9}},
+			}}},
+		},
+	}
+
+	want := `---
+cover: "ipfs://bafy-cover"
+title: "Hello: World"
+---
+
+# Intro
+
+**Bold and _ita_**_lic_ with [link](https://example.com) and 1\*2.
+
+List:
+
+1. 😀 ` + "`first`" + `
+2. second
+
+   - nested
+
+` + "```go\nfmt.Println(\"hi\")\n```" + `
+
+![A cat](https://gateway.example/ipfs/bafy-cat)
+
+[hm://alice/foo](hm://alice/foo)
+
+Mention <hm://bob> here
+`
+
+	got := markdownRenderer{ipfsGateway: "https://gateway.example/ipfs/"}.Render(doc)
+	require.Equal(t, want, got)
+}
+
+func TestExportDocument(t *testing.T) {
+	t.Parallel()
+
+	alice := newTestDocsAPI(t, "alice")
+	ctx := context.Background()
+
+	d1, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        alice.me.Account.Principal().String(),
+		Path:           "/export",
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_SetMetadata_{SetMetadata: &pb.DocumentChange_SetMetadata{Key: "title", Value: "Export"}}},
+			{Op: &pb.DocumentChange_MoveBlock_{MoveBlock: &pb.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	d2, err := alice.CreateDocumentChange(ctx, &pb.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        d1.Account,
+		Path:           d1.Path,
+		BaseVersion:    d1.Version,
+		Changes: []*pb.DocumentChange{
+			{Op: &pb.DocumentChange_ReplaceBlock{ReplaceBlock: &pb.Block{Id: "b1", Type: "paragraph", Text: "Hello World"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	latest, err := alice.ExportDocument(ctx, &pb.ExportDocumentRequest{Account: d1.Account, Path: d1.Path})
+	require.NoError(t, err)
+	require.Equal(t, d2.Version, latest.Version)
+	require.Equal(t, "---\ntitle: \"Export\"\n---\n\nHello World\n", latest.Markdown)
+
+	old, err := alice.ExportDocument(ctx, &pb.ExportDocumentRequest{Account: d1.Account, Path: d1.Path, Version: d1.Version})
+	require.NoError(t, err)
+	require.Equal(t, d1.Version, old.Version)
+	require.Equal(t, "---\ntitle: \"Export\"\n---\n\nHello\n", old.Markdown)
+}
+
+func TestMarkdownEscaping(t *testing.T) {
+	t.Parallel()
+
+	blocks := []*pb.Block{
+		{Type: "paragraph", Text: "- not a list"},
+		{Type: "paragraph", Text: "+ not a list either"},
+		{Type: "paragraph", Text: "1. not an ordered list"},
+		{Type: "paragraph", Text: "2) neither this"},
+		{Type: "paragraph", Text: "---"},
+		{Type: "paragraph", Text: "Code *with* `ticks` and [brackets]", Annotations: []*pb.Annotation{
+			{Type: "code", Starts: []int32{0}, Ends: []int32{34}},
+		}},
+		{Type: "paragraph", Text: "Bold code here", Annotations: []*pb.Annotation{
+			{Type: "strong", Starts: []int32{0}, Ends: []int32{9}},
+			{Type: "code", Starts: []int32{5}, Ends: []int32{14}},
+		}},
+		{Type: "paragraph", Text: "Wiki link", Annotations: []*pb.Annotation{
+			{Type: "link", Ref: "https://en.wikipedia.org/wiki/Foo_(bar)", Starts: []int32{0}, Ends: []int32{9}},
+		}},
+		{Type: "image", Text: "Pic", Ref: "https://example.com/a (1).png"},
+	}
+
+	doc := &pb.Document{}
+	for i, blk := range blocks {
+		blk.Id = "b" + strconv.Itoa(i)
+		doc.Content = append(doc.Content, &pb.BlockNode{Block: blk})
+	}
+
+	got := markdownRenderer{}.Render(doc)
+	require.Equal(t, `\- not a list
+
+\+ not a list either
+
+1\. not an ordered list
+
+2\) neither this
+
+\---
+
+`+"``Code *with* `ticks` and [brackets]``"+`
+
+**Bold `+"`code`**`"+` here`+"`"+`
+
+[Wiki link](https://en.wikipedia.org/wiki/Foo_\(bar\))
+
+![Pic](<https://example.com/a \(1\).png>)
+`, got)
+
+	var n int
+	_, content, err := parseMarkdown(got, func() string {
+		n++
+		return "n" + strconv.Itoa(n)
+	})
+	require.NoError(t, err)
+	require.Len(t, content, len(blocks), "escaped text must not produce new blocks")
+
+	for i, want := range blocks {
+		blk := content[i].Block
+		require.Equal(t, want.Type, blk.Type, "block %d", i)
+		require.Equal(t, want.Text, blk.Text, "block %d", i)
+		require.Equal(t, want.Ref, blk.Ref, "block %d", i)
+	}
+
+	require.Equal(t, blocks[5].Annotations, content[5].Block.Annotations)
+	require.Equal(t, blocks[7].Annotations, content[7].Block.Annotations)
+}
@@ -3,16 +3,27 @@ package entities
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
 	"seed/backend/core"
 	entities "seed/backend/genproto/entities/v1alpha"
+	"seed/backend/hlc"
 	"seed/backend/index"
+	"seed/backend/syncing"
+	"seed/backend/util/apiutil"
+	"seed/backend/util/colx"
 	"seed/backend/util/dqb"
 	"seed/backend/util/errutil"
-	"sort"
 	"strings"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/lithammer/fuzzysearch/fuzzy"
 	"google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 
@@ -25,7 +36,7 @@ import (
 
 // Discoverer is an interface for discovering objects.
 type Discoverer interface {
-	DiscoverObject(ctx context.Context, entityID, version string) error
+	DiscoverObject(ctx context.Context, entityID, version string) ([]peer.ID, error)
 }
 
 // Server implements Entities API.
@@ -49,219 +60,259 @@ func (srv *Server) RegisterServer(rpc grpc.ServiceRegistrar) {
 	entities.RegisterEntitiesServer(rpc, srv)
 }
 
-// // GetChange implements the Changes server.
-// func (api *Server) GetChange(ctx context.Context, in *entities.GetChangeRequest) (out *entities.Change, err error) {
-// 	if in.Id == "" {
-// 		return nil, errutil.MissingArgument("id")
-// 	}
-
-// 	c, err := cid.Decode(in.Id)
-// 	if err != nil {
-// 		return nil, errutil.ParseError("id", in.Id, c, err)
-// 	}
-
-// 	if err := api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 		size, err := hypersql.BlobsGetSize(conn, c.Hash())
-// 		if err != nil {
-// 			return err
-// 		}
-// 		if size.BlobsSize < 0 {
-// 			return errutil.NotFound("no such change %s", c)
-// 		}
-
-// 		out, err = getChange(conn, c, size.BlobsID)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		return nil
-// 	}); err != nil {
-// 		return nil, err
-// 	}
-
-// 	return out, nil
-// }
-
-// func getChange(conn *sqlite.Conn, c cid.Cid, id int64) (*entities.Change, error) {
-// 	var out *entities.Change
-// 	info, err := hypersql.ChangesGetInfo(conn, id)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	out = &entities.Change{
-// 		Id:         c.String(),
-// 		Author:     core.Principal(info.PublicKeysPrincipal).String(),
-// 		CreateTime: timestamppb.New(hlc.Timestamp(info.StructuralBlobsTs).Time()),
-// 		IsTrusted:  info.IsTrusted > 0,
-// 	}
-
-// 	deps, err := hypersql.ChangesGetDeps(conn, info.StructuralBlobsID)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	if len(deps) > 0 {
-// 		out.Deps = make([]string, len(deps))
-// 		for i, d := range deps {
-// 			out.Deps[i] = cid.NewCidV1(uint64(d.BlobsCodec), d.BlobsMultihash).String()
-// 		}
-// 	}
-
-// 	return out, nil
-// }
+// GetChange implements the Changes server.
+func (api *Server) GetChange(ctx context.Context, in *entities.GetChangeRequest) (out *entities.Change, err error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	c, err := cid.Decode(in.Id)
+	if err != nil {
+		return nil, errutil.ParseError("id", in.Id, c, err)
+	}
+
+	if err := api.idx.Query(ctx, func(conn *sqlite.Conn) error {
+		if err := sqlitex.Exec(conn, qGetChange(), func(stmt *sqlite.Stmt) error {
+			var (
+				author = core.Principal(stmt.ColumnBytesUnsafe(0)).String()
+				ts     = stmt.ColumnInt64(1)
+			)
+
+			out = &entities.Change{
+				Id:         c.String(),
+				Author:     author,
+				CreateTime: timestamppb.New(time.UnixMicro(ts)),
+			}
+			return nil
+		}, c.Hash()); err != nil {
+			return err
+		}
+
+		if out == nil {
+			return errutil.NotFound("no such change %s", c)
+		}
+
+		return sqlitex.Exec(conn, qGetChangeLinks(), func(stmt *sqlite.Stmt) error {
+			var (
+				isDep = stmt.ColumnInt(0) > 0
+				codec = stmt.ColumnInt64(1)
+				hash  = stmt.ColumnBytesUnsafe(2)
+				link  = cid.NewCidV1(uint64(codec), hash).String()
+			)
+
+			if isDep {
+				out.Deps = append(out.Deps, link)
+			} else {
+				out.Children = append(out.Children, link)
+			}
+			return nil
+		}, c.Hash())
+	}); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+var qGetChange = dqb.Str(`
+	SELECT
+		pk.principal,
+		sb.ts
+	FROM blobs b
+	JOIN structural_blobs sb ON sb.id = b.id
+	JOIN public_keys pk ON pk.id = sb.author
+	WHERE b.multihash = :hash
+	AND sb.type = 'Change'
+	LIMIT 1;
+`)
+
+// Deps are listed first, then the children, each group ordered by the timestamp of the linked change.
+var qGetChangeLinks = dqb.Str(`
+	WITH ch AS (
+		SELECT id FROM blobs WHERE multihash = :hash
+	)
+	SELECT
+		bl.source = ch.id AS is_dep,
+		b.codec,
+		b.multihash
+	FROM blob_links bl, ch
+	JOIN blobs b ON b.id = (CASE WHEN bl.source = ch.id THEN bl.target ELSE bl.source END)
+	LEFT JOIN structural_blobs sb ON sb.id = b.id
+	WHERE bl.type = 'change/dep'
+	AND (bl.source = ch.id OR bl.target = ch.id)
+	ORDER BY is_dep DESC, sb.ts, b.id;
+`)
 
 // GetEntityTimeline implements the Entities server.
-// func (api *Server) GetEntityTimeline(ctx context.Context, in *entities.GetEntityTimelineRequest) (*entities.EntityTimeline, error) {
-// 	if in.Id == "" {
-// 		return nil, errutil.MissingArgument("id")
-// 	}
-
-// 	// Prepare the response that will be filled in later.
-// 	out := &entities.EntityTimeline{
-// 		Id:      in.Id,
-// 		Changes: make(map[string]*entities.Change),
-// 	}
-
-// 	// Initialize some lookup tables and indexes.
-// 	var (
-// 		// Lookup for short database IDs to CID strings.
-// 		changeLookup = make(map[int64]string)
-
-// 		// Lookup for short database IDs to account IDs.
-// 		accountLookup = make(map[int64]string)
-
-// 		accounts colx.SmallSet[string]
-
-// 		// Set of leaf changes in the entire DAG.
-// 		heads colx.SmallSet[string]
-
-// 		headsByAuthor = make(map[string]*colx.SmallSet[string])
-
-// 		// Queue for doing tree traversal to find author heads.
-// 		queue [][]string
-// 	)
-
-// 	if err := api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 		edb, err := hypersql.EntitiesLookupID(conn, in.Id)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		if edb.ResourcesID == 0 {
-// 			return errutil.NotFound("no such entity %s", in.Id)
-// 		}
-
-// 		// Process all the changes and their deps.
-// 		if err := sqlitex.Exec(conn, qGetEntityTimeline(), func(stmt *sqlite.Stmt) error {
-// 			var (
-// 				idShort     = stmt.ColumnInt64(0)
-// 				codec       = stmt.ColumnInt64(1)
-// 				hash        = stmt.ColumnBytesUnsafe(2)
-// 				ts          = stmt.ColumnInt64(3)
-// 				isTrusted   = stmt.ColumnInt(4)
-// 				authorID    = stmt.ColumnInt64(5)
-// 				authorBytes = stmt.ColumnBytesUnsafe(6)
-// 				deps        = stmt.ColumnText(7)
-// 				isDraft     = stmt.ColumnInt(8)
-// 			)
-
-// 			idLong := cid.NewCidV1(uint64(codec), hash).String()
-// 			changeLookup[idShort] = idLong
-
-// 			// The database query already sorts by timestamp,
-// 			// so we can just append.
-// 			out.ChangesByTime = append(out.ChangesByTime, idLong)
-
-// 			author, ok := accountLookup[authorID]
-// 			if !ok {
-// 				author = core.Principal(authorBytes).String()
-// 				accountLookup[authorID] = author
-// 			}
-
-// 			accounts.Put(author)
-
-// 			if _, ok := headsByAuthor[author]; !ok {
-// 				headsByAuthor[author] = &colx.SmallSet[string]{}
-// 			}
-
-// 			change := &entities.Change{
-// 				Id:         idLong,
-// 				Author:     author,
-// 				CreateTime: timestamppb.New(hlc.Timestamp(ts).Time()),
-// 				IsTrusted:  isTrusted > 0,
-// 				IsDraft:    isDraft > 0,
-// 			}
-
-// 			if deps == "" {
-// 				out.Roots = append(out.Roots, idLong)
-// 			} else {
-// 				depsShort := strings.Fields(deps)
-// 				change.Deps = make([]string, len(depsShort))
-// 				for i, depShort := range depsShort {
-// 					depInt, err := strconv.Atoi(depShort)
-// 					if err != nil {
-// 						return fmt.Errorf("failed to parse dep %q as int: %w", depShort, err)
-// 					}
-// 					depLong, ok := changeLookup[int64(depInt)]
-// 					if !ok {
-// 						return fmt.Errorf("missing causal dependency lookup %q for change %q", depShort, idLong)
-// 					}
-
-// 					change.Deps[i] = depLong
-// 					out.Changes[depLong].Children = append(out.Changes[depLong].Children, idLong)
-// 					heads.Delete(depLong)
-// 				}
-// 			}
-
-// 			heads.Put(idLong)
-// 			out.Changes[idLong] = change
-
-// 			// Iterate over author heads, and find path to the current change
-// 			// if found remove the head
-// 			// in the end add this change to the authors head
-// 			authorHeads := headsByAuthor[author]
-// 			for _, head := range authorHeads.Slice() {
-// 				if isDescendant(out, queue, head, idLong) {
-// 					authorHeads.Delete(head)
-// 				}
-// 			}
-
-// 			authorHeads.Put(idLong)
-// 			return nil
-// 		}, edb.ResourcesID, in.IncludeDrafts); err != nil {
-// 			return err
-// 		}
-
-// 		// Sometimes we know about a document from a link,
-// 		// but don't have any changes for it. We don't want this to be an error,
-// 		// so we just stop further processing and return an empty timeline.
-// 		if len(changeLookup) == 0 {
-// 			return nil
-// 		}
-
-// 		owner, ok := accountLookup[edb.ResourcesOwner]
-// 		if !ok {
-// 			return fmt.Errorf("BUG: missing owner for entity %q after processing the timeline", in.Id)
-// 		}
-
-// 		out.Owner = owner
-// 		out.Heads = sortChanges(out, heads.Slice())
-
-// 		for _, author := range accounts.Slice() {
-// 			av := &entities.AuthorVersion{
-// 				Author: author,
-// 				Heads:  sortChanges(out, headsByAuthor[author].Slice()),
-// 			}
-// 			av.Version = strings.Join(av.Heads, ".")
-// 			av.VersionTime = out.Changes[av.Heads[len(av.Heads)-1]].CreateTime
-// 			out.AuthorVersions = append(out.AuthorVersions, av)
-// 		}
-
-// 		return nil
-// 	}); err != nil {
-// 		return nil, err
-// 	}
-
-// 	return out, nil
-// }
+// The timeline includes the changes from the owner of the entity and from the holders of valid capabilities.
+// There're no drafts in the current data model, so include_drafts has no effect.
+func (api *Server) GetEntityTimeline(ctx context.Context, in *entities.GetEntityTimelineRequest) (*entities.EntityTimeline, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	iri, owner, err := parseEntityID(in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare the response that will be filled in later.
+	out := &entities.EntityTimeline{
+		Id:      in.Id,
+		Owner:   owner.String(),
+		Changes: make(map[string]*entities.Change),
+	}
+
+	// Lookup for CIDs of the changes by their string IDs.
+	cids := make(map[string]cid.Cid)
+
+	changes, check := api.idx.IterChanges(ctx, iri, owner)
+	for _, ch := range changes {
+		id := ch.CID.String()
+		change := &entities.Change{
+			Id:         id,
+			Author:     ch.Data.Author.String(),
+			CreateTime: timestamppb.New(hlc.Timestamp(ch.Data.Ts).Time()),
+		}
+
+		if len(ch.Data.Deps) > 0 {
+			change.Deps = make([]string, len(ch.Data.Deps))
+			for i, dep := range ch.Data.Deps {
+				change.Deps[i] = dep.String()
+			}
+		}
+
+		// Changes are returned sorted by timestamp.
+		out.ChangesByTime = append(out.ChangesByTime, id)
+		out.Changes[id] = change
+		cids[id] = ch.CID
+	}
+	if err := check(); err != nil {
+		return nil, err
+	}
+
+	// Sometimes we know about a document from a link,
+	// but don't have any changes for it. We don't want this to be an error,
+	// so we just return an empty timeline.
+	if len(out.Changes) == 0 {
+		var found bool
+		if err := api.idx.Query(ctx, func(conn *sqlite.Conn) error {
+			return sqlitex.Exec(conn, qResourceExists(), func(*sqlite.Stmt) error {
+				found = true
+				return nil
+			}, string(iri))
+		}); err != nil {
+			return nil, err
+		}
+
+		if !found {
+			return nil, errutil.NotFound("no such entity %s", in.Id)
+		}
+
+		return out, nil
+	}
+
+	for _, id := range out.ChangesByTime {
+		change := out.Changes[id]
+		if len(change.Deps) == 0 {
+			out.Roots = append(out.Roots, id)
+		}
+
+		for _, dep := range change.Deps {
+			// Deps we don't have yet are kept in the change, but they can't be part of the DAG.
+			if parent, ok := out.Changes[dep]; ok {
+				parent.Children = append(parent.Children, id)
+			}
+		}
+	}
+
+	var (
+		// Set of leaf changes in the entire DAG.
+		heads []string
+
+		authors       []string
+		headsByAuthor = make(map[string]*colx.SmallSet[string])
+
+		// Queue for doing tree traversal to find author heads.
+		queue [][]string
+	)
+
+	for _, id := range out.ChangesByTime {
+		change := out.Changes[id]
+		if len(change.Children) == 0 {
+			heads = append(heads, id)
+		}
+
+		authorHeads, ok := headsByAuthor[change.Author]
+		if !ok {
+			authorHeads = &colx.SmallSet[string]{}
+			headsByAuthor[change.Author] = authorHeads
+			authors = append(authors, change.Author)
+		}
+
+		// Iterate over author heads, and find path to the current change
+		// if found remove the head
+		// in the end add this change to the authors head
+		for _, head := range authorHeads.Slice() {
+			if isDescendant(out, queue, head, id) {
+				authorHeads.Delete(head)
+			}
+		}
+
+		authorHeads.Put(id)
+	}
+
+	out.Heads = sortChanges(out, heads)
+
+	for _, author := range authors {
+		av := &entities.AuthorVersion{
+			Author: author,
+			Heads:  sortChanges(out, headsByAuthor[author].Slice()),
+		}
+
+		heads := make([]cid.Cid, len(av.Heads))
+		for i, h := range av.Heads {
+			heads[i] = cids[h]
+		}
+		av.Version = index.NewVersion(heads...).String()
+		av.VersionTime = out.Changes[av.Heads[len(av.Heads)-1]].CreateTime
+		out.AuthorVersions = append(out.AuthorVersions, av)
+	}
+
+	slices.SortStableFunc(out.AuthorVersions, func(a, b *entities.AuthorVersion) int {
+		return a.VersionTime.AsTime().Compare(b.VersionTime.AsTime())
+	})
+
+	return out, nil
+}
+
+var qResourceExists = dqb.Str(`
+	SELECT 1 FROM resources WHERE iri = :iri LIMIT 1;
+`)
+
+// parseEntityID parses the hm:// entity ID into the resource IRI and its owner.
+func parseEntityID(id string) (iri index.IRI, owner core.Principal, err error) {
+	if !strings.HasPrefix(id, "hm://") {
+		return "", nil, status.Errorf(codes.InvalidArgument, "invalid id %q: must start with hm://", id)
+	}
+
+	u, err := url.Parse(id)
+	if err != nil {
+		return "", nil, status.Errorf(codes.InvalidArgument, "invalid id %q: %v", id, err)
+	}
+
+	owner, err = core.DecodePrincipal(u.Host)
+	if err != nil {
+		return "", nil, status.Errorf(codes.InvalidArgument, "failed to decode account '%s' of entity '%s': %v", u.Host, id, err)
+	}
+
+	iri, err = index.NewIRI(owner, u.Path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return iri, owner, nil
+}
 
 func sortChanges(timeline *entities.EntityTimeline, heads []string) []string {
 	slices.SortFunc(heads, func(a, b string) int {
@@ -320,361 +371,477 @@ func (api *Server) DiscoverEntity(ctx context.Context, in *entities.DiscoverEnti
 		return nil, status.Errorf(codes.InvalidArgument, "invalid id %q: must start with hm://", in.Id)
 	}
 
-	// 	ver := hyper.Version(in.Version)
-
-	// 	heads, err := ver.Parse()
-	// 	if err != nil {
-	// 		return nil, status.Errorf(codes.InvalidArgument, "invalid version %q: %v", in.Version, err)
-	// 	}
+	if _, err := index.Version(in.Version).Parse(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid version %q: %v", in.Version, err)
+	}
 
-	if err := api.disc.DiscoverObject(ctx, in.Id, in.Version); err != nil {
-		return nil, err
+	peers, err := api.disc.DiscoverObject(ctx, in.Id, in.Version)
+	if err != nil {
+		switch {
+		case errors.Is(err, syncing.ErrDiscoveryDisabled):
+			return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+		case errors.Is(err, syncing.ErrObjectNotFound):
+			return nil, status.Errorf(codes.NotFound, "discovery attempt failed: %v", err)
+		default:
+			return nil, fmt.Errorf("discovery attempt failed: %w", err)
+		}
 	}
 
-	// 	for _, h := range heads {
-	// 		ok, err := api.blobs.IPFSBlockstore().Has(ctx, h)
-	// 		if err != nil {
-	// 			return nil, fmt.Errorf("failed to check if block %s exists: %w", h, err)
-	// 		}
-	// 		if !ok {
-	// 			return nil, status.Errorf(codes.Unavailable, "discovery attempt failed: couldn't find the desired version %q", in.Version)
-	// 		}
-	// 	}
+	out := &entities.DiscoverEntityResponse{
+		Peers: make([]string, len(peers)),
+	}
+	for i, pid := range peers {
+		out.Peers[i] = pid.String()
+	}
 
-	return &entities.DiscoverEntityResponse{}, nil
+	return out, nil
 }
 
-// SearchEntities implements the Fuzzy search of entities.
+// SearchEntities implements the full-text search of entities.
 func (api *Server) SearchEntities(ctx context.Context, in *entities.SearchEntitiesRequest) (*entities.SearchEntitiesResponse, error) {
-	var titles []string
-	var iris []string
-	var owners []string
-	const limit = 30
-	type meta struct {
-		Title string `json:"title"`
+	{
+		if in.Query == "" {
+			return nil, errutil.MissingArgument("query")
+		}
+
+		if in.PathPrefix != "" && in.Account == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "path_prefix requires account to be set")
+		}
+	}
+
+	iriGlob := "hm://*"
+	if in.Account != "" {
+		acc, err := core.DecodePrincipal(in.Account)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode account '%s': %v", in.Account, err)
+		}
+
+		iriGlob = "hm://" + acc.String() + in.PathPrefix + "*"
+	}
+
+	query := ftsQuery(in.Query)
+	if query == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "query '%s' doesn't have any words to search for", in.Query)
+	}
+
+	type Cursor struct {
+		Rank float64 `json:"r"`
+		ID   int64   `json:"i"`
+	}
+
+	var (
+		count      int32
+		lastCursor = Cursor{
+			Rank: -math.MaxFloat64,
+		}
+	)
+
+	if in.PageSize <= 0 {
+		in.PageSize = 30
 	}
+
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &lastCursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+
+	out := &entities.SearchEntitiesResponse{
+		Entities: make([]*entities.Entity, 0, in.PageSize),
+	}
+
 	if err := api.idx.Query(ctx, func(conn *sqlite.Conn) error {
-		return sqlitex.Exec(conn, qGetEntityTitles(), func(stmt *sqlite.Stmt) error {
-			var attr meta
-			if err := json.Unmarshal(stmt.ColumnBytes(0), &attr); err != nil {
+		return sqlitex.Exec(conn, qSearchEntities(), func(stmt *sqlite.Stmt) error {
+			if count == in.PageSize {
+				var err error
+				out.NextPageToken, err = apiutil.EncodePageToken(lastCursor, nil)
+				return err
+			}
+			count++
+
+			var (
+				id       = stmt.ColumnInt64(0)
+				rank     = stmt.ColumnFloat(1)
+				iri      = stmt.ColumnText(2)
+				title    = stmt.ColumnText(3)
+				textType = stmt.ColumnText(4)
+				key      = stmt.ColumnText(5)
+				snippet  = stmt.ColumnText(6)
+			)
+
+			lastCursor.Rank = rank
+			lastCursor.ID = id
+
+			// Documents are owned by the account in their IRI.
+			u, err := url.Parse(iri)
+			if err != nil {
 				return err
 			}
-			titles = append(titles, attr.Title)
-			iris = append(iris, stmt.ColumnText(1))
-			ownerID := core.Principal(stmt.ColumnBytes(2)).String()
-			owners = append(owners, ownerID)
+
+			ent := &entities.Entity{
+				Id:      iri,
+				Title:   title,
+				Owner:   u.Host,
+				Content: snippet,
+			}
+
+			if textType == index.TextContentBlock {
+				ent.BlockId = key
+			}
+
+			out.Entities = append(out.Entities, ent)
 			return nil
-		})
+		}, query, iriGlob, lastCursor.Rank, lastCursor.Rank, lastCursor.ID, in.PageSize)
 	}); err != nil {
 		return nil, err
 	}
-	ranks := fuzzy.RankFindNormalizedFold(in.Query, titles)
-	sort.Slice(ranks, func(i, j int) bool {
-		return ranks[i].Distance < ranks[j].Distance
-	})
-	matchingEntities := []*entities.Entity{}
-	for i, rank := range ranks {
-		if i >= limit {
-			break
-		}
-		matchingEntities = append(matchingEntities, &entities.Entity{
-			Id:    iris[rank.OriginalIndex],
-			Title: rank.Target,
-			Owner: owners[rank.OriginalIndex]})
+
+	return out, nil
+}
+
+// ftsQuery converts user input into an FTS5 query.
+// Each word is quoted to avoid interpreting the FTS5 query syntax,
+// and the last word is matched as a prefix.
+func ftsQuery(in string) string {
+	words := strings.Fields(in)
+	if len(words) == 0 {
+		return ""
+	}
+
+	for i, w := range words {
+		words[i] = `"` + strings.ReplaceAll(w, `"`, `""`) + `"`
 	}
-	return &entities.SearchEntitiesResponse{Entities: matchingEntities}, nil
+	words[len(words)-1] += "*"
+
+	return strings.Join(words, " ")
 }
 
+// Content is attributed to documents when the changes are applied to the document state,
+// which only happens for the changes from the authorized writers.
+// Only the latest content for each block or metadata key is considered.
+// Matches in the title are ranked higher than the rest.
+var qSearchEntities = dqb.Str(`
+	WITH matches AS (
+		SELECT
+			fi.id,
+			fi.resource,
+			fi.type,
+			fi.key,
+			fi.ts,
+			snippet(fts, 0, '', '', '…', 16) AS snippet,
+			fts.rank * (CASE WHEN fi.type = 'metadata' AND fi.key = 'title' THEN 2.0 ELSE 1.0 END) AS rank
+		FROM fts
+		JOIN fts_index fi ON fi.id = fts.rowid
+		WHERE fts MATCH ?
+		AND fi.resource IS NOT NULL
+	)
+	SELECT
+		m.id,
+		m.rank,
+		r.iri,
+		ds.metadata->>'title' AS title,
+		m.type,
+		m.key,
+		m.snippet
+	FROM matches m
+	JOIN resources r ON r.id = m.resource
+	LEFT JOIN document_states ds ON ds.resource = m.resource
+	WHERE r.iri GLOB ?
+	AND r.iri NOT IN (SELECT iri FROM deleted_resources)
+	AND (m.rank > ? OR (m.rank = ? AND m.id > ?))
+	AND NOT EXISTS (
+		SELECT 1
+		FROM fts_index newer
+		WHERE newer.resource = m.resource
+		AND newer.type = m.type
+		AND newer.key = m.key
+		AND newer.ts > m.ts
+	)
+	ORDER BY m.rank, m.id
+	LIMIT ? + 1;
+`)
+
 // DeleteEntity implements the corresponding gRPC method.
-// func (api *Server) DeleteEntity(ctx context.Context, in *entities.DeleteEntityRequest) (*emptypb.Empty, error) {
-// 	var meta string
-// 	var qGetResourceMetadata = dqb.Str(`
-//   	SELECT meta from meta_view
-// 	WHERE iri = :eid
-// 	`)
-
-// 	if in.Id == "" {
-// 		return nil, status.Errorf(codes.InvalidArgument, "must specify entity ID to delete")
-// 	}
-
-// 	eid := hyper.EntityID(in.Id)
-
-// 	err := api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 		return sqlitex.Exec(conn, qGetResourceMetadata(), func(stmt *sqlite.Stmt) error {
-// 			meta = stmt.ColumnText(0)
-// 			return nil
-// 		}, in.Id)
-// 	})
-// 	if err != nil {
-// 		return nil, err
-// 	}
-// 	err = api.blobs.ForEachComment(ctx, eid.String(), func(c cid.Cid, cmt hyper.Comment, conn *sqlite.Conn) error {
-// 		referencedDocument := strings.Split(cmt.Target, "?v=")[0]
-// 		if referencedDocument == eid.String() {
-// 			_, err = hypersql.BlobsDelete(conn, c.Hash())
-// 			if err != nil {
-// 				if err = hypersql.BlobsEmptyByHash(conn, c.Hash()); err != nil {
-// 					return err
-// 				}
-// 			}
-// 			if cmt.RepliedComment.String() != "" {
-// 				_, err = hypersql.BlobsDelete(conn, cmt.RepliedComment.Hash())
-// 				if err != nil {
-// 					if err = hypersql.BlobsEmptyByHash(conn, cmt.RepliedComment.Hash()); err != nil {
-// 						return err
-// 					}
-// 				}
-// 			}
-
-// 			return nil
-// 		}
-// 		return nil
-// 	})
-
-// 	err = api.blobs.DeleteEntity(ctx, eid)
-// 	if err != nil {
-// 		if errors.Is(err, hyper.ErrEntityNotFound) {
-// 			return nil, err
-// 		}
-
-// 		_, err = &emptypb.Empty{}, api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 			return hypersql.BlobsEmptyByEID(conn, in.Id)
-// 		})
-// 		if err != nil {
-// 			return &emptypb.Empty{}, err
-// 		}
-
-// 		_, err = &emptypb.Empty{}, api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 			return hypersql.BlobsStructuralDelete(conn, in.Id)
-// 		})
-// 		if err != nil {
-// 			return &emptypb.Empty{}, err
-// 		}
-// 	}
-// 	_, err = &emptypb.Empty{}, api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 		return sqlitex.WithTx(conn, func() error {
-// 			res, err := hypersql.EntitiesInsertRemovedRecord(conn, eid.String(), in.Reason, meta)
-// 			if err != nil {
-// 				return err
-// 			}
-// 			if res.ResourceEID != eid.String() {
-// 				return fmt.Errorf("%w: %s", hyper.ErrEntityNotFound, eid)
-// 			}
-
-// 			return nil
-// 		})
-// 	})
-// 	return &emptypb.Empty{}, err
-// }
+func (api *Server) DeleteEntity(ctx context.Context, in *entities.DeleteEntityRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	iri, _, err := parseEntityID(in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := api.idx.DeleteResource(ctx, iri, in.Reason); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
 
 // UndeleteEntity implements the corresponding gRPC method.
-// func (api *Server) UndeleteEntity(ctx context.Context, in *entities.UndeleteEntityRequest) (*emptypb.Empty, error) {
-// 	if in.Id == "" {
-// 		return nil, status.Errorf(codes.InvalidArgument, "must specify entity ID to restore")
-// 	}
+func (api *Server) UndeleteEntity(ctx context.Context, in *entities.UndeleteEntityRequest) (*emptypb.Empty, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
 
-// 	eid := hyper.EntityID(in.Id)
+	iri, _, err := parseEntityID(in.Id)
+	if err != nil {
+		return nil, err
+	}
 
-// 	return &emptypb.Empty{}, api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 		return hypersql.EntitiesDeleteRemovedRecord(conn, eid.String())
-// 	})
-// }
+	// The content is fetched back by the syncing loop,
+	// because we no longer pretend to have the deleted blobs.
+	if err := api.idx.UndeleteResource(ctx, iri); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
 
 // ListDeletedEntities implements the corresponding gRPC method.
-// func (api *Server) ListDeletedEntities(ctx context.Context, _ *entities.ListDeletedEntitiesRequest) (*entities.ListDeletedEntitiesResponse, error) {
-// 	resp := &entities.ListDeletedEntitiesResponse{
-// 		DeletedEntities: make([]*entities.DeletedEntity, 0),
-// 	}
-
-// 	err := api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 		list, err := hypersql.EntitiesListRemovedRecords(conn)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		for _, entity := range list {
-// 			resp.DeletedEntities = append(resp.DeletedEntities, &entities.DeletedEntity{
-// 				Id:            entity.DeletedResourcesIRI,
-// 				DeleteTime:    &timestamppb.Timestamp{Seconds: entity.DeletedResourcesDeleteTime},
-// 				DeletedReason: entity.DeletedResourcesReason,
-// 				Metadata:      entity.DeletedResourcesMeta,
-// 			})
-// 		}
-// 		return nil
-// 	})
-
-// 	return resp, err
-// }
-
-var qGetEntityTitles = dqb.Str(`
-	SELECT extra_attrs, iri, principal
-	FROM meta_view;`)
+func (api *Server) ListDeletedEntities(ctx context.Context, in *entities.ListDeletedEntitiesRequest) (*entities.ListDeletedEntitiesResponse, error) {
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	type Cursor struct {
+		ID int64 `json:"i"`
+	}
+
+	var cursor Cursor
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode page token: %v", err)
+		}
+	}
+
+	resp := &entities.ListDeletedEntitiesResponse{
+		DeletedEntities: make([]*entities.DeletedEntity, 0, in.PageSize),
+	}
+
+	if err := api.idx.Query(ctx, func(conn *sqlite.Conn) error {
+		var (
+			count      int32
+			lastCursor Cursor
+		)
+		return sqlitex.Exec(conn, qListDeletedEntities(), func(stmt *sqlite.Stmt) error {
+			if count == in.PageSize {
+				var err error
+				resp.NextPageToken, err = apiutil.EncodePageToken(lastCursor, nil)
+				return err
+			}
+			count++
+
+			lastCursor.ID = stmt.ColumnInt64(0)
+			resp.DeletedEntities = append(resp.DeletedEntities, &entities.DeletedEntity{
+				Id:            stmt.ColumnText(1),
+				DeleteTime:    timestamppb.New(time.Unix(stmt.ColumnInt64(2), 0)),
+				DeletedReason: stmt.ColumnText(3),
+				Metadata:      stmt.ColumnText(4),
+			})
+			return nil
+		}, cursor.ID, in.PageSize)
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+var qListDeletedEntities = dqb.Str(`
+	SELECT
+		rowid,
+		iri,
+		delete_time,
+		reason,
+		extra_attrs->>'metadata'
+	FROM deleted_resources
+	WHERE rowid > :cursor
+	ORDER BY rowid
+	LIMIT :page_size + 1;
+`)
 
 // ListEntityMentions implements listing mentions of an entity in other resources.
-// func (api *Server) ListEntityMentions(ctx context.Context, in *entities.ListEntityMentionsRequest) (*entities.ListEntityMentionsResponse, error) {
-// 	if in.Id == "" {
-// 		return nil, errutil.MissingArgument("id")
-// 	}
-
-// 	var cursor mentionsCursor
-// 	if in.PageToken != "" {
-// 		if err := cursor.FromString(in.PageToken); err != nil {
-// 			return nil, status.Errorf(codes.InvalidArgument, "failed to decode page token: %v", err)
-// 		}
-// 	}
-
-// 	// Without this querying in reverse order wouldn't ever return any results.
-// 	if in.ReverseOrder && in.PageToken == "" {
-// 		cursor.BlobID = math.MaxInt64
-// 		cursor.LinkID = math.MaxInt64
-// 	}
-
-// 	// Arbitrary default page size.
-// 	if in.PageSize == 0 {
-// 		in.PageSize = 10
-// 	}
-
-// 	resp := &entities.ListEntityMentionsResponse{}
-
-// 	if err := api.blobs.Query(ctx, func(conn *sqlite.Conn) error {
-// 		edb, err := hypersql.EntitiesLookupID(conn, in.Id)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		if edb.ResourcesID == 0 {
-// 			return status.Errorf(codes.NotFound, "entity '%s' is not found", in.Id)
-// 		}
-
-// 		var lastCursor mentionsCursor
-
-// 		var count int32
-// 		if err := sqlitex.Exec(conn, qListMentions(in.ReverseOrder), func(stmt *sqlite.Stmt) error {
-// 			// We query for pageSize + 1 items to know if there's more items on the next page,
-// 			// because if not we don't need to return the page token in the response.
-// 			if count == in.PageSize {
-// 				resp.NextPageToken = lastCursor.String()
-// 				return nil
-// 			}
-
-// 			count++
-
-// 			var (
-// 				source        = stmt.ColumnText(0)
-// 				sourceBlob    = cid.NewCidV1(uint64(stmt.ColumnInt64(1)), stmt.ColumnBytesUnsafe(2)).String()
-// 				author        = core.Principal(stmt.ColumnBytesUnsafe(3)).String()
-// 				ts            = hlc.Timestamp(stmt.ColumnInt64(4)).Time()
-// 				blobType      = hyper.BlobType(stmt.ColumnText(5))
-// 				isDraft       = stmt.ColumnInt(6) > 0
-// 				isPinned      = stmt.ColumnInt(7) > 0
-// 				anchor        = stmt.ColumnText(8)
-// 				targetVersion = stmt.ColumnText(9)
-// 				fragment      = stmt.ColumnText(10)
-// 			)
-
-// 			lastCursor.BlobID = stmt.ColumnInt64(11)
-// 			lastCursor.LinkID = stmt.ColumnInt64(12)
-
-// 			if source == "" && blobType != hyper.TypeComment {
-// 				return fmt.Errorf("BUG: missing source for mention of type '%s'", blobType)
-// 			}
-
-// 			if blobType == hyper.TypeComment {
-// 				source = "hm://c/" + sourceBlob
-// 			}
-
-// 			resp.Mentions = append(resp.Mentions, &entities.Mention{
-// 				Source:        source,
-// 				SourceContext: anchor,
-// 				SourceBlob: &entities.Mention_BlobInfo{
-// 					Cid:        sourceBlob,
-// 					Author:     author,
-// 					CreateTime: timestamppb.New(ts),
-// 					IsDraft:    isDraft,
-// 				},
-// 				TargetVersion:  targetVersion,
-// 				IsExactVersion: isPinned,
-// 				TargetFragment: fragment,
-// 			})
-
-// 			return nil
-// 		}, edb.ResourcesID, cursor.BlobID, cursor.BlobID, in.PageSize); err != nil {
-// 			return err
-// 		}
-
-// 		return nil
-// 	}); err != nil {
-// 		return nil, err
-// 	}
-
-// 	return resp, nil
-// }
-
-// const qListMentionsTpl = `
-// 	SELECT
-// 		resources.iri,
-// 		blobs.codec,
-// 		blobs.multihash,
-// 		public_keys.principal AS author,
-// 		structural_blobs.ts,
-// 		structural_blobs.type AS blob_type,
-// 		drafts.blob IS NOT NULL AS is_draft,
-// 		resource_links.is_pinned,
-// 		resource_links.meta->>'a' AS anchor,
-// 		resource_links.meta->>'v' AS target_version,
-// 		resource_links.meta->>'f' AS target_fragment,
-// 		blobs.id AS blob_id,
-// 		resource_links.id AS link_id
-// 	FROM resource_links
-// 	JOIN structural_blobs ON structural_blobs.id = resource_links.source
-// 	JOIN blobs INDEXED BY blobs_metadata ON blobs.id = structural_blobs.id
-// 	LEFT JOIN drafts ON drafts.blob = structural_blobs.id
-// 	JOIN public_keys ON public_keys.id = structural_blobs.author
-// 	LEFT JOIN resources ON resources.id = structural_blobs.resource
-// 	WHERE resource_links.target = :target
-// 	AND (resource_links.source, resource_links.id) %s (:blob_id, :link_id)
-// 	AND structural_blobs.type IN ('Change', 'Comment')
-// 	ORDER BY resource_links.source %s, resource_links.id %s
-// 	LIMIT :page_size + 1;
-// `
-
-// func qListMentions(desc bool) string {
-// 	if desc {
-// 		return qListMentionsDesc()
-// 	}
-
-// 	return qListMentionsAsc()
-// }
-
-// var qListMentionsAsc = dqb.Q(func() string {
-// 	return fmt.Sprintf(qListMentionsTpl, ">", "ASC", "ASC")
-// })
-
-// var qListMentionsDesc = dqb.Q(func() string {
-// 	return fmt.Sprintf(qListMentionsTpl, "<", "DESC", "DESC")
-// })
-
-// type mentionsCursor struct {
-// 	BlobID int64 `json:"b"`
-// 	LinkID int64 `json:"l"`
-// }
-
-// func (mc *mentionsCursor) FromString(s string) error {
-// 	data, err := base64.RawURLEncoding.DecodeString(s)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	return json.Unmarshal(data, mc)
-// }
-
-// func (mc mentionsCursor) String() string {
-// 	if mc.BlobID == 0 && mc.LinkID == 0 {
-// 		return ""
-// 	}
-
-// 	data, err := json.Marshal(mc)
-// 	if err != nil {
-// 		panic(err)
-// 	}
-
-// 	return base64.RawURLEncoding.EncodeToString(data)
-// }
+func (api *Server) ListEntityMentions(ctx context.Context, in *entities.ListEntityMentionsRequest) (*entities.ListEntityMentionsResponse, error) {
+	if in.Id == "" {
+		return nil, errutil.MissingArgument("id")
+	}
+
+	if err := apiutil.ValidatePageSize(&in.PageSize); err != nil {
+		return nil, err
+	}
+
+	type Cursor struct {
+		BlobID int64 `json:"b"`
+		LinkID int64 `json:"l"`
+	}
+
+	var cursor Cursor
+	if in.PageToken != "" {
+		if err := apiutil.DecodePageToken(in.PageToken, &cursor, nil); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode page token: %v", err)
+		}
+	} else if in.ReverseOrder {
+		// Without this querying in reverse order wouldn't ever return any results.
+		cursor.BlobID = math.MaxInt64
+		cursor.LinkID = math.MaxInt64
+	}
+
+	resp := &entities.ListEntityMentionsResponse{}
+
+	if err := api.idx.Query(ctx, func(conn *sqlite.Conn) error {
+		var target int64
+		if err := sqlitex.Exec(conn, qLookupResource(), func(stmt *sqlite.Stmt) error {
+			target = stmt.ColumnInt64(0)
+			return nil
+		}, in.Id); err != nil {
+			return err
+		}
+		if target == 0 {
+			return status.Errorf(codes.NotFound, "entity '%s' is not found", in.Id)
+		}
+
+		var (
+			lastCursor Cursor
+			count      int32
+		)
+		if err := sqlitex.Exec(conn, qListMentions(in.ReverseOrder), func(stmt *sqlite.Stmt) error {
+			// We query for pageSize + 1 items to know if there's more items on the next page,
+			// because if not we don't need to return the page token in the response.
+			if count == in.PageSize {
+				var err error
+				resp.NextPageToken, err = apiutil.EncodePageToken(lastCursor, nil)
+				return err
+			}
+			count++
+
+			var (
+				source        = stmt.ColumnText(0)
+				sourceBlob    = cid.NewCidV1(uint64(stmt.ColumnInt64(1)), stmt.ColumnBytesUnsafe(2)).String()
+				author        = core.Principal(stmt.ColumnBytesUnsafe(3)).String()
+				ts            = time.UnixMicro(stmt.ColumnInt64(4))
+				blobType      = stmt.ColumnText(5)
+				isPinned      = stmt.ColumnInt(6) > 0
+				anchor        = stmt.ColumnText(7)
+				targetVersion = stmt.ColumnText(8)
+				fragment      = stmt.ColumnText(9)
+				origCodec     = stmt.ColumnInt64(10)
+				origHash      = stmt.ColumnBytesUnsafe(11)
+			)
+
+			lastCursor.BlobID = stmt.ColumnInt64(12)
+			lastCursor.LinkID = stmt.ColumnInt64(13)
+
+			mention := &entities.Mention{
+				Source:        source,
+				SourceContext: anchor,
+				SourceBlob: &entities.Mention_BlobInfo{
+					Cid:        sourceBlob,
+					Author:     author,
+					CreateTime: timestamppb.New(ts),
+				},
+				TargetVersion:  targetVersion,
+				IsExactVersion: isPinned,
+				TargetFragment: fragment,
+			}
+
+			switch blobType {
+			case "Comment":
+				// Edited comments are identified by their original version.
+				if origHash != nil {
+					mention.Source = "hm://c/" + cid.NewCidV1(uint64(origCodec), origHash).String()
+				} else {
+					mention.Source = "hm://c/" + sourceBlob
+				}
+			}
+
+			resp.Mentions = append(resp.Mentions, mention)
+			return nil
+		}, target, cursor.BlobID, cursor.LinkID, in.PageSize); err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+var qLookupResource = dqb.Str(`
+	SELECT id FROM resources WHERE iri = :iri LIMIT 1;
+`)
+
+// Links from comments to their target documents are not mentions.
+// Changes only mention things from the documents they were applied to,
+// which only happens for the changes from the authorized writers.
+// Nothing is mentioned from the deleted documents.
+const qListMentionsTpl = `
+	SELECT
+		r.iri,
+		b.codec,
+		b.multihash,
+		pk.principal AS author,
+		sb.ts,
+		sb.type AS blob_type,
+		rl.is_pinned,
+		rl.extra_attrs->>'a' AS anchor,
+		rl.extra_attrs->>'v' AS target_version,
+		rl.extra_attrs->>'f' AS target_fragment,
+		ob.codec AS original_codec,
+		ob.multihash AS original_multihash,
+		sb.id AS blob_id,
+		rl.id AS link_id
+	FROM resource_links rl
+	JOIN structural_blobs sb ON sb.id = rl.source
+	JOIN blobs b ON b.id = sb.id
+	JOIN public_keys pk ON pk.id = sb.author
+	LEFT JOIN resources r ON r.id = CASE sb.type
+		WHEN 'Change' THEN (SELECT dc.resource FROM document_changes dc WHERE dc.change = sb.id LIMIT 1)
+		ELSE sb.resource
+	END
+	LEFT JOIN blob_links ol ON ol.source = sb.id AND ol.type = 'comment/original'
+	LEFT JOIN blobs ob ON ob.id = ol.target
+	WHERE rl.target = :target
+	AND rl.type != 'comment/target'
+	AND (rl.source, rl.id) %s (:blob_id, :link_id)
+	AND sb.type IN ('Change', 'Comment')
+	AND (sb.type != 'Change' OR r.id IS NOT NULL)
+	AND (r.iri IS NULL OR r.iri NOT IN (SELECT iri FROM deleted_resources))
+	-- Comments only mention what their latest version by the same author links to.
+	-- Deleted comments have no content, so their previous versions are not mentions either.
+	AND (sb.type != 'Comment' OR sb.id = (
+		SELECT coalesce((
+			SELECT v.id
+			FROM blob_links vl
+			JOIN structural_blobs v ON v.id = vl.source
+			WHERE vl.target = o.id
+			AND vl.type = 'comment/original'
+			AND v.author = o.author
+			ORDER BY v.ts DESC
+			LIMIT 1
+		), o.id)
+		FROM structural_blobs o
+		WHERE o.id = coalesce(ol.target, sb.id)
+	))
+	ORDER BY rl.source %s, rl.id %s
+	LIMIT :page_size + 1;
+`
+
+func qListMentions(desc bool) string {
+	if desc {
+		return qListMentionsDesc()
+	}
+
+	return qListMentionsAsc()
+}
+
+var qListMentionsAsc = dqb.Q(func() string {
+	return fmt.Sprintf(qListMentionsTpl, ">", "ASC", "ASC")
+})
+
+var qListMentionsDesc = dqb.Q(func() string {
+	return fmt.Sprintf(qListMentionsTpl, "<", "DESC", "DESC")
+})
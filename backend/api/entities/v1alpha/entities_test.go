@@ -0,0 +1,580 @@
+package entities
+
+import (
+	"context"
+	documentsimpl "seed/backend/api/documents/v3alpha"
+	"seed/backend/core"
+	"seed/backend/core/coretest"
+	documents "seed/backend/genproto/documents/v3alpha"
+	entities "seed/backend/genproto/entities/v1alpha"
+	"seed/backend/hlc"
+	"seed/backend/index"
+	"seed/backend/logging"
+	"seed/backend/storage"
+	"seed/backend/util/must"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServers creates the entities server along with the documents server to create the content.
+// The key of alice is stored as "main", and the key of bob as "bob".
+func newTestServers(t *testing.T) (*Server, *documentsimpl.Server, *index.Index) {
+	t.Helper()
+
+	ctx := context.Background()
+	ks := core.NewMemoryKeyStore()
+	require.NoError(t, ks.StoreKey(ctx, "main", coretest.NewTester("alice").Account))
+	require.NoError(t, ks.StoreKey(ctx, "bob", coretest.NewTester("bob").Account))
+
+	db := storage.MakeTestMemoryDB(t)
+	idx := index.NewIndex(db, logging.New("seed/index", "debug"), nil)
+	docs := documentsimpl.NewServer(ks, idx, db, logging.New("seed/documents", "debug"))
+
+	return NewServer(idx, nil), docs, idx
+}
+
+func TestSearchEntities(t *testing.T) {
+	t.Parallel()
+
+	srv, docs, idx := newTestServers(t)
+	ctx := context.Background()
+	account := coretest.NewTester("alice").Account.Principal().String()
+
+	d1, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/notes/cats",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Cats"}}},
+			{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b1", Type: "paragraph", Text: "Cats are independent animals"}}},
+			{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b2", LeftSibling: "b1"}}},
+			{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b2", Type: "paragraph", Text: "Some cats like water"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/pets/dogs",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Dogs"}}},
+			{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b1", Type: "paragraph", Text: "Dogs chase cats and like water"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "cats"})
+	require.NoError(t, err)
+	require.Len(t, res.Entities, 4)
+	require.Equal(t, "hm://"+account+"/notes/cats", res.Entities[0].Id, "title match must be ranked first")
+	require.Equal(t, "", res.Entities[0].BlockId)
+	require.Equal(t, "Cats", res.Entities[0].Title)
+	require.Equal(t, account, res.Entities[0].Owner)
+
+	// Editing and deleting blocks must supersede the old content.
+	_, err = docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           d1.Path,
+		BaseVersion:    d1.Version,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b1", Type: "paragraph", Text: "Felines are independent animals"}}},
+			{Op: &documents.DocumentChange_DeleteBlock{DeleteBlock: "b2"}},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err = srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "water"})
+	require.NoError(t, err)
+	require.Len(t, res.Entities, 1)
+	require.Equal(t, "hm://"+account+"/pets/dogs", res.Entities[0].Id)
+	require.Equal(t, "b1", res.Entities[0].BlockId)
+	require.Equal(t, "Dogs chase cats and like water", res.Entities[0].Content)
+
+	res, err = srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "indep fel"})
+	require.NoError(t, err)
+	require.Len(t, res.Entities, 0, "only the last word must be matched as a prefix")
+
+	res, err = srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "independent fel"})
+	require.NoError(t, err)
+	require.Len(t, res.Entities, 1)
+	require.Equal(t, "b1", res.Entities[0].BlockId)
+	require.Equal(t, "Cats", res.Entities[0].Title)
+
+	// Filtering by path prefix.
+	res, err = srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "cats", Account: account, PathPrefix: "/pets"})
+	require.NoError(t, err)
+	require.Len(t, res.Entities, 1)
+	require.Equal(t, "hm://"+account+"/pets/dogs", res.Entities[0].Id)
+
+	// Pagination.
+	var all []*entities.Entity
+	var pageToken string
+	for {
+		res, err := srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "cats", PageSize: 1, PageToken: pageToken})
+		require.NoError(t, err)
+		all = append(all, res.Entities...)
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	require.Len(t, all, 2)
+
+	// Refs from unauthorized authors must not get their content into other documents.
+	bob := coretest.NewTester("bob")
+	bobDoc, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "bob",
+		Account:        bob.Account.Principal().String(),
+		Path:           "/tigers",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Tigers"}}},
+		},
+	})
+	require.NoError(t, err)
+	head := must.Do2(cid.Decode(bobDoc.Version))
+	ref := must.Do2(index.NewRef(bob.Account, head, index.IRI("hm://"+account+d1.Path), []cid.Cid{head}, int64(hlc.FromTime(time.Now()))))
+	require.NoError(t, idx.Put(ctx, ref))
+
+	res, err = srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "tigers"})
+	require.NoError(t, err)
+	require.Len(t, res.Entities, 1)
+	require.Equal(t, "hm://"+bob.Account.Principal().String()+"/tigers", res.Entities[0].Id)
+}
+
+func TestEntityTimeline(t *testing.T) {
+	t.Parallel()
+
+	srv, docs, _ := newTestServers(t)
+	bob := coretest.NewTester("bob")
+	ctx := context.Background()
+	account := coretest.NewTester("alice").Account.Principal().String()
+
+	d1, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/timeline",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Timeline"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	cpb, err := docs.CreateCapability(ctx, &documents.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bob.Account.Principal().String(),
+		Account:        account,
+		Path:           d1.Path,
+		Role:           documents.Role_WRITER,
+	})
+	require.NoError(t, err)
+
+	bobDoc, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "bob",
+		Capability:     cpb.Id,
+		Account:        account,
+		Path:           d1.Path,
+		BaseVersion:    d1.Version,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Bob's timeline"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	aliceDoc, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           d1.Path,
+		BaseVersion:    d1.Version,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Alice's timeline"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Document versions include the heads of all the writers,
+	// so we need to find the change created by each of the authors.
+	root := d1.Version
+	bobChange := bobDoc.Version
+	var aliceChange string
+	for _, h := range strings.Split(aliceDoc.Version, ".") {
+		if h != bobChange {
+			aliceChange = h
+		}
+	}
+	require.NotEmpty(t, aliceChange)
+
+	tl, err := srv.GetEntityTimeline(ctx, &entities.GetEntityTimelineRequest{Id: "hm://" + account + d1.Path})
+	require.NoError(t, err)
+	require.Equal(t, account, tl.Owner)
+	require.Len(t, tl.Changes, 3)
+	require.Equal(t, []string{root, bobChange, aliceChange}, tl.ChangesByTime)
+	require.Equal(t, []string{root}, tl.Roots)
+	require.Equal(t, []string{bobChange, aliceChange}, tl.Heads)
+	require.ElementsMatch(t, []string{bobChange, aliceChange}, tl.Changes[root].Children)
+	require.Equal(t, []string{root}, tl.Changes[aliceChange].Deps)
+
+	require.Len(t, tl.AuthorVersions, 2)
+	require.Equal(t, bob.Account.Principal().String(), tl.AuthorVersions[0].Author)
+	require.Equal(t, []string{bobChange}, tl.AuthorVersions[0].Heads)
+	require.Equal(t, bobChange, tl.AuthorVersions[0].Version)
+	require.Equal(t, account, tl.AuthorVersions[1].Author)
+	require.Equal(t, []string{aliceChange}, tl.AuthorVersions[1].Heads, "author heads must not include ancestors of other changes from the same author")
+	require.Equal(t, tl.Changes[aliceChange].CreateTime.AsTime(), tl.AuthorVersions[1].VersionTime.AsTime())
+
+	change, err := srv.GetChange(ctx, &entities.GetChangeRequest{Id: root})
+	require.NoError(t, err)
+	require.Equal(t, account, change.Author)
+	require.Empty(t, change.Deps)
+	require.Equal(t, []string{bobChange, aliceChange}, change.Children)
+	require.Equal(t, tl.Changes[root].CreateTime.AsTime(), change.CreateTime.AsTime())
+
+	change, err = srv.GetChange(ctx, &entities.GetChangeRequest{Id: bobChange})
+	require.NoError(t, err)
+	require.Equal(t, bob.Account.Principal().String(), change.Author)
+	require.Equal(t, []string{root}, change.Deps)
+	require.Empty(t, change.Children)
+
+	_, err = srv.GetChange(ctx, &entities.GetChangeRequest{Id: cpb.Id})
+	require.Error(t, err, "capability is not a change")
+
+	_, err = srv.GetEntityTimeline(ctx, &entities.GetEntityTimelineRequest{Id: "hm://" + account + "/missing"})
+	require.Error(t, err)
+}
+
+func TestListEntityMentions(t *testing.T) {
+	t.Parallel()
+
+	srv, docs, idx := newTestServers(t)
+	ctx := context.Background()
+	account := coretest.NewTester("alice").Account.Principal().String()
+
+	target, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/target",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Target"}}},
+		},
+	})
+	require.NoError(t, err)
+	targetID := "hm://" + account + target.Path
+
+	source, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/source",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b1", Type: "embed", Ref: targetID + "?v=" + target.Version + "#blk"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	// Edit the source to make sure the mention is attributed to the document through its descendant changes.
+	_, err = docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           source.Path,
+		BaseVersion:    source.Version,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Source"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	cmt, err := docs.CreateComment(ctx, &documents.CreateCommentRequest{
+		SigningKeyName: "main",
+		TargetAccount:  account,
+		TargetPath:     source.Path,
+		TargetVersion:  source.Version,
+		Content: []*documents.BlockNode{{Block: &documents.Block{Id: "c1", Type: "paragraph", Text: "See target", Annotations: []*documents.Annotation{
+			{Type: "link", Ref: targetID, Starts: []int32{4}, Ends: []int32{10}},
+		}}}},
+	})
+	require.NoError(t, err)
+
+	// Comments on the target itself are not mentions.
+	_, err = docs.CreateComment(ctx, &documents.CreateCommentRequest{
+		SigningKeyName: "main",
+		TargetAccount:  account,
+		TargetPath:     target.Path,
+		TargetVersion:  target.Version,
+		Content:        []*documents.BlockNode{{Block: &documents.Block{Id: "c1", Type: "paragraph", Text: "Nice"}}},
+	})
+	require.NoError(t, err)
+
+	res, err := srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: targetID})
+	require.NoError(t, err)
+	require.Len(t, res.Mentions, 2)
+	require.Empty(t, res.NextPageToken)
+
+	m := res.Mentions[0]
+	require.Equal(t, "hm://"+account+source.Path, m.Source)
+	require.Equal(t, "b1", m.SourceContext)
+	require.Equal(t, source.Version, m.SourceBlob.Cid)
+	require.Equal(t, account, m.SourceBlob.Author)
+	require.Equal(t, target.Version, m.TargetVersion)
+	require.True(t, m.IsExactVersion)
+	require.Equal(t, "blk", m.TargetFragment)
+
+	m = res.Mentions[1]
+	require.Equal(t, "hm://c/"+cmt.Id, m.Source)
+	require.Equal(t, "c1", m.SourceContext)
+	require.Equal(t, cmt.Id, m.SourceBlob.Cid)
+	require.False(t, m.IsExactVersion)
+	require.Empty(t, m.TargetVersion)
+
+	// Pagination in both directions.
+	for _, reverse := range []bool{false, true} {
+		var (
+			all   []*entities.Mention
+			token string
+		)
+		for {
+			res, err := srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: targetID, PageSize: 1, PageToken: token, ReverseOrder: reverse})
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(res.Mentions), 1)
+			all = append(all, res.Mentions...)
+			token = res.NextPageToken
+			if token == "" {
+				break
+			}
+		}
+		require.Len(t, all, 2)
+		if reverse {
+			require.Equal(t, "hm://c/"+cmt.Id, all[0].Source)
+		} else {
+			require.Equal(t, "hm://c/"+cmt.Id, all[1].Source)
+		}
+	}
+
+	_, err = srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: "hm://" + account + "/missing"})
+	require.Error(t, err)
+
+	// Only the latest version of the comment is taken into account.
+	_, err = docs.UpdateComment(ctx, &documents.UpdateCommentRequest{
+		Id:             cmt.Id,
+		SigningKeyName: "main",
+		Content:        []*documents.BlockNode{{Block: &documents.Block{Id: "c1", Type: "paragraph", Text: "No links anymore"}}},
+	})
+	require.NoError(t, err)
+
+	res, err = srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: targetID})
+	require.NoError(t, err)
+	require.Len(t, res.Mentions, 1, "edited comment must not mention the target")
+	require.Equal(t, "hm://"+account+source.Path, res.Mentions[0].Source)
+
+	_, err = docs.UpdateComment(ctx, &documents.UpdateCommentRequest{
+		Id:             cmt.Id,
+		SigningKeyName: "main",
+		Content: []*documents.BlockNode{{Block: &documents.Block{Id: "c1", Type: "paragraph", Text: "See target again", Annotations: []*documents.Annotation{
+			{Type: "link", Ref: targetID, Starts: []int32{4}, Ends: []int32{10}},
+		}}}},
+	})
+	require.NoError(t, err)
+
+	res, err = srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: targetID})
+	require.NoError(t, err)
+	require.Len(t, res.Mentions, 2, "comment must mention the target again")
+	require.Equal(t, "hm://c/"+cmt.Id, res.Mentions[1].Source)
+
+	_, err = docs.DeleteComment(ctx, &documents.DeleteCommentRequest{Id: cmt.Id, SigningKeyName: "main"})
+	require.NoError(t, err)
+
+	res, err = srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: targetID})
+	require.NoError(t, err)
+	require.Len(t, res.Mentions, 1, "deleted comment must not mention the target")
+	require.Equal(t, "hm://"+account+source.Path, res.Mentions[0].Source)
+
+	// Changes from unauthorized authors are not applied to the documents, so they don't mention anything.
+	bob := coretest.NewTester("bob")
+	ts := int64(hlc.FromTime(time.Now()))
+	forged := must.Do2(index.NewChange(bob.Account, nil, "Create", map[string]any{
+		"blocks": map[string]any{
+			"b1": map[string]any{"#map": map[string]any{"type": "embed", "ref": targetID}},
+		},
+	}, ts))
+	require.NoError(t, idx.Put(ctx, forged))
+	ref := must.Do2(index.NewRef(bob.Account, forged.CID, index.IRI("hm://"+account+"/forged"), []cid.Cid{forged.CID}, ts))
+	require.NoError(t, idx.Put(ctx, ref))
+
+	res, err = srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: targetID})
+	require.NoError(t, err)
+	require.Len(t, res.Mentions, 1, "unauthorized changes must not mention the target")
+	require.Equal(t, "hm://"+account+source.Path, res.Mentions[0].Source)
+
+	_, err = docs.DeleteDocument(ctx, &documents.DeleteDocumentRequest{Account: account, Path: source.Path, SigningKeyName: "main"})
+	require.NoError(t, err)
+
+	res, err = srv.ListEntityMentions(ctx, &entities.ListEntityMentionsRequest{Id: targetID})
+	require.NoError(t, err)
+	require.Len(t, res.Mentions, 0, "deleted documents must not mention the target")
+}
+
+func TestDeleteEntity(t *testing.T) {
+	t.Parallel()
+
+	srv, docs, idx := newTestServers(t)
+	ctx := context.Background()
+	account := coretest.NewTester("alice").Account.Principal().String()
+
+	spam, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/spam",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Cheap watches"}}},
+			{Op: &documents.DocumentChange_MoveBlock_{MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1"}}},
+			{Op: &documents.DocumentChange_ReplaceBlock{ReplaceBlock: &documents.Block{Id: "b1", Type: "paragraph", Text: "Buy cheap watches now"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = docs.CreateComment(ctx, &documents.CreateCommentRequest{
+		SigningKeyName: "main",
+		TargetAccount:  account,
+		TargetPath:     spam.Path,
+		TargetVersion:  spam.Version,
+		Content:        []*documents.BlockNode{{Block: &documents.Block{Id: "c1", Type: "paragraph", Text: "Great deal"}}},
+	})
+	require.NoError(t, err)
+
+	keep, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/keep",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Watches I like"}}},
+		},
+	})
+	require.NoError(t, err)
+
+	change, err := cid.Decode(spam.Version)
+	require.NoError(t, err)
+	blk, err := idx.Get(ctx, change)
+	require.NoError(t, err)
+
+	spamID := "hm://" + account + spam.Path
+	_, err = srv.DeleteEntity(ctx, &entities.DeleteEntityRequest{Id: spamID, Reason: "spam"})
+	require.NoError(t, err)
+
+	_, err = docs.GetDocument(ctx, &documents.GetDocumentRequest{Account: account, Path: spam.Path})
+	require.Error(t, err)
+
+	_, err = docs.GetDocument(ctx, &documents.GetDocumentRequest{Account: account, Path: keep.Path})
+	require.NoError(t, err)
+
+	ok, err := idx.Has(ctx, change)
+	require.NoError(t, err)
+	require.False(t, ok, "blobs of the deleted entity must be removed")
+
+	res, err := srv.SearchEntities(ctx, &entities.SearchEntitiesRequest{Query: "watches"})
+	require.NoError(t, err)
+	require.Len(t, res.Entities, 1)
+	require.Equal(t, "hm://"+account+keep.Path, res.Entities[0].Id)
+
+	comments, err := docs.ListComments(ctx, &documents.ListCommentsRequest{TargetAccount: account, TargetPath: spam.Path})
+	require.NoError(t, err)
+	require.Len(t, comments.Comments, 0)
+
+	// We don't offer deleted blobs to other peers, but we pretend to have them when syncing.
+	set, err := idx.BlobSet(ctx, false)
+	require.NoError(t, err)
+	require.False(t, must.Do2(set.Has(ctx, change)))
+	set, err = idx.BlobSet(ctx, true)
+	require.NoError(t, err)
+	require.True(t, must.Do2(set.Has(ctx, change)))
+
+	// Deleted blobs must not come back.
+	require.NoError(t, idx.Put(ctx, blk))
+	ok, err = idx.Has(ctx, change)
+	require.NoError(t, err)
+	require.False(t, ok, "deleted blobs must not be stored again")
+
+	list, err := srv.ListDeletedEntities(ctx, &entities.ListDeletedEntitiesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.DeletedEntities, 1)
+	require.Equal(t, spamID, list.DeletedEntities[0].Id)
+	require.Equal(t, "spam", list.DeletedEntities[0].DeletedReason)
+	require.Contains(t, list.DeletedEntities[0].Metadata, "Cheap watches")
+	require.NotNil(t, list.DeletedEntities[0].DeleteTime)
+
+	_, err = srv.UndeleteEntity(ctx, &entities.UndeleteEntityRequest{Id: "hm://" + account + keep.Path})
+	require.Error(t, err, "can't undelete entities that are not deleted")
+
+	_, err = srv.UndeleteEntity(ctx, &entities.UndeleteEntityRequest{Id: spamID})
+	require.NoError(t, err)
+
+	list, err = srv.ListDeletedEntities(ctx, &entities.ListDeletedEntitiesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.DeletedEntities, 0)
+
+	set, err = idx.BlobSet(ctx, true)
+	require.NoError(t, err)
+	require.False(t, must.Do2(set.Has(ctx, change)), "undeleted blobs must be fetched again")
+
+	require.NoError(t, idx.Put(ctx, blk))
+	ok, err = idx.Has(ctx, change)
+	require.NoError(t, err)
+	require.True(t, ok, "blobs must be synced back after undeleting")
+
+	set, err = idx.BlobSet(ctx, false)
+	require.NoError(t, err)
+	require.True(t, must.Do2(set.Has(ctx, change)))
+}
+
+func TestUndeleteTombstonedEntity(t *testing.T) {
+	t.Parallel()
+
+	srv, docs, _ := newTestServers(t)
+	ctx := context.Background()
+	account := coretest.NewTester("alice").Account.Principal().String()
+
+	doc, err := docs.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		SigningKeyName: "main",
+		Account:        account,
+		Path:           "/old",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Old"}}},
+		},
+	})
+	require.NoError(t, err)
+	docID := "hm://" + account + doc.Path
+
+	_, err = docs.DeleteDocument(ctx, &documents.DeleteDocumentRequest{Account: account, Path: doc.Path, SigningKeyName: "main"})
+	require.NoError(t, err)
+
+	list, err := srv.ListDeletedEntities(ctx, &entities.ListDeletedEntitiesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.DeletedEntities, 1)
+	require.Equal(t, "tombstone", list.DeletedEntities[0].DeletedReason)
+
+	_, err = srv.DeleteEntity(ctx, &entities.DeleteEntityRequest{Id: docID, Reason: "cleanup"})
+	require.NoError(t, err)
+
+	list, err = srv.ListDeletedEntities(ctx, &entities.ListDeletedEntitiesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.DeletedEntities, 1)
+	require.Equal(t, "cleanup", list.DeletedEntities[0].DeletedReason)
+
+	// Undoing the local deletion must not bring back the document deleted by its owner.
+	_, err = srv.UndeleteEntity(ctx, &entities.UndeleteEntityRequest{Id: docID})
+	require.NoError(t, err)
+
+	list, err = srv.ListDeletedEntities(ctx, &entities.ListDeletedEntitiesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.DeletedEntities, 1)
+	require.Equal(t, docID, list.DeletedEntities[0].Id)
+	require.Equal(t, "tombstone", list.DeletedEntities[0].DeletedReason)
+
+	_, err = docs.GetDocument(ctx, &documents.GetDocumentRequest{Account: account, Path: doc.Path})
+	require.Error(t, err)
+}
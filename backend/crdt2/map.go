@@ -1,6 +1,7 @@
 package crdt2
 
 import (
+	"fmt"
 	"math"
 	"sort"
 
@@ -215,6 +216,66 @@ func (m *Map) ForgetState(time int64, origin string) {
 	}
 }
 
+// Node is an exported representation of a single entry of the map state.
+// It's used to persist the state of the map, e.g. in snapshots.
+type Node struct {
+	Path   []string
+	Time   int64
+	Origin string
+	Kind   byte
+	Value  any
+}
+
+// Nodes returns the compacted state of the map.
+// Overwritten values are omitted, because they can never win over the ones that overwrote them,
+// but list chunks are returned in full, because they all contribute to the final state of the list.
+// Nodes are returned in the internal order of the map.
+func (m *Map) Nodes() []Node {
+	var out []Node
+	var last mapNode
+	m.state.Scan(func(item mapNode) bool {
+		// Nodes with the same path are sorted by time,
+		// so the previous node is overwritten by the current one.
+		if len(out) > 0 && samePath(last.key.path, item.key.path) &&
+			last.valueType != mapValueListChunk && item.valueType != mapValueListChunk {
+			out = out[:len(out)-1]
+		}
+
+		out = append(out, Node{
+			Path:   item.key.path,
+			Time:   item.key.time,
+			Origin: item.key.origin,
+			Kind:   byte(item.valueType),
+			Value:  item.value,
+		})
+		last = item
+
+		return true
+	})
+
+	return out
+}
+
+// LoadNodes restores the state of the map from the nodes previously returned by Nodes.
+func (m *Map) LoadNodes(nodes []Node) error {
+	for _, n := range nodes {
+		vt := mapValueType(n.Kind)
+		switch vt {
+		case mapValuePrimitive, mapValueAtomicMap:
+		case mapValueListChunk:
+			if _, ok := n.Value.(map[string]any); !ok {
+				return fmt.Errorf("list chunk at %v must be a map, got %T", n.Path, n.Value)
+			}
+		default:
+			return fmt.Errorf("unsupported map node kind %d at %v", n.Kind, n.Path)
+		}
+
+		m.setNode(n.Time, n.Origin, n.Path, vt, n.Value)
+	}
+
+	return nil
+}
+
 func newPivot(path []string, reverse bool) mapNode {
 	t := int64(0)
 	if reverse {
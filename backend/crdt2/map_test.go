@@ -130,3 +130,35 @@ func TestMapValueGet(t *testing.T) {
 		"country": "Wonderland",
 	}, v.(map[string]any))
 }
+
+func TestMapNodes(t *testing.T) {
+	m := NewMap()
+
+	m.Set(1, "bob", []string{"title"}, "Old")
+	m.Set(2, "alice", []string{"title"}, "New")
+	m.ApplyPatch(1, "bob", map[string]any{
+		"list": map[string]any{"#list": map[string]any{"#ins": []any{"a"}}},
+	})
+	m.ApplyPatch(2, "alice", map[string]any{
+		"list": map[string]any{"#list": map[string]any{"#ins": []any{"b"}}},
+	})
+
+	nodes := m.Nodes()
+	require.Len(t, nodes, 3, "overwritten values must be compacted, but list chunks must be kept")
+
+	m2 := NewMap()
+	require.NoError(t, m2.LoadNodes(nodes))
+
+	v, origin, ok := m2.GetWithOrigin("title")
+	require.True(t, ok)
+	require.Equal(t, "New", v)
+	require.Equal(t, "alice", origin)
+
+	list, ok := m2.List("list")
+	require.True(t, ok)
+	require.Equal(t, []any{"a", "b"}, list)
+
+	// Older concurrent values must not win over the restored state.
+	m2.Set(1, "carol", []string{"title"}, "Concurrent")
+	require.Equal(t, "New", m2.GetAny("title"))
+}
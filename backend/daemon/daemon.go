@@ -164,18 +164,39 @@ func Load(ctx context.Context, cfg config.Config, r Storage, oo ...Option) (a *A
 	otel.SetTracerProvider(tp)
 
 	a.Index = index.NewIndex(a.Storage.DB(), logging.New("seed/indexing", cfg.LogLevel), nil)
+	// Reindexing can take a long time on large databases, so we don't block the startup.
+	// Until it finishes the derived data is stale, but everything keeps working.
+	a.g.Go(func() error {
+		if err := a.Index.MaybeReindex(ctx); err != nil && ctx.Err() == nil {
+			a.log.Error("ReindexFailed", zap.Error(err))
+		}
+		return nil
+	})
 
 	a.Net, err = initNetwork(&a.clean, a.g, a.Storage, cfg.P2P, a.Index, cfg.LogLevel, opts.extraP2PServices...)
 	if err != nil {
 		return nil, err
 	}
 	a.Index.SetProvider(a.Net.Provider())
-	activitySrv := activity.NewServer(a.Storage.DB(), logging.New("seed/activity", cfg.LogLevel), &a.clean)
+	activitySrv := activity.NewServer(a.Storage.DB(), a.Index, logging.New("seed/activity", cfg.LogLevel), &a.clean)
 	a.Syncing, err = initSyncing(cfg.Syncing, &a.clean, a.g, a.Storage.DB(), a.Index, a.Net, activitySrv, cfg.LogLevel)
 	if err != nil {
 		return nil, err
 	}
 	activitySrv.SetSyncer(a.Syncing)
+
+	// The webhook loop must stop before the database is closed.
+	webhooksCtx, stopWebhooks := context.WithCancel(ctx)
+	webhooksDone := make(chan struct{})
+	a.clean.AddErrFunc(func() error {
+		stopWebhooks()
+		<-webhooksDone
+		return nil
+	})
+	a.g.Go(func() error {
+		defer close(webhooksDone)
+		return activitySrv.DeliverWebhooks(webhooksCtx)
+	})
 	a.Wallet = wallet.New(ctx, logging.New("seed/wallet", cfg.LogLevel), a.Storage.DB(), a.Storage.KeyStore(), "main", a.Net, cfg.Lndhub.Mainnet)
 
 	a.GRPCServer, a.GRPCListener, a.RPC, err = initGRPC(ctx, cfg.GRPC.Port, &a.clean, a.g, a.Storage, a.Index, a.Net,
@@ -204,7 +225,9 @@ func Load(ctx context.Context, cfg config.Config, r Storage, oo ...Option) (a *A
 
 	a.HTTPServer, a.HTTPListener, err = initHTTP(cfg.HTTP.Port, a.GRPCServer, &a.clean, a.g, a.Index,
 		a.Wallet,
-		fm, opts.extraHTTPHandlers...)
+		fm,
+		a.RPC.DocumentsV3,
+		opts.extraHTTPHandlers...)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package daemon
 import (
 	"context"
 	documentsimpl "seed/backend/api/documents/v3alpha"
+	"seed/backend/config"
 	"seed/backend/core"
 	"seed/backend/core/coretest"
 	activity "seed/backend/genproto/activity/v1alpha"
@@ -364,10 +365,11 @@ func TestDiscoverHomeDocument(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	_, err = bob.RPC.Entities.DiscoverEntity(ctx, &entities.DiscoverEntityRequest{
+	disc, err := bob.RPC.Entities.DiscoverEntity(ctx, &entities.DiscoverEntityRequest{
 		Id: "hm://" + homeDoc.Account,
 	})
 	require.NoError(t, err)
+	require.Equal(t, []string{alice.Net.AddrInfo().ID.String()}, disc.Peers)
 
 	accGotten, err := bob.RPC.DocumentsV3.GetDocument(ctx, &documents.GetDocumentRequest{
 		Account: homeDoc.Account,
@@ -376,6 +378,41 @@ func TestDiscoverHomeDocument(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, homeDoc.Version, accGotten.Version)
 	require.Equal(t, homeDoc.Content, accGotten.Content)
+
+	// Discovering the version we already have doesn't need any peers.
+	disc, err = bob.RPC.Entities.DiscoverEntity(ctx, &entities.DiscoverEntityRequest{
+		Id:      "hm://" + homeDoc.Account,
+		Version: homeDoc.Version,
+	})
+	require.NoError(t, err)
+	require.Len(t, disc.Peers, 0)
+
+	homeDoc2, err := alice.RPC.DocumentsV3.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		Account:        ret.AccountId,
+		Path:           "",
+		BaseVersion:    homeDoc.Version,
+		SigningKeyName: ret.Name,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Alice's home"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	disc, err = bob.RPC.Entities.DiscoverEntity(ctx, &entities.DiscoverEntityRequest{
+		Id:      "hm://" + homeDoc.Account,
+		Version: homeDoc2.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{alice.Net.AddrInfo().ID.String()}, disc.Peers)
+
+	accGotten, err = bob.RPC.DocumentsV3.GetDocument(ctx, &documents.GetDocumentRequest{
+		Account: homeDoc.Account,
+		Version: homeDoc2.Version,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Alice's home", accGotten.Metadata["title"])
 }
 
 func TestSubscriptions(t *testing.T) {
@@ -577,6 +614,9 @@ func TestSubscriptions(t *testing.T) {
 	require.Len(t, res.Subscriptions, 1)
 	require.Equal(t, doc2.Account, res.Subscriptions[0].Account)
 	require.Equal(t, doc2.Path, res.Subscriptions[0].Path)
+	require.NotNil(t, res.Subscriptions[0].LastSyncTime, "blocking subscription must record the sync")
+	require.Empty(t, res.Subscriptions[0].LastSyncError)
+	require.Equal(t, int32(1), res.Subscriptions[0].PeersWithContent)
 	time.Sleep(time.Millisecond * 100)
 
 	_, err = alice.RPC.DocumentsV3.GetDocument(ctx, &documents.GetDocumentRequest{
@@ -782,3 +822,136 @@ func TestSubscriptions(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestFilteredSubscriptions(t *testing.T) {
+	t.Parallel()
+	newConfig := func() config.Config {
+		cfg := makeTestConfig(t)
+		cfg.Syncing.NoSyncBack = true
+		cfg.Syncing.SmartSyncing = true
+		cfg.Syncing.Interval = time.Millisecond * 100
+		cfg.Syncing.WarmupDuration = time.Millisecond * 200
+		return cfg
+	}
+	alice := makeTestApp(t, "alice", newConfig(), true)
+	bob := makeTestApp(t, "bob", newConfig(), true)
+	carol := makeTestApp(t, "carol", newConfig(), true)
+	ctx := context.Background()
+	aliceIdentity := coretest.NewTester("alice")
+	bobIdentity := coretest.NewTester("bob")
+
+	doc, err := alice.RPC.DocumentsV3.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		Account:        aliceIdentity.Account.Principal().String(),
+		Path:           "/cars",
+		SigningKeyName: "main",
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_SetMetadata_{
+				SetMetadata: &documents.DocumentChange_SetMetadata{Key: "title", Value: "Cars"},
+			}},
+			{Op: &documents.DocumentChange_MoveBlock_{
+				MoveBlock: &documents.DocumentChange_MoveBlock{BlockId: "b1", Parent: "", LeftSibling: ""},
+			}},
+			{Op: &documents.DocumentChange_ReplaceBlock{
+				ReplaceBlock: &documents.Block{Id: "b1", Type: "paragraph", Text: "Written by Alice"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	cpb, err := alice.RPC.DocumentsV3.CreateCapability(ctx, &documents.CreateCapabilityRequest{
+		SigningKeyName: "main",
+		Delegate:       bobIdentity.Account.Principal().String(),
+		Account:        aliceIdentity.Account.Principal().String(),
+		Path:           doc.Path,
+		Role:           documents.Role_WRITER,
+	})
+	require.NoError(t, err)
+
+	_, err = bob.RPC.Networking.Connect(ctx, &networking.ConnectRequest{
+		Addrs: mttnet.AddrInfoToStrings(alice.Net.AddrInfo()),
+	})
+	require.NoError(t, err)
+
+	_, err = bob.RPC.Activity.Subscribe(ctx, &activity.SubscribeRequest{
+		Account: doc.Account,
+		Path:    doc.Path,
+	})
+	require.NoError(t, err)
+
+	// Bob's change depends on the changes from Alice.
+	bobDoc, err := bob.RPC.DocumentsV3.CreateDocumentChange(ctx, &documents.CreateDocumentChangeRequest{
+		Account:        doc.Account,
+		Path:           doc.Path,
+		BaseVersion:    doc.Version,
+		SigningKeyName: "main",
+		Capability:     cpb.Id,
+		Changes: []*documents.DocumentChange{
+			{Op: &documents.DocumentChange_ReplaceBlock{
+				ReplaceBlock: &documents.Block{Id: "b1", Type: "paragraph", Text: "Edited by Bob"},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	comment, err := bob.RPC.DocumentsV3.CreateComment(ctx, &documents.CreateCommentRequest{
+		TargetAccount:  bobDoc.Account,
+		TargetPath:     bobDoc.Path,
+		TargetVersion:  bobDoc.Version,
+		Content:        []*documents.BlockNode{{Block: &documents.Block{Id: "b1", Type: "paragraph", Text: "Hello, Alice!"}}},
+		SigningKeyName: "main",
+	})
+	require.NoError(t, err)
+
+	_, err = carol.RPC.Networking.Connect(ctx, &networking.ConnectRequest{
+		Addrs: mttnet.AddrInfoToStrings(bob.Net.AddrInfo()),
+	})
+	require.NoError(t, err)
+
+	// Only following the discussions must not bring an incomplete document.
+	_, err = carol.RPC.Activity.Subscribe(ctx, &activity.SubscribeRequest{
+		Account:   doc.Account,
+		Path:      doc.Path,
+		BlobTypes: []string{"Comment"},
+	})
+	require.NoError(t, err)
+
+	comments, err := carol.RPC.DocumentsV3.ListComments(ctx, &documents.ListCommentsRequest{
+		TargetAccount: doc.Account,
+		TargetPath:    doc.Path,
+	})
+	require.NoError(t, err)
+	require.Len(t, comments.Comments, 1)
+	require.Equal(t, comment.Id, comments.Comments[0].Id)
+
+	_, err = carol.RPC.DocumentsV3.GetDocument(ctx, &documents.GetDocumentRequest{
+		Account: doc.Account,
+		Path:    doc.Path,
+	})
+	require.Error(t, err)
+
+	// Following only the changes from Bob must bring the changes from Alice he depends on.
+	// The resource is known from the comment, so the subscription syncs in the background.
+	_, err = carol.RPC.Activity.Subscribe(ctx, &activity.SubscribeRequest{
+		Account: doc.Account,
+		Path:    doc.Path,
+		Authors: []string{bobIdentity.Account.Principal().String()},
+	})
+	require.NoError(t, err)
+
+	var got *documents.Document
+	require.Eventually(t, func() bool {
+		got, err = carol.RPC.DocumentsV3.GetDocument(ctx, &documents.GetDocumentRequest{
+			Account: doc.Account,
+			Path:    doc.Path,
+		})
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+	require.Equal(t, bobDoc.Version, got.Version)
+	require.Equal(t, bobDoc.Content, got.Content)
+	require.Equal(t, "Cars", got.Metadata["title"])
+
+	subs, err := carol.RPC.Activity.ListSubscriptions(ctx, &activity.ListSubscriptionsRequest{})
+	require.NoError(t, err)
+	require.Len(t, subs.Subscriptions, 1)
+	require.Equal(t, int32(1), subs.Subscriptions[0].PeersWithContent)
+}
@@ -9,6 +9,7 @@ import (
 	"net"
 	"net/http"
 	"runtime/debug"
+	documents "seed/backend/genproto/documents/v3alpha"
 	"seed/backend/logging"
 	"seed/backend/util/cleanup"
 	"strconv"
@@ -29,6 +30,8 @@ import (
 	"golang.org/x/exp/slices"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
@@ -50,6 +53,42 @@ func setupIPFSFileHandlers(r *Router, h IPFSFileHandler) {
 	r.Handle("/ipfs/{cid}", http.HandlerFunc(h.GetFile), 0)
 }
 
+// setupDocumentHandlers sets up the endpoints for exporting documents.
+func setupDocumentHandlers(r *Router, docs DocumentExporter) {
+	r.Handle("/export/markdown/{account}{path:(?:/.*)?}", corsMiddleware(makeMarkdownExportHandler(docs)), 0)
+}
+
+// makeMarkdownExportHandler returns the handler that renders documents as Markdown.
+// The version of the document can be specified with the "v" query parameter,
+// similar to our hm:// URLs. The "gateway" query parameter allows to rewrite ipfs:// links.
+func makeMarkdownExportHandler(docs DocumentExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		q := r.URL.Query()
+
+		res, err := docs.ExportDocument(r.Context(), &documents.ExportDocumentRequest{
+			Account:        vars["account"],
+			Path:           vars["path"],
+			Version:        q.Get("v"),
+			IpfsGatewayUrl: q.Get("gateway"),
+		})
+		if err != nil {
+			code := http.StatusInternalServerError
+			switch status.Code(err) {
+			case codes.NotFound:
+				code = http.StatusNotFound
+			case codes.InvalidArgument:
+				code = http.StatusBadRequest
+			}
+			http.Error(w, err.Error(), code)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		_, _ = io.WriteString(w, res.Markdown)
+	}
+}
+
 // setupDebugHandlers sets up the debug endpoints.
 func setupDebugHandlers(r *Router, blobs blockstore.Blockstore) {
 	r.Handle("/debug/metrics", promhttp.Handler(), RouteNav)
@@ -122,6 +161,12 @@ func setupGRPCWebHandler(r *Router, rpc *grpc.Server) {
 	})).Handler(grpcWebHandler)
 }
 
+// DocumentExporter is an interface to pass to the router only the document export functionality
+// and not the entire Documents API.
+type DocumentExporter interface {
+	ExportDocument(context.Context, *documents.ExportDocumentRequest) (*documents.ExportDocumentResponse, error)
+}
+
 // IPFSFileHandler is an interface to pass to the router only the http handlers and
 // not all the FileManager type.
 type IPFSFileHandler interface {
@@ -137,6 +182,7 @@ func initHTTP(
 	blobs blockstore.Blockstore,
 	wallet any, // TODO(hm24) put the wallet back in.
 	ipfsHandler IPFSFileHandler,
+	docs DocumentExporter,
 	extraHandlers ...func(*Router),
 ) (srv *http.Server, lis net.Listener, err error) {
 	router := &Router{r: mux.NewRouter()}
@@ -149,6 +195,7 @@ func initHTTP(
 	setupDebugHandlers(router, blobs)
 	setupGraphQLHandlers(router, wallet)
 	setupIPFSFileHandlers(router, ipfsHandler)
+	setupDocumentHandlers(router, docs)
 	setupGRPCWebHandler(router, rpc)
 	for _, handle := range extraHandlers {
 		handle(router)
@@ -25,6 +25,10 @@ type ActivityFeedClient interface {
 	// Lists the recent activity events,
 	// sorted by locally observed time (newest first).
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
+	// Streams the new activity events as soon as they are observed locally,
+	// sorted by locally observed time (oldest first).
+	// Clients can resume the stream after reconnecting by providing the last received cursor.
+	StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (ActivityFeed_StreamEventsClient, error)
 }
 
 type activityFeedClient struct {
@@ -44,6 +48,38 @@ func (c *activityFeedClient) ListEvents(ctx context.Context, in *ListEventsReque
 	return out, nil
 }
 
+func (c *activityFeedClient) StreamEvents(ctx context.Context, in *StreamEventsRequest, opts ...grpc.CallOption) (ActivityFeed_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ActivityFeed_ServiceDesc.Streams[0], "/com.seed.activity.v1alpha.ActivityFeed/StreamEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &activityFeedStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ActivityFeed_StreamEventsClient interface {
+	Recv() (*StreamEventsResponse, error)
+	grpc.ClientStream
+}
+
+type activityFeedStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *activityFeedStreamEventsClient) Recv() (*StreamEventsResponse, error) {
+	m := new(StreamEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ActivityFeedServer is the server API for ActivityFeed service.
 // All implementations should embed UnimplementedActivityFeedServer
 // for forward compatibility
@@ -51,6 +87,10 @@ type ActivityFeedServer interface {
 	// Lists the recent activity events,
 	// sorted by locally observed time (newest first).
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
+	// Streams the new activity events as soon as they are observed locally,
+	// sorted by locally observed time (oldest first).
+	// Clients can resume the stream after reconnecting by providing the last received cursor.
+	StreamEvents(*StreamEventsRequest, ActivityFeed_StreamEventsServer) error
 }
 
 // UnimplementedActivityFeedServer should be embedded to have forward compatible implementations.
@@ -60,6 +100,9 @@ type UnimplementedActivityFeedServer struct {
 func (UnimplementedActivityFeedServer) ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListEvents not implemented")
 }
+func (UnimplementedActivityFeedServer) StreamEvents(*StreamEventsRequest, ActivityFeed_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
 
 // UnsafeActivityFeedServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ActivityFeedServer will
@@ -90,6 +133,27 @@ func _ActivityFeed_ListEvents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActivityFeed_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ActivityFeedServer).StreamEvents(m, &activityFeedStreamEventsServer{stream})
+}
+
+type ActivityFeed_StreamEventsServer interface {
+	Send(*StreamEventsResponse) error
+	grpc.ServerStream
+}
+
+type activityFeedStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *activityFeedStreamEventsServer) Send(m *StreamEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ActivityFeed_ServiceDesc is the grpc.ServiceDesc for ActivityFeed service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -102,6 +166,12 @@ var ActivityFeed_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ActivityFeed_ListEvents_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _ActivityFeed_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "activity/v1alpha/activity.proto",
 }
@@ -29,6 +29,10 @@ type SubscriptionsClient interface {
 	Unsubscribe(ctx context.Context, in *UnsubscribeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Lists active subscriptions.
 	ListSubscriptions(ctx context.Context, in *ListSubscriptionsRequest, opts ...grpc.CallOption) (*ListSubscriptionsResponse, error)
+	// Streams the progress of the syncing of the subscribed content with each peer.
+	// The current state of the syncs in progress is sent first, followed by the updates.
+	// Intermediate updates may be skipped for slow readers, but the final state of each sync is always sent.
+	GetSyncProgress(ctx context.Context, in *GetSyncProgressRequest, opts ...grpc.CallOption) (Subscriptions_GetSyncProgressClient, error)
 }
 
 type subscriptionsClient struct {
@@ -66,6 +70,38 @@ func (c *subscriptionsClient) ListSubscriptions(ctx context.Context, in *ListSub
 	return out, nil
 }
 
+func (c *subscriptionsClient) GetSyncProgress(ctx context.Context, in *GetSyncProgressRequest, opts ...grpc.CallOption) (Subscriptions_GetSyncProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Subscriptions_ServiceDesc.Streams[0], "/com.seed.activity.v1alpha.Subscriptions/GetSyncProgress", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &subscriptionsGetSyncProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Subscriptions_GetSyncProgressClient interface {
+	Recv() (*SyncProgress, error)
+	grpc.ClientStream
+}
+
+type subscriptionsGetSyncProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *subscriptionsGetSyncProgressClient) Recv() (*SyncProgress, error) {
+	m := new(SyncProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // SubscriptionsServer is the server API for Subscriptions service.
 // All implementations should embed UnimplementedSubscriptionsServer
 // for forward compatibility
@@ -76,6 +112,10 @@ type SubscriptionsServer interface {
 	Unsubscribe(context.Context, *UnsubscribeRequest) (*emptypb.Empty, error)
 	// Lists active subscriptions.
 	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error)
+	// Streams the progress of the syncing of the subscribed content with each peer.
+	// The current state of the syncs in progress is sent first, followed by the updates.
+	// Intermediate updates may be skipped for slow readers, but the final state of each sync is always sent.
+	GetSyncProgress(*GetSyncProgressRequest, Subscriptions_GetSyncProgressServer) error
 }
 
 // UnimplementedSubscriptionsServer should be embedded to have forward compatible implementations.
@@ -91,6 +131,9 @@ func (UnimplementedSubscriptionsServer) Unsubscribe(context.Context, *Unsubscrib
 func (UnimplementedSubscriptionsServer) ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*ListSubscriptionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSubscriptions not implemented")
 }
+func (UnimplementedSubscriptionsServer) GetSyncProgress(*GetSyncProgressRequest, Subscriptions_GetSyncProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetSyncProgress not implemented")
+}
 
 // UnsafeSubscriptionsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to SubscriptionsServer will
@@ -157,6 +200,27 @@ func _Subscriptions_ListSubscriptions_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Subscriptions_GetSyncProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetSyncProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SubscriptionsServer).GetSyncProgress(m, &subscriptionsGetSyncProgressServer{stream})
+}
+
+type Subscriptions_GetSyncProgressServer interface {
+	Send(*SyncProgress) error
+	grpc.ServerStream
+}
+
+type subscriptionsGetSyncProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *subscriptionsGetSyncProgressServer) Send(m *SyncProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Subscriptions_ServiceDesc is the grpc.ServiceDesc for Subscriptions service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -177,6 +241,12 @@ var Subscriptions_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Subscriptions_ListSubscriptions_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetSyncProgress",
+			Handler:       _Subscriptions_GetSyncProgress_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "activity/v1alpha/subscriptions.proto",
 }
@@ -28,6 +28,9 @@ type AccessControlClient interface {
 	CreateCapability(ctx context.Context, in *CreateCapabilityRequest, opts ...grpc.CallOption) (*Capability, error)
 	// Get a single capability by ID.
 	GetCapability(ctx context.Context, in *GetCapabilityRequest, opts ...grpc.CallOption) (*Capability, error)
+	// Revokes a previously issued capability.
+	// Changes made by the delegate after the revocation are no longer honored.
+	RevokeCapability(ctx context.Context, in *RevokeCapabilityRequest, opts ...grpc.CallOption) (*Revocation, error)
 }
 
 type accessControlClient struct {
@@ -65,6 +68,15 @@ func (c *accessControlClient) GetCapability(ctx context.Context, in *GetCapabili
 	return out, nil
 }
 
+func (c *accessControlClient) RevokeCapability(ctx context.Context, in *RevokeCapabilityRequest, opts ...grpc.CallOption) (*Revocation, error) {
+	out := new(Revocation)
+	err := c.cc.Invoke(ctx, "/com.seed.documents.v3alpha.AccessControl/RevokeCapability", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccessControlServer is the server API for AccessControl service.
 // All implementations should embed UnimplementedAccessControlServer
 // for forward compatibility
@@ -75,6 +87,9 @@ type AccessControlServer interface {
 	CreateCapability(context.Context, *CreateCapabilityRequest) (*Capability, error)
 	// Get a single capability by ID.
 	GetCapability(context.Context, *GetCapabilityRequest) (*Capability, error)
+	// Revokes a previously issued capability.
+	// Changes made by the delegate after the revocation are no longer honored.
+	RevokeCapability(context.Context, *RevokeCapabilityRequest) (*Revocation, error)
 }
 
 // UnimplementedAccessControlServer should be embedded to have forward compatible implementations.
@@ -90,6 +105,9 @@ func (UnimplementedAccessControlServer) CreateCapability(context.Context, *Creat
 func (UnimplementedAccessControlServer) GetCapability(context.Context, *GetCapabilityRequest) (*Capability, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCapability not implemented")
 }
+func (UnimplementedAccessControlServer) RevokeCapability(context.Context, *RevokeCapabilityRequest) (*Revocation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCapability not implemented")
+}
 
 // UnsafeAccessControlServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to AccessControlServer will
@@ -156,6 +174,24 @@ func _AccessControl_GetCapability_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccessControl_RevokeCapability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCapabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccessControlServer).RevokeCapability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.seed.documents.v3alpha.AccessControl/RevokeCapability",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccessControlServer).RevokeCapability(ctx, req.(*RevokeCapabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AccessControl_ServiceDesc is the grpc.ServiceDesc for AccessControl service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -175,6 +211,10 @@ var AccessControl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCapability",
 			Handler:    _AccessControl_GetCapability_Handler,
 		},
+		{
+			MethodName: "RevokeCapability",
+			Handler:    _AccessControl_RevokeCapability_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v3alpha/access_control.proto",
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -28,6 +29,16 @@ type CommentsClient interface {
 	GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*Comment, error)
 	// Lists comments for a given target.
 	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// Updates the content of an existing comment.
+	// Only the author of the comment can update it.
+	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*Comment, error)
+	// Deletes an existing comment.
+	// Deleted comments are kept as tombstones to preserve the structure of the threads.
+	// Only the author of the comment can delete it.
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Marks a comment thread as resolved or reopens it.
+	// Only the owner of the target document or the author of the thread can resolve it.
+	ResolveThread(ctx context.Context, in *ResolveThreadRequest, opts ...grpc.CallOption) (*CommentThread, error)
 }
 
 type commentsClient struct {
@@ -65,6 +76,33 @@ func (c *commentsClient) ListComments(ctx context.Context, in *ListCommentsReque
 	return out, nil
 }
 
+func (c *commentsClient) UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*Comment, error) {
+	out := new(Comment)
+	err := c.cc.Invoke(ctx, "/com.seed.documents.v3alpha.Comments/UpdateComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/com.seed.documents.v3alpha.Comments/DeleteComment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentsClient) ResolveThread(ctx context.Context, in *ResolveThreadRequest, opts ...grpc.CallOption) (*CommentThread, error) {
+	out := new(CommentThread)
+	err := c.cc.Invoke(ctx, "/com.seed.documents.v3alpha.Comments/ResolveThread", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommentsServer is the server API for Comments service.
 // All implementations should embed UnimplementedCommentsServer
 // for forward compatibility
@@ -75,6 +113,16 @@ type CommentsServer interface {
 	GetComment(context.Context, *GetCommentRequest) (*Comment, error)
 	// Lists comments for a given target.
 	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// Updates the content of an existing comment.
+	// Only the author of the comment can update it.
+	UpdateComment(context.Context, *UpdateCommentRequest) (*Comment, error)
+	// Deletes an existing comment.
+	// Deleted comments are kept as tombstones to preserve the structure of the threads.
+	// Only the author of the comment can delete it.
+	DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
+	// Marks a comment thread as resolved or reopens it.
+	// Only the owner of the target document or the author of the thread can resolve it.
+	ResolveThread(context.Context, *ResolveThreadRequest) (*CommentThread, error)
 }
 
 // UnimplementedCommentsServer should be embedded to have forward compatible implementations.
@@ -90,6 +138,15 @@ func (UnimplementedCommentsServer) GetComment(context.Context, *GetCommentReques
 func (UnimplementedCommentsServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
 }
+func (UnimplementedCommentsServer) UpdateComment(context.Context, *UpdateCommentRequest) (*Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (UnimplementedCommentsServer) DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedCommentsServer) ResolveThread(context.Context, *ResolveThreadRequest) (*CommentThread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveThread not implemented")
+}
 
 // UnsafeCommentsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to CommentsServer will
@@ -156,6 +213,60 @@ func _Comments_ListComments_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Comments_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.seed.documents.v3alpha.Comments/UpdateComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comments_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.seed.documents.v3alpha.Comments/DeleteComment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Comments_ResolveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentsServer).ResolveThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.seed.documents.v3alpha.Comments/ResolveThread",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentsServer).ResolveThread(ctx, req.(*ResolveThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Comments_ServiceDesc is the grpc.ServiceDesc for Comments service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -175,6 +286,18 @@ var Comments_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListComments",
 			Handler:    _Comments_ListComments_Handler,
 		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _Comments_UpdateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _Comments_DeleteComment_Handler,
+		},
+		{
+			MethodName: "ResolveThread",
+			Handler:    _Comments_ResolveThread_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v3alpha/comments.proto",
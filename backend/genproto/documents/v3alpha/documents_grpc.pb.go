@@ -27,7 +27,9 @@ type DocumentsClient interface {
 	GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*Document, error)
 	// Creates a new Document Change.
 	CreateDocumentChange(ctx context.Context, in *CreateDocumentChangeRequest, opts ...grpc.CallOption) (*Document, error)
-	// Deletes a document.
+	// Deletes a document by publishing a signed tombstone.
+	// Deleted documents are hidden from the list requests, and other peers will hide them too after syncing.
+	// Creating new changes for the same path after the deletion makes the document visible again.
 	DeleteDocument(ctx context.Context, in *DeleteDocumentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Lists documents within the account. Only the most recent versions show up.
 	ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error)
@@ -35,6 +37,18 @@ type DocumentsClient interface {
 	ListRootDocuments(ctx context.Context, in *ListRootDocumentsRequest, opts ...grpc.CallOption) (*ListRootDocumentsResponse, error)
 	// Lists all changes of a document.
 	ListDocumentChanges(ctx context.Context, in *ListDocumentChangesRequest, opts ...grpc.CallOption) (*ListDocumentChangesResponse, error)
+	// Returns block-level differences between two versions of a document.
+	DiffDocument(ctx context.Context, in *DiffDocumentRequest, opts ...grpc.CallOption) (*DocumentDiff, error)
+	// Renders a document as CommonMark text.
+	ExportDocument(ctx context.Context, in *ExportDocumentRequest, opts ...grpc.CallOption) (*ExportDocumentResponse, error)
+	// Creates a new document change replacing the content of the document with the provided Markdown.
+	ImportMarkdown(ctx context.Context, in *ImportMarkdownRequest, opts ...grpc.CallOption) (*Document, error)
+	// Streams updates of a document whenever new changes for it are indexed,
+	// either created locally or received from other peers.
+	// The first update reflects the state of the document at the time of the subscription.
+	// Deleting the document emits an update with the deleted flag,
+	// and the stream continues in case the document comes back.
+	WatchDocument(ctx context.Context, in *WatchDocumentRequest, opts ...grpc.CallOption) (Documents_WatchDocumentClient, error)
 }
 
 type documentsClient struct {
@@ -99,6 +113,65 @@ func (c *documentsClient) ListDocumentChanges(ctx context.Context, in *ListDocum
 	return out, nil
 }
 
+func (c *documentsClient) DiffDocument(ctx context.Context, in *DiffDocumentRequest, opts ...grpc.CallOption) (*DocumentDiff, error) {
+	out := new(DocumentDiff)
+	err := c.cc.Invoke(ctx, "/com.seed.documents.v3alpha.Documents/DiffDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) ExportDocument(ctx context.Context, in *ExportDocumentRequest, opts ...grpc.CallOption) (*ExportDocumentResponse, error) {
+	out := new(ExportDocumentResponse)
+	err := c.cc.Invoke(ctx, "/com.seed.documents.v3alpha.Documents/ExportDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) ImportMarkdown(ctx context.Context, in *ImportMarkdownRequest, opts ...grpc.CallOption) (*Document, error) {
+	out := new(Document)
+	err := c.cc.Invoke(ctx, "/com.seed.documents.v3alpha.Documents/ImportMarkdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *documentsClient) WatchDocument(ctx context.Context, in *WatchDocumentRequest, opts ...grpc.CallOption) (Documents_WatchDocumentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Documents_ServiceDesc.Streams[0], "/com.seed.documents.v3alpha.Documents/WatchDocument", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &documentsWatchDocumentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Documents_WatchDocumentClient interface {
+	Recv() (*DocumentUpdate, error)
+	grpc.ClientStream
+}
+
+type documentsWatchDocumentClient struct {
+	grpc.ClientStream
+}
+
+func (x *documentsWatchDocumentClient) Recv() (*DocumentUpdate, error) {
+	m := new(DocumentUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DocumentsServer is the server API for Documents service.
 // All implementations should embed UnimplementedDocumentsServer
 // for forward compatibility
@@ -107,7 +180,9 @@ type DocumentsServer interface {
 	GetDocument(context.Context, *GetDocumentRequest) (*Document, error)
 	// Creates a new Document Change.
 	CreateDocumentChange(context.Context, *CreateDocumentChangeRequest) (*Document, error)
-	// Deletes a document.
+	// Deletes a document by publishing a signed tombstone.
+	// Deleted documents are hidden from the list requests, and other peers will hide them too after syncing.
+	// Creating new changes for the same path after the deletion makes the document visible again.
 	DeleteDocument(context.Context, *DeleteDocumentRequest) (*emptypb.Empty, error)
 	// Lists documents within the account. Only the most recent versions show up.
 	ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error)
@@ -115,6 +190,18 @@ type DocumentsServer interface {
 	ListRootDocuments(context.Context, *ListRootDocumentsRequest) (*ListRootDocumentsResponse, error)
 	// Lists all changes of a document.
 	ListDocumentChanges(context.Context, *ListDocumentChangesRequest) (*ListDocumentChangesResponse, error)
+	// Returns block-level differences between two versions of a document.
+	DiffDocument(context.Context, *DiffDocumentRequest) (*DocumentDiff, error)
+	// Renders a document as CommonMark text.
+	ExportDocument(context.Context, *ExportDocumentRequest) (*ExportDocumentResponse, error)
+	// Creates a new document change replacing the content of the document with the provided Markdown.
+	ImportMarkdown(context.Context, *ImportMarkdownRequest) (*Document, error)
+	// Streams updates of a document whenever new changes for it are indexed,
+	// either created locally or received from other peers.
+	// The first update reflects the state of the document at the time of the subscription.
+	// Deleting the document emits an update with the deleted flag,
+	// and the stream continues in case the document comes back.
+	WatchDocument(*WatchDocumentRequest, Documents_WatchDocumentServer) error
 }
 
 // UnimplementedDocumentsServer should be embedded to have forward compatible implementations.
@@ -139,6 +226,18 @@ func (UnimplementedDocumentsServer) ListRootDocuments(context.Context, *ListRoot
 func (UnimplementedDocumentsServer) ListDocumentChanges(context.Context, *ListDocumentChangesRequest) (*ListDocumentChangesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListDocumentChanges not implemented")
 }
+func (UnimplementedDocumentsServer) DiffDocument(context.Context, *DiffDocumentRequest) (*DocumentDiff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffDocument not implemented")
+}
+func (UnimplementedDocumentsServer) ExportDocument(context.Context, *ExportDocumentRequest) (*ExportDocumentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportDocument not implemented")
+}
+func (UnimplementedDocumentsServer) ImportMarkdown(context.Context, *ImportMarkdownRequest) (*Document, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportMarkdown not implemented")
+}
+func (UnimplementedDocumentsServer) WatchDocument(*WatchDocumentRequest, Documents_WatchDocumentServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDocument not implemented")
+}
 
 // UnsafeDocumentsServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DocumentsServer will
@@ -259,6 +358,81 @@ func _Documents_ListDocumentChanges_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Documents_DiffDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).DiffDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.seed.documents.v3alpha.Documents/DiffDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).DiffDocument(ctx, req.(*DiffDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_ExportDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).ExportDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.seed.documents.v3alpha.Documents/ExportDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).ExportDocument(ctx, req.(*ExportDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_ImportMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocumentsServer).ImportMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/com.seed.documents.v3alpha.Documents/ImportMarkdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocumentsServer).ImportMarkdown(ctx, req.(*ImportMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Documents_WatchDocument_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDocumentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DocumentsServer).WatchDocument(m, &documentsWatchDocumentServer{stream})
+}
+
+type Documents_WatchDocumentServer interface {
+	Send(*DocumentUpdate) error
+	grpc.ServerStream
+}
+
+type documentsWatchDocumentServer struct {
+	grpc.ServerStream
+}
+
+func (x *documentsWatchDocumentServer) Send(m *DocumentUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Documents_ServiceDesc is the grpc.ServiceDesc for Documents service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -290,7 +464,25 @@ var Documents_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListDocumentChanges",
 			Handler:    _Documents_ListDocumentChanges_Handler,
 		},
+		{
+			MethodName: "DiffDocument",
+			Handler:    _Documents_DiffDocument_Handler,
+		},
+		{
+			MethodName: "ExportDocument",
+			Handler:    _Documents_ExportDocument_Handler,
+		},
+		{
+			MethodName: "ImportMarkdown",
+			Handler:    _Documents_ImportMarkdown_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDocument",
+			Handler:       _Documents_WatchDocument_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "documents/v3alpha/documents.proto",
 }
@@ -29,9 +29,9 @@ type EntitiesClient interface {
 	GetEntityTimeline(ctx context.Context, in *GetEntityTimelineRequest, opts ...grpc.CallOption) (*EntityTimeline, error)
 	// Triggers a best-effort discovery of an entity.
 	DiscoverEntity(ctx context.Context, in *DiscoverEntityRequest, opts ...grpc.CallOption) (*DiscoverEntityResponse, error)
-	// Finds the list of local entities whose titles match the input string.
-	// A fuzzy search is performed among documents, groups and accounts.
-	// For groups and documents, we match the title, while we match alias in accounts.
+	// Finds the content of local documents matching the input query.
+	// A full-text search is performed over the text of the blocks and the metadata
+	// of the latest version of each document. Results are ordered by relevance.
 	SearchEntities(ctx context.Context, in *SearchEntitiesRequest, opts ...grpc.CallOption) (*SearchEntitiesResponse, error)
 	// Deletes an entity from the local node. It removes all the patches corresponding to it, including comments.
 	DeleteEntity(ctx context.Context, in *DeleteEntityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -134,9 +134,9 @@ type EntitiesServer interface {
 	GetEntityTimeline(context.Context, *GetEntityTimelineRequest) (*EntityTimeline, error)
 	// Triggers a best-effort discovery of an entity.
 	DiscoverEntity(context.Context, *DiscoverEntityRequest) (*DiscoverEntityResponse, error)
-	// Finds the list of local entities whose titles match the input string.
-	// A fuzzy search is performed among documents, groups and accounts.
-	// For groups and documents, we match the title, while we match alias in accounts.
+	// Finds the content of local documents matching the input query.
+	// A full-text search is performed over the text of the blocks and the metadata
+	// of the latest version of each document. Results are ordered by relevance.
 	SearchEntities(context.Context, *SearchEntitiesRequest) (*SearchEntitiesResponse, error)
 	// Deletes an entity from the local node. It removes all the patches corresponding to it, including comments.
 	DeleteEntity(context.Context, *DeleteEntityRequest) (*emptypb.Empty, error)
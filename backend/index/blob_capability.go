@@ -5,6 +5,10 @@ import (
 	"fmt"
 	"seed/backend/core"
 	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"strings"
 	"time"
 
 	"github.com/ipfs/go-cid"
@@ -106,7 +110,8 @@ func indexCapability(ictx *indexingCtx, id int64, c cid.Cid, v *Capability) erro
 
 	sb := newStructuralBlob(c, string(blobTypeCapability), v.Issuer, time.UnixMicro(v.Ts), iri, cid.Undef, v.Account, time.Time{})
 
-	if _, err := ictx.ensurePubKey(v.Issuer); err != nil {
+	issuer, err := ictx.ensurePubKey(v.Issuer)
+	if err != nil {
 		return err
 	}
 
@@ -115,10 +120,135 @@ func indexCapability(ictx *indexingCtx, id int64, c cid.Cid, v *Capability) erro
 		return err
 	}
 
-	sb.Meta = map[string]any{
+	meta := map[string]any{
 		"role": v.Role,
 		"del":  del,
 	}
 
-	return ictx.SaveBlob(id, sb)
+	if v.NoRecursive {
+		meta["noRecursive"] = true
+	}
+
+	// Revocations can arrive before the capability itself.
+	revokedAt, err := capabilityRevokeTime(ictx.conn, id, issuer)
+	if err != nil {
+		return err
+	}
+	if revokedAt != 0 {
+		meta["revokedAt"] = revokedAt
+	}
+
+	sb.Meta = meta
+
+	if err := ictx.SaveBlob(id, sb); err != nil {
+		return err
+	}
+
+	// Changes and deletions from the delegate might become valid in the documents
+	// they've already written to, so we need to update their state.
+	return sqlitex.Exec(ictx.conn, qDocumentsWithBlobsByAuthor(), func(stmt *sqlite.Stmt) error {
+		ictx.markDocumentStale(IRI(stmt.ColumnText(0)))
+		return nil
+	}, del, iri)
+}
+
+var qDocumentsWithBlobsByAuthor = dqb.Str(`
+	SELECT DISTINCT r.iri
+	FROM structural_blobs sb
+	JOIN resources r ON r.id = sb.resource
+	WHERE sb.type IN ('Ref', 'Tombstone')
+	AND sb.author = :author
+	AND r.iri BETWEEN :iri AND :iri || '~~~~~~';
+`)
+
+// sqlCanWrite returns the SQL condition that checks whether the author of the blob
+// from the given table had write access to the resource when the blob was created.
+// The condition expects the :iri and :owner named parameters with the resource and its owner.
+func sqlCanWrite(blob string) string {
+	return strings.ReplaceAll(`({{blob}}.author = (SELECT id FROM public_keys WHERE principal = :owner) OR EXISTS (
+		SELECT 1
+		FROM structural_blobs cap
+		JOIN resources cap_res ON cap_res.id = cap.resource
+		WHERE cap.type = 'Capability'
+		AND cap.extra_attrs->>'role' IN ('EDITOR', 'WRITER')
+		AND `+sqlCapabilityHonored("cap", "cap_res", blob)+`
+	))`, "{{blob}}", blob)
+}
+
+// sqlCanComment returns the SQL condition that checks whether the author of the comment blob
+// from the given table was a member of the resource when the comment was created,
+// i.e. whether it's the owner, or the comment was created with a capability honored at the time.
+// The condition expects the :iri and :owner named parameters with the resource and its owner.
+func sqlCanComment(blob string) string {
+	return strings.ReplaceAll(`({{blob}}.author = (SELECT id FROM public_keys WHERE principal = :owner) OR EXISTS (
+		SELECT 1
+		FROM blob_links bl
+		JOIN structural_blobs cap ON cap.id = bl.target AND cap.type = 'Capability'
+		JOIN resources cap_res ON cap_res.id = cap.resource
+		WHERE bl.source = {{blob}}.id
+		AND bl.type = 'comment/capability'
+		AND `+sqlCapabilityHonored("cap", "cap_res", blob)+`
+	))`, "{{blob}}", blob)
+}
+
+// sqlCapabilityHonored returns the SQL condition that checks whether the capability from the given table
+// was honored for the blob from the blob table at the time the blob was created.
+// The res table must be the resource of the capability.
+// Capabilities are honored when they are issued by the owner,
+// or by an editor with a valid capability from the owner for a parent path.
+// Revoked capabilities are only honored for blobs created before the revocation.
+// Non-recursive capabilities only cover their exact path, and so do the capabilities delegated under them.
+func sqlCapabilityHonored(capability, res, blob string) string {
+	return strings.NewReplacer("{{cap}}", capability, "{{res}}", res, "{{blob}}", blob).Replace(`{{cap}}.extra_attrs->>'del' = {{blob}}.author
+		AND ` + sqlCoversIRI(capability, res) + `
+		AND ({{cap}}.extra_attrs->>'revokedAt' IS NULL OR {{blob}}.ts < {{cap}}.extra_attrs->>'revokedAt')
+		AND ({{cap}}.author = (SELECT id FROM public_keys WHERE principal = :owner) OR (
+			{{cap}}.extra_attrs->>'role' IN ('WRITER', 'COLLABORATOR') AND EXISTS (
+				SELECT 1
+				FROM structural_blobs parent
+				JOIN resources parent_res ON parent_res.id = parent.resource
+				WHERE parent.type = 'Capability'
+				AND parent.author = (SELECT id FROM public_keys WHERE principal = :owner)
+				AND parent.extra_attrs->>'del' = {{cap}}.author
+				AND parent.extra_attrs->>'role' = 'EDITOR'
+				AND {{res}}.iri BETWEEN parent_res.iri AND parent_res.iri || '~~~~~~'
+				AND ` + sqlCoversIRI("parent", "parent_res") + `
+				AND (parent.extra_attrs->>'revokedAt' IS NULL OR {{blob}}.ts < parent.extra_attrs->>'revokedAt')
+			)
+		))`)
+}
+
+// sqlCoversIRI returns the SQL condition that checks whether the capability from the given table
+// covers the resource from the :iri named parameter. The res table must be the resource of the capability.
+// Recursive capabilities cover all the subpaths, while non-recursive ones only cover the exact path.
+func sqlCoversIRI(capability, res string) string {
+	return strings.NewReplacer("{{cap}}", capability, "{{res}}", res).Replace(
+		`(:iri = {{res}}.iri OR ({{cap}}.extra_attrs->>'noRecursive' IS NULL AND :iri BETWEEN {{res}}.iri AND {{res}}.iri || '~~~~~~'))`,
+	)
+}
+
+// sqlValidCapability returns the SQL condition that checks whether the capability from the given table
+// is currently valid for the resource from the :iri named parameter, owned by the :owner named parameter.
+// The res table must be the resource of the capability.
+// Capabilities are valid when they are issued by the owner of the resource,
+// or by an editor with a valid capability from the owner for a parent path.
+// Editors can only delegate roles lower than their own.
+// Non-recursive capabilities only cover their exact path.
+func sqlValidCapability(capability, res string) string {
+	return strings.NewReplacer("{{cap}}", capability, "{{res}}", res).Replace(sqlCoversIRI(capability, res) + `
+	AND {{cap}}.extra_attrs->>'revokedAt' IS NULL
+	AND ({{cap}}.author = (SELECT id FROM public_keys WHERE principal = :owner) OR (
+		{{cap}}.extra_attrs->>'role' IN ('WRITER', 'COLLABORATOR') AND EXISTS (
+			SELECT 1
+			FROM structural_blobs parent
+			JOIN resources parent_res ON parent_res.id = parent.resource
+			WHERE parent.type = 'Capability'
+			AND parent.author = (SELECT id FROM public_keys WHERE principal = :owner)
+			AND parent.extra_attrs->>'del' = {{cap}}.author
+			AND parent.extra_attrs->>'role' = 'EDITOR'
+			AND parent.extra_attrs->>'revokedAt' IS NULL
+			AND {{res}}.iri BETWEEN parent_res.iri AND parent_res.iri || '~~~~~~'
+			AND ` + sqlCoversIRI("parent", "parent_res") + `
+		)
+	))`)
 }
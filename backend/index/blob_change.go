@@ -9,7 +9,11 @@ import (
 	documents "seed/backend/genproto/documents/v3alpha"
 	"seed/backend/hlc"
 	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
 	"seed/backend/util/must"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"strings"
 	"time"
 
 	"github.com/ipfs/go-cid"
@@ -27,6 +31,15 @@ func init() {
 
 const blobTypeChange blobType = "Change"
 
+// Types of text content extracted from changes for full-text search.
+const (
+	TextContentBlock    = "block"
+	TextContentMetadata = "metadata"
+)
+
+// TrashNodeID is the parent of deleted blocks in the block tree.
+const TrashNodeID = "◊"
+
 type Change struct {
 	ChangeUnsigned
 	Sig core.Signature `refmt:"sig,omitempty"`
@@ -149,6 +162,11 @@ func indexChange(ictx *indexingCtx, id int64, c cid.Cid, v *Change) error {
 				continue
 			}
 
+			// File links are not useful for full-text search.
+			if !strings.HasPrefix(vs, "ipfs://") {
+				sb.AddTextContent(TextContentMetadata, k, vs)
+			}
+
 			u, err := url.Parse(vs)
 			if err != nil {
 				continue
@@ -188,6 +206,8 @@ func indexChange(ictx *indexingCtx, id int64, c cid.Cid, v *Change) error {
 			}
 			blk.Id = id
 			blk.Revision = c.String()
+			sb.AddTextContent(TextContentBlock, blk.Id, blk.Text)
+
 			if err := indexURL(&sb, ictx.log, blk.Id, "doc/"+blk.Type, blk.Ref); err != nil {
 				return err
 			}
@@ -200,6 +220,29 @@ func indexChange(ictx *indexingCtx, id int64, c cid.Cid, v *Change) error {
 		}
 	}
 
+	// Deleted blocks must not be found by the full-text search anymore,
+	// so we supersede their previous content with an empty text.
+	moves, _ := v.Payload["moves"].(map[string]any)
+	list, _ := moves["#list"].(map[string]any)
+	ins, _ := list["#ins"].([]any)
+	for _, m := range ins {
+		mm, ok := m.(map[string]any)
+		if !ok || mm["p"] != TrashNodeID {
+			continue
+		}
+
+		blk, ok := mm["b"].(string)
+		if !ok {
+			continue
+		}
+
+		if _, ok := blocks[blk]; ok {
+			continue
+		}
+
+		sb.AddTextContent(TextContentBlock, blk, "")
+	}
+
 	index, ok := v.Payload["index"].(map[string]any)
 	if ok {
 		for key, v := range index {
@@ -233,5 +276,24 @@ func indexChange(ictx *indexingCtx, id int64, c cid.Cid, v *Change) error {
 			sb.Meta = meta{Title: title.(string)}
 		}
 	}
-	return ictx.SaveBlob(id, sb)
+
+	if err := ictx.SaveBlob(id, sb); err != nil {
+		return err
+	}
+
+	// Refs could be indexed before their head changes,
+	// so we need to update the state of the documents they point to.
+	return sqlitex.Exec(ictx.conn, qDocumentsWithRefHead(), func(stmt *sqlite.Stmt) error {
+		ictx.markDocumentRefs(IRI(stmt.ColumnText(0)), stmt.ColumnInt64(1))
+		return nil
+	}, id)
 }
+
+var qDocumentsWithRefHead = dqb.Str(`
+	SELECT r.iri, sb.id
+	FROM blob_links bl
+	JOIN structural_blobs sb ON sb.id = bl.source AND sb.type = 'Ref'
+	JOIN resources r ON r.id = sb.resource
+	WHERE bl.target = :change
+	AND bl.type = 'ref/head';
+`)
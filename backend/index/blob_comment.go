@@ -2,15 +2,21 @@ package index
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/url"
 	"seed/backend/core"
 	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
 	"time"
 
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
 	"github.com/multiformats/go-multicodec"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const blobTypeComment blobType = "Comment"
@@ -49,6 +55,30 @@ func NewComment(kp core.KeyPair, cpb cid.Cid, t CommentTarget, threadRoot, reply
 	return encodeBlob(cc)
 }
 
+// NewCommentVersion creates a new version of the original comment with the new body.
+// Versions with a nil body and the deleted flag are tombstones for deleted comments.
+func NewCommentVersion(kp core.KeyPair, original cid.Cid, orig *Comment, body []CommentBlock, deleted bool, ts int64) (eb EncodedBlob[*Comment], err error) {
+	cu := CommentUnsigned{
+		Type:        blobTypeComment,
+		Capability:  orig.Capability,
+		Author:      kp.Principal(),
+		Target:      orig.Target,
+		ThreadRoot:  orig.ThreadRoot,
+		ReplyParent: orig.ReplyParent,
+		Body:        body,
+		Original:    original,
+		Deleted:     deleted,
+		Ts:          ts,
+	}
+
+	cc, err := cu.Sign(kp)
+	if err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(cc)
+}
+
 type CommentUnsigned struct {
 	Type        blobType       `refmt:"@type"`
 	Capability  cid.Cid        `refmt:"capability,omitempty"`
@@ -57,7 +87,12 @@ type CommentUnsigned struct {
 	ThreadRoot  cid.Cid        `refmt:"threadRoot,omitempty"`
 	ReplyParent cid.Cid        `refmt:"replyParent,omitempty"`
 	Body        []CommentBlock `refmt:"body"`
-	Ts          int64          `refmt:"ts"`
+	// Original is the first version of the comment, when this comment is an edit of another one.
+	// Edits always point to the original comment, and the latest edit by the same author wins.
+	Original cid.Cid `refmt:"original,omitempty"`
+	// Deleted marks the comment as deleted by its author.
+	Deleted bool  `refmt:"deleted,omitempty"`
+	Ts      int64 `refmt:"ts"`
 }
 
 func (r *CommentUnsigned) Sign(kp core.KeyPair) (rr *Comment, err error) {
@@ -81,10 +116,30 @@ func (r *CommentUnsigned) Sign(kp core.KeyPair) (rr *Comment, err error) {
 	}, nil
 }
 
+// CommentAuthors filters comments by the authorization of their authors.
+type CommentAuthors int
+
+// Comment author filters.
+const (
+	// CommentAuthorsAll includes all the comments.
+	CommentAuthorsAll CommentAuthors = iota
+	// CommentAuthorsMembers includes comments from the owner of the target,
+	// and from the members commenting with a capability that was valid at the time.
+	CommentAuthorsMembers
+	// CommentAuthorsPublic includes comments from anyone else.
+	CommentAuthorsPublic
+)
+
 type CommentTarget struct {
 	Account core.Principal `refmt:"account"`
 	Path    string         `refmt:"path,omitempty"`
 	Version []cid.Cid      `refmt:"version,omitempty"`
+	// Block is the optional ID of the block the comment is anchored to.
+	Block string `refmt:"block,omitempty"`
+	// Starts and Ends are the optional spans of the block text the comment is anchored to,
+	// in UTF-16 code units, following the same model as annotations.
+	Starts []int32 `refmt:"starts,omitempty"`
+	Ends   []int32 `refmt:"ends,omitempty"`
 }
 
 // Block is a block of text with annotations.
@@ -184,12 +239,18 @@ func indexComment(ictx *indexingCtx, id int64, c cid.Cid, v *Comment) error {
 		return err
 	}
 
-	if threadRoot.Defined() {
-		sb.AddBlobLink("comment/thread-root", threadRoot)
-	}
+	// New versions of comments are not part of the threads on their own,
+	// they replace the content of the original comment.
+	if v.Original.Defined() {
+		sb.AddBlobLink("comment/original", v.Original)
+	} else {
+		if threadRoot.Defined() {
+			sb.AddBlobLink("comment/thread-root", threadRoot)
+		}
 
-	if replyParent.Defined() {
-		sb.AddBlobLink("comment/reply-parent", replyParent)
+		if replyParent.Defined() {
+			sb.AddBlobLink("comment/reply-parent", replyParent)
+		}
 	}
 
 	if v.Capability.Defined() {
@@ -223,3 +284,107 @@ func indexComment(ictx *indexingCtx, id int64, c cid.Cid, v *Comment) error {
 
 	return ictx.SaveBlob(id, sb)
 }
+
+// CommentRecord is a comment resolved to its latest version by the same author.
+type CommentRecord struct {
+	// ID is the database ID of the original comment.
+	ID int64
+	// Version is the CID of the latest version of the comment.
+	// It's the same as the original comment if it was never edited.
+	Version cid.Cid
+	// Comment is the data of the latest version of the comment.
+	Comment *Comment
+	// CreateTime is the timestamp of the original comment.
+	CreateTime time.Time
+}
+
+// IsEdited checks whether the comment has newer versions than the original one.
+func (r CommentRecord) IsEdited() bool {
+	return r.Comment.Original.Defined()
+}
+
+// GetComment returns the comment resolved to its latest version.
+// The CID can be either the original comment or any of its versions.
+func (idx *Index) GetComment(ctx context.Context, c cid.Cid) (original cid.Cid, rec CommentRecord, err error) {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return original, rec, err
+	}
+	defer release()
+
+	var found bool
+	if err := sqlitex.Exec(conn, qGetComment(), func(stmt *sqlite.Stmt) error {
+		original, rec, _, err = idx.scanCommentRecord(stmt, nil)
+		found = true
+		return err
+	}, c.Hash()); err != nil {
+		return original, rec, err
+	}
+
+	if !found {
+		return original, rec, status.Errorf(codes.NotFound, "comment %s not found", c)
+	}
+
+	return original, rec, nil
+}
+
+// sqlCommentRecordColumns are the columns expected by scanCommentRecord,
+// where ob is the blob of the original comment, sb is its structural blob, and lb is the blob of its latest version.
+const sqlCommentRecordColumns = `ob.codec, ob.multihash, sb.ts, lb.codec, lb.multihash, lb.data, sb.id`
+
+// sqlLatestCommentVersion is the SQL expression for the ID of the latest version of the comment in the sb table,
+// only taking into account the versions created by the same author.
+const sqlLatestCommentVersion = `coalesce((
+		SELECT v.id
+		FROM blob_links bl
+		JOIN structural_blobs v ON v.id = bl.source
+		WHERE bl.target = sb.id
+		AND bl.type = 'comment/original'
+		AND v.author = sb.author
+		ORDER BY v.ts DESC
+		LIMIT 1
+	), sb.id)`
+
+var qGetComment = dqb.Q(func() string {
+	return `
+	SELECT ` + sqlCommentRecordColumns + `
+	FROM structural_blobs sb
+	JOIN blobs ob ON ob.id = sb.id
+	JOIN blobs lb ON lb.id = ` + sqlLatestCommentVersion + `
+	WHERE sb.type = 'Comment'
+	AND sb.id = coalesce((
+		SELECT bl.target
+		FROM blobs b
+		JOIN blob_links bl ON bl.source = b.id AND bl.type = 'comment/original'
+		WHERE b.multihash = :hash
+	), (SELECT id FROM blobs WHERE multihash = :hash))
+`
+})
+
+func (idx *Index) scanCommentRecord(stmt *sqlite.Stmt, buf []byte) (original cid.Cid, rec CommentRecord, out []byte, err error) {
+	var (
+		ocodec = stmt.ColumnInt64(0)
+		ohash  = stmt.ColumnBytesUnsafe(1)
+		ots    = stmt.ColumnInt64(2)
+		lcodec = stmt.ColumnInt64(3)
+		lhash  = stmt.ColumnBytesUnsafe(4)
+		ldata  = stmt.ColumnBytesUnsafe(5)
+		id     = stmt.ColumnInt64(6)
+	)
+
+	out, err = idx.bs.decoder.DecodeAll(ldata, buf)
+	if err != nil {
+		return original, rec, out, err
+	}
+
+	original = cid.NewCidV1(uint64(ocodec), ohash)
+	rec.ID = id
+	rec.Version = cid.NewCidV1(uint64(lcodec), lhash)
+	rec.CreateTime = time.UnixMicro(ots)
+	rec.Comment = &Comment{}
+	if err := cbornode.DecodeInto(out, rec.Comment); err != nil {
+		return original, rec, out, fmt.Errorf("failed to decode comment %s: %w", rec.Version, err)
+	}
+
+	return original, rec, out, nil
+}
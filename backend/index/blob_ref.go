@@ -117,5 +117,13 @@ func indexRef(ictx *indexingCtx, id int64, c cid.Cid, v *Ref) error {
 		sb.AddBlobLink("ref/capability", v.Capability)
 	}
 
-	return ictx.SaveBlob(id, sb)
+	if err := ictx.SaveBlob(id, sb); err != nil {
+		return err
+	}
+
+	// Newer changes make previously deleted resources visible again,
+	// which is checked when the document state is updated.
+	ictx.markDocumentRefs(v.Resource, id)
+
+	return nil
 }
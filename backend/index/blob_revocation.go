@@ -0,0 +1,219 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+const blobTypeRevocation blobType = "Revocation"
+
+func init() {
+	cbornode.RegisterCborType(Revocation{})
+	cbornode.RegisterCborType(RevocationUnsigned{})
+}
+
+// Revocation is a signed record that invalidates a previously issued capability.
+// Blobs created by the delegate after the revocation timestamp are no longer honored.
+// Only the issuer of the capability can revoke it.
+type Revocation struct {
+	RevocationUnsigned
+	Sig core.Signature `refmt:"sig,omitempty"`
+}
+
+// RevocationUnsigned holds the unsigned fields of the revocation.
+// Account and path of the revoked capability are included to sync the revocation
+// along with the rest of the blobs of the resource.
+type RevocationUnsigned struct {
+	Type       blobType       `refmt:"@type"`
+	Capability cid.Cid        `refmt:"capability"`
+	Account    core.Principal `refmt:"account"`
+	Path       string         `refmt:"path,omitempty"`
+	Author     core.Principal `refmt:"author"`
+	Ts         int64          `refmt:"ts"`
+}
+
+// NewRevocation creates a new signed revocation for the capability.
+func NewRevocation(kp core.KeyPair, capc cid.Cid, account core.Principal, path string, ts int64) (eb EncodedBlob[*Revocation], err error) {
+	ru := RevocationUnsigned{
+		Type:       blobTypeRevocation,
+		Capability: capc,
+		Account:    account,
+		Path:       path,
+		Author:     kp.Principal(),
+		Ts:         ts,
+	}
+
+	rr, err := ru.Sign(kp)
+	if err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(rr)
+}
+
+// Sign the revocation with the provided key pair.
+func (r *RevocationUnsigned) Sign(kp core.KeyPair) (rr *Revocation, err error) {
+	if !r.Author.Equal(kp.Principal()) {
+		return nil, fmt.Errorf("author mismatch when signing")
+	}
+
+	data, err := cbornode.DumpObject(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := kp.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Revocation{
+		RevocationUnsigned: *r,
+		Sig:                sig,
+	}, nil
+}
+
+func init() {
+	matcher := makeCBORTypeMatch(blobTypeRevocation)
+
+	registerIndexer(blobTypeRevocation,
+		func(c cid.Cid, data []byte) (*Revocation, error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return nil, errSkipIndexing
+			}
+
+			v := &Revocation{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return nil, err
+			}
+
+			return v, nil
+		},
+		indexRevocation,
+	)
+}
+
+func indexRevocation(ictx *indexingCtx, id int64, c cid.Cid, v *Revocation) error {
+	if !v.Capability.Defined() {
+		return fmt.Errorf("revocation must reference a capability")
+	}
+
+	iri, err := NewIRI(v.Account, v.Path)
+	if err != nil {
+		return err
+	}
+
+	sb := newStructuralBlob(c, string(blobTypeRevocation), v.Author, time.UnixMicro(v.Ts), iri, cid.Undef, v.Account, time.Time{})
+	sb.AddBlobLink("revocation/capability", v.Capability)
+
+	if err := ictx.SaveBlob(id, sb); err != nil {
+		return err
+	}
+
+	author, err := ictx.ensurePubKey(v.Author)
+	if err != nil {
+		return err
+	}
+
+	capID, err := ictx.ensureBlob(v.Capability)
+	if err != nil {
+		return err
+	}
+
+	// The capability might not be indexed yet, in which case
+	// the revocation will be applied when the capability arrives.
+	// The earliest revocation wins if there're multiple ones.
+	if err := sqlitex.Exec(ictx.conn, qCapabilitiesRevoke(), nil, v.Ts, capID, author); err != nil {
+		return err
+	}
+
+	if ictx.conn.Changes() == 0 {
+		return nil
+	}
+
+	// Changes and deletions from the delegate made after the revocation are no longer valid,
+	// so we need to update the state of the affected documents.
+	return sqlitex.Exec(ictx.conn, qDocumentsWithBlobsByRevokedDelegate(), func(stmt *sqlite.Stmt) error {
+		ictx.markDocumentStale(IRI(stmt.ColumnText(0)))
+		return nil
+	}, capID, iri)
+}
+
+var qCapabilitiesRevoke = dqb.Str(`
+	UPDATE structural_blobs
+	SET extra_attrs = jsonb_set(extra_attrs, '$.revokedAt', :ts)
+	WHERE id = :capability
+	AND type = 'Capability'
+	AND author = :author
+	AND (extra_attrs->>'revokedAt' IS NULL OR extra_attrs->>'revokedAt' > :ts);
+`)
+
+var qDocumentsWithBlobsByRevokedDelegate = dqb.Str(`
+	SELECT DISTINCT r.iri
+	FROM structural_blobs sb
+	JOIN resources r ON r.id = sb.resource
+	WHERE sb.type IN ('Ref', 'Tombstone')
+	AND sb.author = (SELECT extra_attrs->>'del' FROM structural_blobs WHERE id = :capability)
+	AND r.iri BETWEEN :iri AND :iri || '~~~~~~';
+`)
+
+// capabilityRevokeTime returns the timestamp of the earliest revocation for the capability
+// issued by the author of the capability. It returns 0 if the capability is not revoked.
+func capabilityRevokeTime(conn *sqlite.Conn, capID, issuer int64) (ts int64, err error) {
+	if err := sqlitex.Exec(conn, qCapabilityRevokeTime(), func(stmt *sqlite.Stmt) error {
+		ts = stmt.ColumnInt64(0)
+		return nil
+	}, capID, issuer); err != nil {
+		return 0, err
+	}
+
+	return ts, nil
+}
+
+var qCapabilityRevokeTime = dqb.Str(`
+	SELECT min(sb.ts)
+	FROM blob_links bl
+	JOIN structural_blobs sb ON sb.id = bl.source AND sb.type = 'Revocation'
+	WHERE bl.target = :capability
+	AND bl.type = 'revocation/capability'
+	AND sb.author = :issuer;
+`)
+
+// IsCapabilityRevoked checks whether the capability was revoked by its issuer.
+func (idx *Index) IsCapabilityRevoked(ctx context.Context, c cid.Cid) (revoked bool, err error) {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qIsCapabilityRevoked(), func(*sqlite.Stmt) error {
+		revoked = true
+		return nil
+	}, c.Hash()); err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+var qIsCapabilityRevoked = dqb.Str(`
+	SELECT 1
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE b.multihash = :hash
+	AND sb.type = 'Capability'
+	AND sb.extra_attrs->>'revokedAt' IS NOT NULL;
+`)
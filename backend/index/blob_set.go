@@ -0,0 +1,563 @@
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"seed/backend/syncing/rbsr"
+	"seed/backend/util/dqb"
+	"seed/backend/util/must"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"sync"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/ipfs/go-cid"
+)
+
+// BlobSet is a sealed rbsr.Store with the blobs from the rbsr_blobs table.
+// Sets are cached in memory and shared between the reconciliation sessions,
+// and they are extended with the new blobs as they arrive, so they must not be modified.
+//
+// The sets are not read from the rbsr_blobs table directly,
+// because the reconciliation needs a stable snapshot of the blobs for the whole session,
+// and it accesses the items by their position, which the database can't do without scanning.
+// To bound the memory, only the filtered sets index their blobs by CID,
+// and the cache of the filtered sets is limited by the total number of blobs.
+type BlobSet struct {
+	rbsr.Store
+
+	db *sqlitex.Pool
+
+	// Shared between the sets extended from one another.
+	// It may have the blobs of the later sets, so it must be checked against the store.
+	// Only the filtered sets have it.
+	index *blobSetIndex
+
+	// Number of blobs by resource. Only known for the filtered sets.
+	resources map[string]int
+}
+
+type blobSetIndex struct {
+	mu      sync.RWMutex
+	entries map[string]blobSetEntry // By CID.
+}
+
+type blobSetEntry struct {
+	TS  int64
+	IRI string
+}
+
+// Has checks whether the blob is in the set.
+// The sets that are not filtered look up the timestamp of the blob in the database,
+// so the blobs removed from the database after loading the set are not found anymore.
+func (s *BlobSet) Has(ctx context.Context, c cid.Cid) (bool, error) {
+	if s.index != nil {
+		_, ok := s.Resource(c)
+		return ok, nil
+	}
+
+	conn, release, err := s.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	var (
+		ts    int64
+		found bool
+	)
+	if err := sqlitex.Exec(conn, qBlobSetLookup(), func(stmt *sqlite.Stmt) error {
+		ts, found = stmt.ColumnInt64(0), true
+		return nil
+	}, c.Hash()); err != nil {
+		return false, err
+	}
+	if !found {
+		return false, nil
+	}
+
+	return s.contains(rbsr.NewItem(ts, c.Bytes())), nil
+}
+
+var qBlobSetLookup = dqb.Str(`
+	SELECT rbsr_blobs.ts
+	FROM blobs
+	JOIN rbsr_blobs ON rbsr_blobs.blob = blobs.id
+	WHERE blobs.multihash = :multihash
+	LIMIT 1;
+`)
+
+// Resource returns the IRI of the resource the blob was selected for, if the blob is in the set.
+// Only the filtered sets know the resources of their blobs, so it always returns false for the others.
+func (s *BlobSet) Resource(c cid.Cid) (iri string, ok bool) {
+	if s.index == nil {
+		return "", false
+	}
+
+	s.index.mu.RLock()
+	entry, ok := s.index.entries[c.KeyString()]
+	s.index.mu.RUnlock()
+	if !ok {
+		return "", false
+	}
+
+	if !s.contains(rbsr.NewItem(entry.TS, c.Bytes())) {
+		return "", false
+	}
+
+	return entry.IRI, true
+}
+
+func (s *BlobSet) contains(item rbsr.Item) (ok bool) {
+	i, err := s.FindLowerBound(0, item)
+	if err != nil || i >= s.Size() {
+		return false
+	}
+
+	if err := s.ForEach(i, i+1, func(_ int, other rbsr.Item) bool {
+		ok = other.Cmp(item) == 0
+		return false
+	}); err != nil {
+		return false
+	}
+
+	return ok
+}
+
+// Resources returns the number of blobs in the set by the resource they were selected for.
+// It's nil for the sets that are not filtered. The returned map must not be modified.
+func (s *BlobSet) Resources() map[string]int {
+	return s.resources
+}
+
+func newBlobSet(db *sqlitex.Pool, items []rbsr.Item, iris []string, filtered bool) (*BlobSet, error) {
+	set := &BlobSet{
+		Store: rbsr.NewSliceStore(),
+		db:    db,
+	}
+	if filtered {
+		set.index = &blobSetIndex{entries: make(map[string]blobSetEntry, len(items))}
+		set.resources = make(map[string]int)
+	}
+
+	for _, item := range items {
+		if err := set.Store.Insert(item.Timestamp, item.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := set.Store.Seal(); err != nil {
+		return nil, err
+	}
+
+	set.index.add(items, iris)
+	set.countResources(items, iris)
+
+	return set, nil
+}
+
+// extend returns a new set with the items added to the ones of this set.
+// If the set is filtered, the items must not be in the set already.
+func (s *BlobSet) extend(items []rbsr.Item, iris []string) (*BlobSet, error) {
+	store, err := rbsr.Extend(s.Store, items)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &BlobSet{
+		Store:     store,
+		db:        s.db,
+		index:     s.index,
+		resources: maps.Clone(s.resources),
+	}
+	out.index.add(items, iris)
+	out.countResources(items, iris)
+
+	return out, nil
+}
+
+func (idx *blobSetIndex) add(items []rbsr.Item, iris []string) {
+	if idx == nil {
+		return
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for i, item := range items {
+		idx.entries[string(item.Value)] = blobSetEntry{TS: item.Timestamp, IRI: iris[i]}
+	}
+}
+
+func (s *BlobSet) countResources(items []rbsr.Item, iris []string) {
+	if s.resources == nil {
+		return
+	}
+
+	for i := range items {
+		s.resources[iris[i]]++
+	}
+}
+
+// BlobSet returns the set of blobs to reconcile with other peers when syncing everything.
+// If includeDeleted is true, the set also includes the blobs of the locally deleted resources,
+// which we pretend to have to avoid fetching them again.
+func (idx *Index) BlobSet(ctx context.Context, includeDeleted bool) (*BlobSet, error) {
+	return idx.bs.blobSets.get(ctx, idx.db, includeDeleted)
+}
+
+// BlobSetListFunc must call fn for every blob of a filtered set,
+// along with the IRI of the resource the blob was selected for.
+// Each blob must be reported only once.
+type BlobSetListFunc func(conn *sqlite.Conn, fn func(c cid.Cid, ts int64, iri string) error) error
+
+// FilteredBlobSet returns the set of blobs selected by the list function.
+// Sets are cached by key, so the function must always select the same blobs for the same key.
+// After blobs change, the cached set is extended with the newly selected blobs,
+// unless some of its blobs are not selected anymore.
+func (idx *Index) FilteredBlobSet(ctx context.Context, key string, list BlobSetListFunc) (*BlobSet, error) {
+	return idx.bs.blobSets.getFiltered(ctx, idx.db, key, list)
+}
+
+const (
+	// maxPendingBlobs is the number of blobs to add to a cached set at most.
+	// Sets with more blobs to add are loaded from scratch.
+	maxPendingBlobs = 50000
+
+	// filteredBlobSetsCacheSize is the number of filtered sets to keep in memory.
+	filteredBlobSetsCacheSize = 64
+
+	// maxFilteredBlobSetsItems is the total number of blobs to keep in the cached filtered sets.
+	// The least recently used sets are evicted beyond that, except the one that was just loaded.
+	maxFilteredBlobSetsItems = 1 << 20
+)
+
+// blobSetCache holds the latest loaded blob sets.
+// Callers must report the changes to the rbsr_blobs table after committing them:
+// new blobs with added, and anything else with reset.
+// Sets are loaded from scratch on the next use after a reset,
+// which only happens when blobs or resources are deleted locally, or after reindexing.
+type blobSetCache struct {
+	// version changes on every change to the rbsr_blobs table.
+	version atomic.Uint64
+
+	mu       sync.Mutex
+	resets   uint64
+	pending  [2][]int64 // IDs of the blobs to add to the cached sets.
+	overflow [2]bool    // Set when there're too many pending blobs.
+	filtered *lru.Cache[string, *filteredBlobSet]
+
+	// Total number of blobs to keep in the cached filtered sets.
+	maxFilteredItems int64
+
+	// Held while loading to avoid loading the same set concurrently.
+	loadMu sync.Mutex
+	sets   [2]cachedBlobSet // Indexed by includeDeleted.
+}
+
+type cachedBlobSet struct {
+	resets uint64
+	set    *BlobSet
+}
+
+type filteredBlobSet struct {
+	mu      sync.Mutex
+	version uint64
+	set     *BlobSet
+
+	// Number of blobs in the set, readable without holding the lock.
+	size atomic.Int64
+}
+
+func newBlobSetCache() *blobSetCache {
+	return &blobSetCache{
+		filtered:         must.Do2(lru.New[string, *filteredBlobSet](filteredBlobSetsCacheSize)),
+		maxFilteredItems: maxFilteredBlobSetsItems,
+	}
+}
+
+// added must be called after committing new blobs to the rbsr_blobs table.
+func (c *blobSetCache) added(ids ...int64) {
+	c.mu.Lock()
+	for i := range c.pending {
+		if c.overflow[i] {
+			continue
+		}
+		if len(c.pending[i])+len(ids) > maxPendingBlobs {
+			c.pending[i], c.overflow[i] = nil, true
+			continue
+		}
+		c.pending[i] = append(c.pending[i], ids...)
+	}
+	c.mu.Unlock()
+
+	c.version.Add(1)
+}
+
+// reset must be called after committing any other changes to the rbsr_blobs table.
+func (c *blobSetCache) reset() {
+	c.mu.Lock()
+	c.resets++
+	c.pending = [2][]int64{}
+	c.overflow = [2]bool{}
+	c.mu.Unlock()
+
+	c.version.Add(1)
+}
+
+func (c *blobSetCache) get(ctx context.Context, db *sqlitex.Pool, includeDeleted bool) (*BlobSet, error) {
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	var i int
+	if includeDeleted {
+		i = 1
+	}
+
+	// Taking the pending blobs before loading, so if the table changes while we load,
+	// the changes are applied next time. Blobs that are added twice are ignored.
+	c.mu.Lock()
+	resets := c.resets
+	pending, overflow := c.pending[i], c.overflow[i]
+	c.pending[i], c.overflow[i] = nil, false
+	c.mu.Unlock()
+
+	cached := c.sets[i]
+	stale := cached.set == nil || cached.resets != resets || overflow
+	if !stale && len(pending) == 0 {
+		return cached.set, nil
+	}
+
+	// The pending blobs are lost if we fail, so the set must be loaded from scratch next time.
+	c.sets[i] = cachedBlobSet{}
+
+	conn, release, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var set *BlobSet
+	if stale {
+		set, err = loadBlobSet(db, conn, includeDeleted)
+	} else {
+		set, err = extendBlobSet(conn, cached.set, pending, includeDeleted)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.sets[i] = cachedBlobSet{resets: resets, set: set}
+	return set, nil
+}
+
+func loadBlobSet(db *sqlitex.Pool, conn *sqlite.Conn, includeDeleted bool) (*BlobSet, error) {
+	var items []rbsr.Item
+	if err := sqlitex.Exec(conn, qListBlobSet(), func(stmt *sqlite.Stmt) error {
+		items = append(items, rbsr.NewItem(stmt.ColumnInt64(0), stmt.ColumnBytes(1)))
+		return nil
+	}, includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return newBlobSet(db, items, nil, false)
+}
+
+// Items are read in the primary key order, so sealing the store doesn't need to do much.
+var qListBlobSet = dqb.Str(`
+	SELECT ts, cid
+	FROM rbsr_blobs
+	WHERE :include_deleted OR NOT deleted
+	ORDER BY ts, cid;
+`)
+
+func extendBlobSet(conn *sqlite.Conn, set *BlobSet, ids []int64, includeDeleted bool) (*BlobSet, error) {
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []rbsr.Item
+	if err := sqlitex.Exec(conn, qListBlobSetItems(), func(stmt *sqlite.Stmt) error {
+		items = append(items, rbsr.NewItem(stmt.ColumnInt64(0), stmt.ColumnBytes(1)))
+		return nil
+	}, string(data), includeDeleted); err != nil {
+		return nil, err
+	}
+
+	return set.extend(items, nil)
+}
+
+var qListBlobSetItems = dqb.Str(`
+	SELECT ts, cid
+	FROM rbsr_blobs
+	WHERE blob IN (SELECT value FROM json_each(:blobs))
+	AND (:include_deleted OR NOT deleted);
+`)
+
+func (c *blobSetCache) getFiltered(ctx context.Context, db *sqlitex.Pool, key string, list BlobSetListFunc) (*BlobSet, error) {
+	c.mu.Lock()
+	fs, ok := c.filtered.Get(key)
+	if !ok {
+		fs = &filteredBlobSet{}
+		c.filtered.Add(key, fs)
+	}
+	c.mu.Unlock()
+
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	// Reading the version before listing, so if the table changes while we list,
+	// the set is updated again next time.
+	version := c.version.Load()
+	if fs.set != nil && fs.version == version {
+		return fs.set, nil
+	}
+
+	conn, release, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var (
+		items []rbsr.Item
+		iris  []string
+		known []bool
+		old   int
+	)
+	if err := list(conn, func(c cid.Cid, ts int64, iri string) error {
+		item := rbsr.NewItem(ts, c.Bytes())
+		ok := fs.set != nil && fs.set.contains(item)
+		if ok {
+			old++
+		}
+		items = append(items, item)
+		iris = append(iris, iri)
+		known = append(known, ok)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var set *BlobSet
+	if fs.set != nil && old == fs.set.Size() {
+		var newItems []rbsr.Item
+		var newIRIs []string
+		for i := range items {
+			if !known[i] {
+				newItems = append(newItems, items[i])
+				newIRIs = append(newIRIs, iris[i])
+			}
+		}
+		set, err = fs.set.extend(newItems, newIRIs)
+	} else {
+		// Some blobs are not selected anymore, so the set is built from scratch.
+		set, err = newBlobSet(db, items, iris, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fs.set, fs.version = set, version
+	fs.size.Store(int64(set.Size()))
+
+	c.mu.Lock()
+	c.evictFiltered(key)
+	c.mu.Unlock()
+
+	return set, nil
+}
+
+// evictFiltered removes the least recently used filtered sets while they have too many blobs in total.
+// The set with the key is never removed. Must be called with mu held.
+func (c *blobSetCache) evictFiltered(key string) {
+	var total int64
+	for _, fs := range c.filtered.Values() {
+		total += fs.size.Load()
+	}
+
+	// Keys are ordered from the oldest to the newest.
+	for _, k := range c.filtered.Keys() {
+		if total <= c.maxFilteredItems {
+			return
+		}
+		if k == key {
+			continue
+		}
+
+		fs, ok := c.filtered.Peek(k)
+		if !ok {
+			continue
+		}
+		c.filtered.Remove(k)
+		total -= fs.size.Load()
+	}
+}
+
+// blobSetInsert adds the stored blob to the RBSR blob set.
+func blobSetInsert(conn *sqlite.Conn, id int64, c cid.Cid) error {
+	return sqlitex.Exec(conn, qBlobSetInsert(), nil, c.Bytes(), id)
+}
+
+var qBlobSetInsert = dqb.Str(`
+	INSERT INTO rbsr_blobs (ts, cid, blob)
+	SELECT insert_time, :cid, id
+	FROM blobs
+	WHERE id = :id
+	ON CONFLICT DO NOTHING;
+`)
+
+// rebuildBlobSet populates the RBSR blob set from scratch.
+func rebuildBlobSet(conn *sqlite.Conn) error {
+	if err := sqlitex.ExecTransient(conn, "DELETE FROM rbsr_blobs", nil); err != nil {
+		return err
+	}
+
+	type blobSetItem struct {
+		ID      int64
+		TS      int64
+		CID     cid.Cid
+		Deleted bool
+	}
+
+	var items []blobSetItem
+	if err := sqlitex.Exec(conn, qListBlobSetSources(), func(stmt *sqlite.Stmt) error {
+		items = append(items, blobSetItem{
+			ID:      stmt.ColumnInt64(0),
+			CID:     cid.NewCidV1(uint64(stmt.ColumnInt64(1)), stmt.ColumnBytes(2)),
+			TS:      stmt.ColumnInt64(3),
+			Deleted: stmt.ColumnInt(4) != 0,
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := sqlitex.Exec(conn, qBlobSetInsertFull(), nil, item.TS, item.CID.Bytes(), item.ID, item.Deleted); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var qListBlobSetSources = dqb.Str(`
+	SELECT
+		blobs.id,
+		blobs.codec,
+		blobs.multihash,
+		blobs.insert_time,
+		blobs.id IN (SELECT blob FROM deleted_blobs)
+	FROM blobs INDEXED BY blobs_metadata
+	WHERE blobs.size >= 0
+	OR blobs.id IN (SELECT blob FROM deleted_blobs);
+`)
+
+var qBlobSetInsertFull = dqb.Str(`
+	INSERT INTO rbsr_blobs (ts, cid, blob, deleted)
+	VALUES (:ts, :cid, :blob, :deleted);
+`)
@@ -0,0 +1,307 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"iter"
+	"seed/backend/core"
+	"seed/backend/crdt2"
+	"seed/backend/hlc"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+const blobTypeSnapshot blobType = "Snapshot"
+
+func init() {
+	cbornode.RegisterCborType(Snapshot{})
+	cbornode.RegisterCborType(SnapshotUnsigned{})
+	cbornode.RegisterCborType(SnapshotNode{})
+	cbornode.RegisterCborType(SnapshotMove{})
+}
+
+// Snapshot is a signed checkpoint of the CRDT state of a resource at a given set of heads.
+// It allows loading the resource without replaying the whole history of changes.
+// The state can be verified by recomputing it from the changes up to the heads.
+// Snapshots received from other peers are only trusted after such verification.
+type Snapshot struct {
+	SnapshotUnsigned
+	Sig core.Signature `refmt:"sig,omitempty"`
+}
+
+// NewSnapshot creates a new signed snapshot.
+func NewSnapshot(kp core.KeyPair, su SnapshotUnsigned, ts int64) (eb EncodedBlob[*Snapshot], err error) {
+	su.Type = blobTypeSnapshot
+	su.Author = kp.Principal()
+	su.Ts = ts
+
+	ss, err := su.Sign(kp)
+	if err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(ss)
+}
+
+// SnapshotUnsigned holds the unsigned fields of the snapshot.
+type SnapshotUnsigned struct {
+	Type        blobType  `refmt:"@type"`
+	Resource    IRI       `refmt:"resource"`
+	GenesisBlob cid.Cid   `refmt:"genesisBlob"`
+	Heads       []cid.Cid `refmt:"heads"`
+	// State is the compacted state of the CRDT map.
+	// It includes the move operations the block tree CRDT is built from.
+	State []SnapshotNode `refmt:"state"`
+	// Tree is the state of the block tree CRDT, i.e. the integrated move operations,
+	// so that the moves don't have to be integrated one by one when loading the resource.
+	Tree []SnapshotMove `refmt:"tree,omitempty"`
+	// Origins are the changes that produced the values in the state.
+	Origins    []cid.Cid        `refmt:"origins,omitempty"`
+	Authors    []core.Principal `refmt:"authors"`
+	NumChanges int              `refmt:"numChanges"`
+	CreateTime int64            `refmt:"createTime"`
+	UpdateTime int64            `refmt:"updateTime"`
+	Author     core.Principal   `refmt:"author"`
+	Ts         int64            `refmt:"ts"`
+}
+
+// Sign the snapshot with the provided key pair.
+func (s *SnapshotUnsigned) Sign(kp core.KeyPair) (ss *Snapshot, err error) {
+	if !s.Author.Equal(kp.Principal()) {
+		return nil, fmt.Errorf("author mismatch when signing")
+	}
+
+	data, err := cbornode.DumpObject(s)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := kp.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{
+		SnapshotUnsigned: *s,
+		Sig:              sig,
+	}, nil
+}
+
+// SnapshotNode is a single node of the CRDT map state.
+type SnapshotNode struct {
+	Path   []string `refmt:"p"`
+	Time   int64    `refmt:"t"`
+	Origin string   `refmt:"o"`
+	Kind   byte     `refmt:"k,omitempty"`
+	Value  any      `refmt:"v"`
+}
+
+// SnapshotMove is a move operation integrated into the block tree CRDT.
+type SnapshotMove struct {
+	Block      string `refmt:"b"`
+	Parent     string `refmt:"p"`
+	Left       string `refmt:"l,omitempty"`
+	LeftOrigin string `refmt:"lo,omitempty"`
+	Origin     string `refmt:"o"`
+	Ts         int64  `refmt:"t"`
+	Idx        int    `refmt:"i,omitempty"`
+	Fracdex    string `refmt:"f"`
+}
+
+// NewSnapshotState converts the CRDT map nodes into snapshot nodes.
+func NewSnapshotState(nodes []crdt2.Node) []SnapshotNode {
+	out := make([]SnapshotNode, len(nodes))
+	for i, n := range nodes {
+		out[i] = SnapshotNode(n)
+	}
+	return out
+}
+
+// MapNodes converts the snapshot state back into CRDT map nodes.
+func (s *SnapshotUnsigned) MapNodes() []crdt2.Node {
+	out := make([]crdt2.Node, len(s.State))
+	for i, n := range s.State {
+		out[i] = crdt2.Node(n)
+	}
+	return out
+}
+
+func init() {
+	matcher := makeCBORTypeMatch(blobTypeSnapshot)
+
+	registerIndexer(blobTypeSnapshot,
+		func(c cid.Cid, data []byte) (*Snapshot, error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return nil, errSkipIndexing
+			}
+
+			v := &Snapshot{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return nil, err
+			}
+
+			return v, nil
+		},
+		indexSnapshot,
+	)
+}
+
+func indexSnapshot(ictx *indexingCtx, id int64, c cid.Cid, v *Snapshot) error {
+	if len(v.Heads) == 0 {
+		return fmt.Errorf("snapshot blob must have heads")
+	}
+
+	sb := newStructuralBlob(c, string(blobTypeSnapshot), v.Author, hlc.Timestamp(v.Ts).Time(), v.Resource, v.GenesisBlob, nil, time.Time{})
+	for _, head := range v.Heads {
+		sb.AddBlobLink("snapshot/head", head)
+	}
+
+	return ictx.SaveBlob(id, sb)
+}
+
+// LatestSnapshot returns the most recent snapshot of the resource,
+// created either by the author or by any of its delegates.
+// Snapshots that failed the verification are skipped.
+// The verified flag reports whether the snapshot has been checked against the changes it was created from,
+// see MarkSnapshotVerified.
+// It returns an undefined CID if there's no snapshot.
+func (idx *Index) LatestSnapshot(ctx context.Context, resource IRI, author core.Principal) (c cid.Cid, snap *Snapshot, verified bool, err error) {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return c, nil, false, err
+	}
+	defer release()
+
+	var buf []byte
+	if err := sqlitex.Exec(conn, qLatestSnapshot(), func(stmt *sqlite.Stmt) error {
+		var (
+			codec = stmt.ColumnInt64(0)
+			hash  = stmt.ColumnBytesUnsafe(1)
+			data  = stmt.ColumnBytesUnsafe(2)
+		)
+		verified = stmt.ColumnInt(3) == 1
+
+		buf, err = idx.bs.decoder.DecodeAll(data, buf)
+		if err != nil {
+			return err
+		}
+
+		c = cid.NewCidV1(uint64(codec), hash)
+		snap = &Snapshot{}
+		if err := cbornode.DecodeInto(buf, snap); err != nil {
+			return fmt.Errorf("failed to decode snapshot %s: %w", c, err)
+		}
+
+		return nil
+	}, resource, author); err != nil {
+		return cid.Undef, nil, false, err
+	}
+
+	return c, snap, verified, nil
+}
+
+var qLatestSnapshot = dqb.Q(func() string {
+	return `
+	SELECT
+		b.codec,
+		b.multihash,
+		b.data,
+		sb.extra_attrs->>'verified' IS 1
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.type = 'Snapshot'
+	-- resource
+	AND sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	-- author
+	AND ` + sqlCanWrite("sb") + `
+	-- skipping invalid snapshots
+	AND sb.extra_attrs->>'verified' IS NOT 0
+	ORDER BY sb.ts DESC
+	LIMIT 1;
+`
+})
+
+// MarkSnapshotVerified records the result of checking the snapshot
+// against the changes it was created from.
+// Invalid snapshots are never returned by LatestSnapshot.
+func (idx *Index) MarkSnapshotVerified(ctx context.Context, c cid.Cid, valid bool) error {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	return sqlitex.Exec(conn, qMarkSnapshotVerified(), nil, valid, c.Hash())
+}
+
+var qMarkSnapshotVerified = dqb.Str(`
+	UPDATE structural_blobs
+	SET extra_attrs = jsonb_set(coalesce(extra_attrs, '{}'), '$.verified', :valid)
+	WHERE id = (SELECT id FROM blobs WHERE multihash = :snapshot)
+	AND type = 'Snapshot';
+`)
+
+// IterChangesSince is like IterChanges, but skips the changes that are already covered by the snapshot,
+// i.e. the heads of the snapshot and all of their transitive dependencies.
+func (idx *Index) IterChangesSince(ctx context.Context, resource IRI, author core.Principal, snapshot cid.Cid) (it iter.Seq2[int, ChangeRecord], check func() error) {
+	return idx.iterChanges(ctx, qIterChangesSince(), snapshot.Hash(), resource, author)
+}
+
+var qIterChangesSince = dqb.Q(func() string {
+	return `
+	WITH RECURSIVE
+	covered (id) AS (
+		SELECT bl.target
+		FROM blob_links bl
+		WHERE bl.source = (SELECT id FROM blobs WHERE multihash = :snapshot)
+		AND bl.type = 'snapshot/head'
+
+		UNION
+
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN covered c ON c.id = bl.source
+		WHERE bl.type = 'change/dep'
+	),
+	refs (id) AS (
+		SELECT id
+		FROM structural_blobs
+		WHERE type = 'Ref'
+		-- resource
+		AND resource = (SELECT id FROM resources WHERE iri = :iri)
+		-- author
+		AND ` + sqlCanWrite("structural_blobs") + `
+	),
+	changes (id) AS (
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN refs r ON r.id = bl.source AND bl.type = 'ref/head'
+		WHERE bl.target NOT IN covered
+
+		UNION
+
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN changes c ON c.id = bl.source
+		WHERE bl.type = 'change/dep'
+		AND bl.target NOT IN covered
+	)
+	SELECT
+		codec,
+		multihash,
+		data
+	FROM blobs b
+	JOIN structural_blobs sb ON sb.id = b.id
+	JOIN changes c ON c.id = b.id
+	ORDER BY sb.ts
+`
+})
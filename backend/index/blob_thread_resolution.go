@@ -0,0 +1,172 @@
+package index
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"seed/backend/core"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+const blobTypeThreadResolution blobType = "ThreadResolution"
+
+func init() {
+	cbornode.RegisterCborType(ThreadResolution{})
+	cbornode.RegisterCborType(ThreadResolutionUnsigned{})
+}
+
+// ThreadResolution is a signed record that marks a comment thread as resolved or reopened.
+// Only resolutions from the owner of the target document or from the author of the thread are honored,
+// and the latest honored resolution determines the state of the thread.
+type ThreadResolution struct {
+	ThreadResolutionUnsigned
+	Sig core.Signature `refmt:"sig,omitempty"`
+}
+
+// ThreadResolutionUnsigned holds the unsigned fields of the thread resolution.
+type ThreadResolutionUnsigned struct {
+	Type       blobType       `refmt:"@type"`
+	ThreadRoot cid.Cid        `refmt:"threadRoot"`
+	Account    core.Principal `refmt:"account"`
+	Path       string         `refmt:"path,omitempty"`
+	Resolved   bool           `refmt:"resolved"`
+	Author     core.Principal `refmt:"author"`
+	Ts         int64          `refmt:"ts"`
+}
+
+// NewThreadResolution creates a new signed resolution for the thread with the given root comment.
+// Account and path must be the target of the root comment.
+func NewThreadResolution(kp core.KeyPair, threadRoot cid.Cid, account core.Principal, path string, resolved bool, ts int64) (eb EncodedBlob[*ThreadResolution], err error) {
+	tu := ThreadResolutionUnsigned{
+		Type:       blobTypeThreadResolution,
+		ThreadRoot: threadRoot,
+		Account:    account,
+		Path:       path,
+		Resolved:   resolved,
+		Author:     kp.Principal(),
+		Ts:         ts,
+	}
+
+	tr, err := tu.Sign(kp)
+	if err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(tr)
+}
+
+// Sign the thread resolution with the provided key pair.
+func (t *ThreadResolutionUnsigned) Sign(kp core.KeyPair) (tr *ThreadResolution, err error) {
+	if !t.Author.Equal(kp.Principal()) {
+		return nil, fmt.Errorf("author mismatch when signing")
+	}
+
+	data, err := cbornode.DumpObject(t)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := kp.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ThreadResolution{
+		ThreadResolutionUnsigned: *t,
+		Sig:                      sig,
+	}, nil
+}
+
+func init() {
+	matcher := makeCBORTypeMatch(blobTypeThreadResolution)
+
+	registerIndexer(blobTypeThreadResolution,
+		func(c cid.Cid, data []byte) (*ThreadResolution, error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return nil, errSkipIndexing
+			}
+
+			v := &ThreadResolution{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return nil, err
+			}
+
+			return v, nil
+		},
+		indexThreadResolution,
+	)
+}
+
+func indexThreadResolution(ictx *indexingCtx, id int64, c cid.Cid, v *ThreadResolution) error {
+	if !v.ThreadRoot.Defined() {
+		return fmt.Errorf("thread resolution must reference the thread root")
+	}
+
+	iri, err := NewIRI(v.Account, v.Path)
+	if err != nil {
+		return err
+	}
+
+	sb := newStructuralBlob(c, string(blobTypeThreadResolution), v.Author, time.UnixMicro(v.Ts), iri, cid.Undef, v.Account, time.Time{})
+	sb.AddBlobLink("resolution/thread", v.ThreadRoot)
+	sb.Meta = map[string]any{
+		"resolved": v.Resolved,
+	}
+
+	return ictx.SaveBlob(id, sb)
+}
+
+// ThreadState is the resolution state of a comment thread.
+type ThreadState struct {
+	Resolved bool
+	// Author of the latest resolution. Empty if the thread was never resolved.
+	Author core.Principal
+	// Time of the latest resolution.
+	Time time.Time
+}
+
+// GetThreadState returns the resolution state of the thread with the given root comment,
+// taking into account only the resolutions from the owner of the target document or the author of the thread.
+func (idx *Index) GetThreadState(ctx context.Context, threadRoot cid.Cid, owner core.Principal) (state ThreadState, err error) {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return state, err
+	}
+	defer release()
+
+	if err := sqlitex.Exec(conn, qGetThreadState(), func(stmt *sqlite.Stmt) error {
+		state.Resolved = stmt.ColumnInt(0) == 1
+		state.Author = core.Principal(stmt.ColumnBytes(1))
+		state.Time = time.UnixMicro(stmt.ColumnInt64(2))
+		return nil
+	}, threadRoot.Hash(), owner); err != nil {
+		return state, err
+	}
+
+	return state, nil
+}
+
+var qGetThreadState = dqb.Str(`
+	SELECT
+		sb.extra_attrs->>'resolved',
+		pk.principal,
+		sb.ts
+	FROM blob_links bl
+	JOIN structural_blobs sb ON sb.id = bl.source AND sb.type = 'ThreadResolution'
+	JOIN structural_blobs root ON root.id = bl.target
+	JOIN public_keys pk ON pk.id = sb.author
+	WHERE bl.target = (SELECT id FROM blobs WHERE multihash = :root)
+	AND bl.type = 'resolution/thread'
+	AND (sb.author = root.author OR sb.author = (SELECT id FROM public_keys WHERE principal = :owner))
+	ORDER BY sb.ts DESC
+	LIMIT 1;
+`)
@@ -0,0 +1,253 @@
+package index
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"seed/backend/core"
+	"seed/backend/hlc"
+	"seed/backend/ipfs"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multicodec"
+)
+
+const blobTypeTombstone blobType = "Tombstone"
+
+func init() {
+	cbornode.RegisterCborType(Tombstone{})
+	cbornode.RegisterCborType(TombstoneUnsigned{})
+}
+
+// Tombstone is a signed record that marks a resource as deleted.
+// It's a regular blob, so it gets synced along with the rest of the resource's blobs,
+// making other peers hide the resource as well.
+type Tombstone struct {
+	TombstoneUnsigned
+	Sig core.Signature `refmt:"sig,omitempty"`
+}
+
+// NewTombstone creates a new signed tombstone for the resource.
+func NewTombstone(kp core.KeyPair, rid IRI, capc cid.Cid, ts int64) (eb EncodedBlob[*Tombstone], err error) {
+	tu := TombstoneUnsigned{
+		Type:       blobTypeTombstone,
+		Resource:   rid,
+		Capability: capc,
+		Author:     kp.Principal(),
+		Ts:         ts,
+	}
+
+	tt, err := tu.Sign(kp)
+	if err != nil {
+		return eb, err
+	}
+
+	return encodeBlob(tt)
+}
+
+// TombstoneUnsigned holds the unsigned fields of the tombstone.
+type TombstoneUnsigned struct {
+	Type       blobType       `refmt:"@type"`
+	Resource   IRI            `refmt:"resource"`
+	Capability cid.Cid        `refmt:"capability,omitempty"`
+	Author     core.Principal `refmt:"author"`
+	Ts         int64          `refmt:"ts"`
+}
+
+// Sign the tombstone with the provided key pair.
+func (t *TombstoneUnsigned) Sign(kp core.KeyPair) (tt *Tombstone, err error) {
+	if !t.Author.Equal(kp.Principal()) {
+		return nil, fmt.Errorf("author mismatch when signing")
+	}
+
+	data, err := cbornode.DumpObject(t)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := kp.Sign(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tombstone{
+		TombstoneUnsigned: *t,
+		Sig:               sig,
+	}, nil
+}
+
+func init() {
+	matcher := makeCBORTypeMatch(blobTypeTombstone)
+
+	registerIndexer(blobTypeTombstone,
+		func(c cid.Cid, data []byte) (*Tombstone, error) {
+			codec, _ := ipfs.DecodeCID(c)
+			if codec != multicodec.DagCbor || !bytes.Contains(data, matcher) {
+				return nil, errSkipIndexing
+			}
+
+			v := &Tombstone{}
+			if err := cbornode.DecodeInto(data, v); err != nil {
+				return nil, err
+			}
+
+			return v, nil
+		},
+		indexTombstone,
+	)
+}
+
+// TombstoneMeta is the metadata we store in the deleted_resources table
+// for resources deleted with a tombstone.
+type TombstoneMeta struct {
+	Tombstone string `json:"tombstone"`
+	Ts        int64  `json:"ts"`
+}
+
+func indexTombstone(ictx *indexingCtx, id int64, c cid.Cid, v *Tombstone) error {
+	owner, err := resourceOwner(v.Resource)
+	if err != nil {
+		return fmt.Errorf("invalid tombstone resource %s: %w", v.Resource, err)
+	}
+
+	if !owner.Equal(v.Author) && !v.Capability.Defined() {
+		return fmt.Errorf("tombstone author %s is not allowed to delete %s without a capability", v.Author, v.Resource)
+	}
+
+	ts := hlc.Timestamp(v.Ts).Time()
+
+	sb := newStructuralBlob(c, string(blobTypeTombstone), v.Author, ts, v.Resource, cid.Undef, owner, time.Time{})
+	if v.Capability.Defined() {
+		sb.AddBlobLink("tombstone/capability", v.Capability)
+	}
+
+	if err := ictx.SaveBlob(id, sb); err != nil {
+		return err
+	}
+
+	// The deletion record is updated along with the document state,
+	// which also lets the watchers know about the deletion.
+	// Delegated deletions are only applied once we have the capability that authorizes them.
+	ictx.markDocumentRefs(v.Resource)
+
+	return nil
+}
+
+// resourceOwner returns the owner of the resource from its hm:// IRI.
+func resourceOwner(resource IRI) (core.Principal, error) {
+	u, err := url.Parse(string(resource))
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "hm" {
+		return nil, fmt.Errorf("must be an hm:// IRI")
+	}
+
+	return core.DecodePrincipal(u.Host)
+}
+
+// refreshTombstone updates the deletion record of the resource from its tombstones and refs.
+// Only the blobs from the owner and the authorized writers are taken into account,
+// so nobody else can delete the resource or bring it back.
+// The resource stays deleted while its latest tombstone is not older than its latest ref.
+// Records of the local deletions are left untouched.
+func refreshTombstone(conn *sqlite.Conn, resource IRI, owner core.Principal) error {
+	var (
+		c  cid.Cid
+		ts int64
+	)
+	if err := sqlitex.Exec(conn, qLatestTombstone(), func(stmt *sqlite.Stmt) error {
+		c = cid.NewCidV1(uint64(stmt.ColumnInt64(0)), stmt.ColumnBytes(1))
+		ts = stmt.ColumnInt64(2)
+		return nil
+	}, resource, owner); err != nil {
+		return err
+	}
+
+	if !c.Defined() {
+		return sqlitex.Exec(conn, qDeletedResourcesClearTombstones(), nil, resource)
+	}
+
+	// Blobs can arrive in any order during syncing,
+	// so if we already have some newer changes for this resource
+	// the tombstone is superseded, and the resource should stay visible.
+	var hasNewerRefs bool
+	if err := sqlitex.Exec(conn, qHasRefsAfter(), func(*sqlite.Stmt) error {
+		hasNewerRefs = true
+		return nil
+	}, resource, ts, owner); err != nil {
+		return err
+	}
+
+	if hasNewerRefs {
+		return sqlitex.Exec(conn, qDeletedResourcesClearTombstones(), nil, resource)
+	}
+
+	meta, err := json.Marshal(TombstoneMeta{
+		Tombstone: c.String(),
+		Ts:        ts,
+	})
+	if err != nil {
+		return err
+	}
+
+	return sqlitex.Exec(conn, qDeletedResourcesUpsertTombstone(), nil, resource, time.UnixMicro(ts).Unix(), string(meta))
+}
+
+var qLatestTombstone = dqb.Q(func() string {
+	return `
+	SELECT b.codec, b.multihash, sb.ts
+	FROM structural_blobs sb
+	JOIN blobs b ON b.id = sb.id
+	WHERE sb.type = 'Tombstone'
+	AND sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	AND (sb.author = (SELECT id FROM public_keys WHERE principal = :owner) OR EXISTS (
+		SELECT 1
+		FROM blob_links bl
+		JOIN structural_blobs cap ON cap.id = bl.target AND cap.type = 'Capability'
+		JOIN resources cap_res ON cap_res.id = cap.resource
+		WHERE bl.source = sb.id
+		AND bl.type = 'tombstone/capability'
+		AND cap.extra_attrs->>'role' IN ('EDITOR', 'WRITER')
+		AND ` + sqlCapabilityHonored("cap", "cap_res", "sb") + `
+	))
+	ORDER BY sb.ts DESC
+	LIMIT 1;
+`
+})
+
+var qHasRefsAfter = dqb.Q(func() string {
+	return `
+	SELECT 1
+	FROM structural_blobs
+	WHERE type = 'Ref'
+	AND resource = (SELECT id FROM resources WHERE iri = :iri)
+	AND ts > :ts
+	AND ` + sqlCanWrite("structural_blobs") + `
+	LIMIT 1;
+`
+})
+
+// Only replacing existing records that were created by tombstones,
+// to avoid overwriting resources deleted locally by the user.
+var qDeletedResourcesUpsertTombstone = dqb.Str(`
+	INSERT INTO deleted_resources (iri, delete_time, reason, extra_attrs)
+	VALUES (:iri, :delete_time, 'tombstone', :meta)
+	ON CONFLICT (iri) DO UPDATE SET
+		delete_time = excluded.delete_time,
+		extra_attrs = excluded.extra_attrs
+	WHERE deleted_resources.extra_attrs->>'tombstone' IS NOT NULL;
+`)
+
+var qDeletedResourcesClearTombstones = dqb.Str(`
+	DELETE FROM deleted_resources
+	WHERE iri = :iri
+	AND extra_attrs->>'tombstone' IS NOT NULL;
+`)
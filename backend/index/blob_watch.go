@@ -0,0 +1,48 @@
+package index
+
+import "sync"
+
+// blobWatchers keeps track of the subscribers interested in newly stored blobs.
+type blobWatchers struct {
+	mu   sync.Mutex
+	subs map[chan struct{}]struct{}
+}
+
+// WatchBlobs returns a channel that receives a signal every time
+// the indexer commits new blobs into the database.
+// Like with WatchDocument, signals are coalesced while the subscriber is busy,
+// so subscribers must query the database for everything that appeared since the last time they looked.
+// The returned function must be called to release the subscription.
+func (idx *Index) WatchBlobs() (updates <-chan struct{}, cancel func()) {
+	ch := make(chan struct{}, 1)
+
+	w := &idx.blobWatchers
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.subs == nil {
+		w.subs = make(map[chan struct{}]struct{})
+	}
+	w.subs[ch] = struct{}{}
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.subs, ch)
+	}
+}
+
+// notify the subscribers about new blobs.
+// Must be called after the indexing transaction is committed.
+func (w *blobWatchers) notify() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
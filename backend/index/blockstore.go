@@ -34,6 +34,8 @@ type blockStore struct {
 	db      *sqlitex.Pool
 	encoder *zstd.Encoder
 	decoder *zstd.Decoder
+
+	blobSets *blobSetCache
 }
 
 // newBlockstore creates a new block store from a given connection pool.
@@ -51,9 +53,10 @@ func newBlockstore(db *sqlitex.Pool) *blockStore {
 	}
 
 	return &blockStore{
-		db:      db,
-		encoder: enc,
-		decoder: dec,
+		db:       db,
+		encoder:  enc,
+		decoder:  dec,
+		blobSets: newBlobSetCache(),
 	}
 }
 
@@ -190,30 +193,55 @@ func (b *blockStore) GetSize(ctx context.Context, c cid.Cid) (int, error) {
 func (b *blockStore) Put(ctx context.Context, block blocks.Block) error {
 	mCallsTotal.WithLabelValues("Put").Inc()
 
-	return b.withConn(ctx, func(conn *sqlite.Conn) error {
-		return sqlitex.WithTx(conn, func() error {
+	var (
+		id     int64
+		exists bool
+	)
+	if err := b.withConn(ctx, func(conn *sqlite.Conn) error {
+		return sqlitex.WithTx(conn, func() (err error) {
 			codec, hash := ipfs.DecodeCID(block.Cid())
-			_, _, err := b.putBlock(conn, 0, uint64(codec), hash, block.RawData())
+			id, exists, err = b.putBlock(conn, 0, uint64(codec), hash, block.RawData())
 			return err
 		})
-	})
+	}); err != nil {
+		return err
+	}
+
+	if !exists {
+		b.blobSets.added(id)
+	}
+
+	return nil
 }
 
 // PutMany implements blockstore.Blockstore interface.
 func (b *blockStore) PutMany(ctx context.Context, blocks []blocks.Block) error {
 	mCallsTotal.WithLabelValues("PutMany").Inc()
 
-	return b.withConn(ctx, func(conn *sqlite.Conn) error {
+	var added []int64
+	if err := b.withConn(ctx, func(conn *sqlite.Conn) error {
 		return sqlitex.WithTx(conn, func() error {
 			for _, blk := range blocks {
 				codec, hash := ipfs.DecodeCID(blk.Cid())
-				if _, _, err := b.putBlock(conn, 0, uint64(codec), hash, blk.RawData()); err != nil {
+				id, exists, err := b.putBlock(conn, 0, uint64(codec), hash, blk.RawData())
+				if err != nil {
 					return err
 				}
+				if !exists {
+					added = append(added, id)
+				}
 			}
 			return nil
 		})
-	})
+	}); err != nil {
+		return err
+	}
+
+	if len(added) > 0 {
+		b.blobSets.added(added...)
+	}
+
+	return nil
 }
 
 func (b *blockStore) putBlock(conn *sqlite.Conn, inID int64, codec uint64, hash multihash.Multihash, data []byte) (id int64, exists bool, err error) {
@@ -230,6 +258,15 @@ func (b *blockStore) putBlock(conn *sqlite.Conn, inID int64, codec uint64, hash
 		return size.BlobsID, true, nil
 	// We know about the blob, but we don't have it.
 	case size.BlobsID != 0 && size.BlobsSize < 0:
+		// Blobs of locally deleted resources must not come back,
+		// so we pretend we have them already.
+		deleted, err := isBlobDeleted(conn, size.BlobsID)
+		if err != nil {
+			return 0, false, err
+		}
+		if deleted {
+			return size.BlobsID, true, nil
+		}
 		update = true
 	// We don't have nor know anything about the blob.
 	case size.BlobsID == 0 && size.BlobsSize == 0:
@@ -250,15 +287,26 @@ func (b *blockStore) putBlock(conn *sqlite.Conn, inID int64, codec uint64, hash
 	}
 
 	if update {
-		newID, err := allocateBlobID(conn)
+		id, err = allocateBlobID(conn)
 		if err != nil {
 			return 0, false, err
 		}
-		return newID, false, blobsUpdateMissingData(conn, compressed, int64(len(data)), newID, size.BlobsID)
+		if err := blobsUpdateMissingData(conn, compressed, int64(len(data)), id, size.BlobsID); err != nil {
+			return 0, false, err
+		}
+	} else {
+		id, err = dbBlobsInsert(conn, inID, hash, int64(codec), compressed, int64(len(data)))
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	// Callers must add the blob to the cached blob sets after committing.
+	if err := blobSetInsert(conn, id, cid.NewCidV1(codec, hash)); err != nil {
+		return 0, false, err
 	}
 
-	ins, err := dbBlobsInsert(conn, inID, hash, int64(codec), compressed, int64(len(data)))
-	return ins, false, err
+	return id, false, nil
 }
 
 func allocateBlobID(conn *sqlite.Conn) (int64, error) {
@@ -307,8 +355,12 @@ func (b *blockStore) DeleteBlock(ctx context.Context, c cid.Cid) error {
 	}
 	defer release()
 
-	_, err = b.deleteBlock(conn, c)
-	return err
+	if _, err := b.deleteBlock(conn, c); err != nil {
+		return err
+	}
+
+	b.blobSets.reset()
+	return nil
 }
 
 func (b *blockStore) deleteBlock(conn *sqlite.Conn, c cid.Cid) (oldid int64, err error) {
@@ -8,6 +8,7 @@ import (
 	"seed/backend/util/must"
 	"testing"
 
+	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
 
 	blocks "github.com/ipfs/go-block-format"
@@ -202,6 +203,152 @@ func TestPutMany(t *testing.T) {
 	require.Len(t, cids, 3)
 }
 
+func TestBlobSet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	bs := makeBlockstore(t)
+	keys := insertBlocks(t, bs, 3)
+
+	set, err := bs.blobSets.get(ctx, bs.db, false)
+	require.NoError(t, err)
+	require.Equal(t, 3, set.Size())
+	for _, k := range keys {
+		require.True(t, must.Do2(set.Has(ctx, k)))
+	}
+
+	cached, err := bs.blobSets.get(ctx, bs.db, false)
+	require.NoError(t, err)
+	require.Same(t, set, cached, "set must be reused while nothing changes")
+
+	// Putting the same blocks again doesn't add anything to the set.
+	insertBlocks(t, bs, 4)
+
+	extended, err := bs.blobSets.get(ctx, bs.db, false)
+	require.NoError(t, err)
+	require.NotSame(t, set, extended, "set must be extended after changes")
+	require.Equal(t, 4, extended.Size())
+	require.True(t, must.Do2(extended.Has(ctx, makeCID(t, []byte("some data 3")))))
+	require.False(t, must.Do2(set.Has(ctx, makeCID(t, []byte("some data 3")))), "previous set must not be modified")
+	requireSameBlobSet(t, bs, extended)
+
+	// Sets are loaded from scratch after removing blobs.
+	require.NoError(t, bs.DeleteBlock(ctx, keys[0]))
+	fresh, err := bs.blobSets.get(ctx, bs.db, false)
+	require.NoError(t, err)
+	require.Equal(t, 3, fresh.Size())
+	require.False(t, must.Do2(fresh.Has(ctx, keys[0])))
+	require.Equal(t, 4, extended.Size(), "previous set must not be modified")
+}
+
+func TestFilteredBlobSet(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	bs := makeBlockstore(t)
+	keys := insertBlocks(t, bs, 4)
+
+	// Selecting all the blobs but the excluded one.
+	var calls int
+	excluded := keys[3]
+	list := func(conn *sqlite.Conn, fn func(c cid.Cid, ts int64, iri string) error) error {
+		calls++
+		return sqlitex.Exec(conn, "SELECT ts, cid FROM rbsr_blobs", func(stmt *sqlite.Stmt) error {
+			c := must.Do2(cid.Cast(stmt.ColumnBytes(1)))
+			if c.Equals(excluded) {
+				return nil
+			}
+			return fn(c, stmt.ColumnInt64(0), "hm://"+c.String())
+		})
+	}
+
+	set, err := bs.blobSets.getFiltered(ctx, bs.db, "test", list)
+	require.NoError(t, err)
+	require.Equal(t, 3, set.Size())
+	require.Len(t, set.Resources(), 3)
+	iri, ok := set.Resource(keys[0])
+	require.True(t, ok)
+	require.Equal(t, "hm://"+keys[0].String(), iri)
+
+	cached, err := bs.blobSets.getFiltered(ctx, bs.db, "test", list)
+	require.NoError(t, err)
+	require.Same(t, set, cached, "set must be reused while nothing changes")
+	require.Equal(t, 1, calls)
+
+	// New blobs extend the set.
+	excluded = keys[0]
+	insertBlocks(t, bs, 5)
+	fresh, err := bs.blobSets.getFiltered(ctx, bs.db, "test", list)
+	require.NoError(t, err)
+	require.Equal(t, 4, fresh.Size(), "set must be built from scratch when blobs are not selected anymore")
+	require.False(t, must.Do2(fresh.Has(ctx, keys[0])))
+	require.True(t, must.Do2(set.Has(ctx, keys[0])), "previous set must not be modified")
+
+	excluded = cid.Undef
+	insertBlocks(t, bs, 6)
+	extended, err := bs.blobSets.getFiltered(ctx, bs.db, "test", list)
+	require.NoError(t, err)
+	require.Equal(t, 6, extended.Size())
+	require.Len(t, extended.Resources(), 6)
+	require.True(t, must.Do2(extended.Has(ctx, keys[0])))
+	require.Equal(t, 4, fresh.Size())
+	require.Len(t, fresh.Resources(), 4)
+	require.Equal(t, 3, calls)
+}
+
+func TestFilteredBlobSetEviction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	bs := makeBlockstore(t)
+	keys := insertBlocks(t, bs, 3)
+	bs.blobSets.maxFilteredItems = 4
+
+	// Each set selects the blobs up to the given one.
+	listUpTo := func(n int) BlobSetListFunc {
+		return func(conn *sqlite.Conn, fn func(c cid.Cid, ts int64, iri string) error) error {
+			return sqlitex.Exec(conn, "SELECT ts, cid FROM rbsr_blobs", func(stmt *sqlite.Stmt) error {
+				c := must.Do2(cid.Cast(stmt.ColumnBytes(1)))
+				if slices.IndexFunc(keys[:n], c.Equals) < 0 {
+					return nil
+				}
+				return fn(c, stmt.ColumnInt64(0), "hm://"+c.String())
+			})
+		}
+	}
+
+	_, err := bs.blobSets.getFiltered(ctx, bs.db, "one", listUpTo(1))
+	require.NoError(t, err)
+	_, err = bs.blobSets.getFiltered(ctx, bs.db, "two", listUpTo(2))
+	require.NoError(t, err)
+	require.Equal(t, []string{"one", "two"}, bs.blobSets.filtered.Keys())
+
+	// The oldest sets are evicted when there're too many blobs in total.
+	_, err = bs.blobSets.getFiltered(ctx, bs.db, "three", listUpTo(3))
+	require.NoError(t, err)
+	require.Equal(t, []string{"three"}, bs.blobSets.filtered.Keys())
+
+	// The latest set is kept even if it's too big on its own.
+	bs.blobSets.maxFilteredItems = 1
+	_, err = bs.blobSets.getFiltered(ctx, bs.db, "two", listUpTo(2))
+	require.NoError(t, err)
+	require.Equal(t, []string{"two"}, bs.blobSets.filtered.Keys())
+}
+
+// requireSameBlobSet checks that the set is the same as the one loaded from scratch.
+func requireSameBlobSet(t *testing.T, bs *blockStore, set *BlobSet) {
+	t.Helper()
+
+	conn, release, err := bs.db.Conn(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	want, err := loadBlobSet(bs.db, conn, false)
+	require.NoError(t, err)
+	require.Equal(t, want.Size(), set.Size())
+	require.Equal(t, must.Do2(want.Fingerprint(0, want.Size())), must.Do2(set.Fingerprint(0, set.Size())))
+}
+
 func TestPut_InlineCID(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,462 @@
+package index
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"seed/backend/core"
+	"seed/backend/crdt2"
+	"seed/backend/util/dqb"
+	"slices"
+
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+
+	"github.com/ipfs/go-cid"
+	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/multiformats/go-multibase"
+)
+
+// staleDocument describes what needs to be updated in the materialized state of a document.
+type staleDocument struct {
+	// Rebuild the state from scratch, because some of the changes might not be visible anymore,
+	// or some old changes became visible.
+	rebuild bool
+
+	// New refs whose heads must be applied on top of the current state.
+	refs []int64
+}
+
+// markDocumentStale schedules the rebuild of the materialized document state from all of its changes
+// at the end of the indexing batch. Done this way to avoid replaying the changes
+// multiple times when many blobs of the same document are indexed together.
+func (idx *indexingCtx) markDocumentStale(iri IRI) {
+	idx.staleDoc(iri).rebuild = true
+}
+
+// markDocumentRefs schedules the update of the materialized document state
+// with the changes from the given refs at the end of the indexing batch.
+// Without refs the state stays the same, but the watchers are notified about the document.
+func (idx *indexingCtx) markDocumentRefs(iri IRI, refs ...int64) {
+	sd := idx.staleDoc(iri)
+	sd.refs = append(sd.refs, refs...)
+}
+
+func (idx *indexingCtx) staleDoc(iri IRI) *staleDocument {
+	sd := idx.staleDocs[iri]
+	if sd == nil {
+		sd = &staleDocument{}
+		idx.staleDocs[iri] = sd
+	}
+	return sd
+}
+
+// updateDocumentStates updates the materialized state of all the stale documents.
+func (idx *indexingCtx) updateDocumentStates() error {
+	for iri, sd := range idx.staleDocs {
+		if err := idx.updateDocumentState(iri, sd); err != nil {
+			return fmt.Errorf("failed to update state of document %s: %w", iri, err)
+		}
+		delete(idx.staleDocs, iri)
+		idx.updatedDocs = append(idx.updatedDocs, iri)
+	}
+
+	return nil
+}
+
+// documentState is the materialized state of a document.
+type documentState struct {
+	heads      map[cid.Cid]struct{}
+	authors    map[string]struct{}
+	metadata   *crdt2.Map
+	createTime int64
+	updateTime int64
+	count      int
+
+	// IDs of the changes applied since the state was created or loaded.
+	applied []int64
+}
+
+func newDocumentState() *documentState {
+	return &documentState{
+		heads:    make(map[cid.Cid]struct{}),
+		authors:  make(map[string]struct{}),
+		metadata: crdt2.NewMap(),
+	}
+}
+
+func (ds *documentState) apply(c cid.Cid, ch *Change) {
+	if ds.count == 0 || ch.Ts < ds.createTime {
+		ds.createTime = ch.Ts
+	}
+	ds.updateTime = max(ds.updateTime, ch.Ts)
+	ds.count++
+
+	ds.heads[c] = struct{}{}
+	ds.authors[ch.Author.String()] = struct{}{}
+
+	if md, ok := ch.Payload["metadata"]; ok {
+		ds.metadata.ApplyPatch(ch.Ts, changeOrigin(c), map[string]any{"metadata": md})
+	}
+}
+
+// applyChanges applies the changes returned by the query on top of the state.
+// The query must return the codec, multihash, data, and ID of the changes.
+// It returns the changes that turned out to be the genesis of the document.
+func (idx *indexingCtx) applyChanges(ds *documentState, query string, args ...any) (genesis int, err error) {
+	var deps []cid.Cid
+
+	buf := make([]byte, 0, 1024*1024) // preallocating 1MB for decompression.
+	rows, check := sqlitex.Query(idx.conn, query, args...)
+	for row := range rows {
+		next := sqlite.NewIncrementor(0)
+		var (
+			codec = row.ColumnInt64(next())
+			hash  = row.ColumnBytesUnsafe(next())
+			data  = row.ColumnBytesUnsafe(next())
+			id    = row.ColumnInt64(next())
+		)
+
+		buf, err = idx.decoder.DecodeAll(data, buf[:0])
+		if err != nil {
+			err = errors.Join(err, check())
+			return 0, err
+		}
+
+		chcid := cid.NewCidV1(uint64(codec), hash)
+		ch := &Change{}
+		if err := cbornode.DecodeInto(buf, ch); err != nil {
+			return 0, errors.Join(fmt.Errorf("failed to decode change %s: %w", chcid, err), check())
+		}
+
+		if len(ch.Deps) == 0 {
+			genesis++
+		}
+
+		ds.apply(chcid, ch)
+		ds.applied = append(ds.applied, id)
+		deps = append(deps, ch.Deps...)
+	}
+	if err := check(); err != nil {
+		return 0, err
+	}
+
+	for _, dep := range deps {
+		delete(ds.heads, dep)
+	}
+
+	return genesis, nil
+}
+
+func (idx *indexingCtx) updateDocumentState(iri IRI, sd *staleDocument) error {
+	rid, err := idx.ensureResource(iri)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(string(iri))
+	if err != nil {
+		return err
+	}
+
+	owner, err := core.DecodePrincipal(u.Host)
+	if err != nil {
+		return fmt.Errorf("failed to decode document owner: %w", err)
+	}
+
+	// Tombstones, refs, and capabilities can arrive in any order,
+	// so the deletion record is updated along with the state.
+	if err := refreshTombstone(idx.conn, iri, owner); err != nil {
+		return err
+	}
+
+	if !sd.rebuild {
+		if len(sd.refs) == 0 {
+			return nil
+		}
+
+		ds, ok, err := idx.loadDocumentState(rid)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			updated, err := idx.applyNewRefs(ds, iri, owner, sd.refs)
+			if err != nil {
+				return err
+			}
+
+			if updated {
+				if err := idx.attachChanges(rid, ds.applied); err != nil {
+					return err
+				}
+				return idx.saveDocumentState(rid, ds)
+			}
+		}
+	}
+
+	// Replaying all the changes from scratch.
+	ds := newDocumentState()
+	if _, err := idx.applyChanges(ds, qIterChanges(), iri, owner); err != nil {
+		return err
+	}
+
+	// Some changes might not be visible anymore, so they must not be attributed to the document either.
+	if err := idx.detachChanges(rid); err != nil {
+		return err
+	}
+
+	if err := idx.attachChanges(rid, ds.applied); err != nil {
+		return err
+	}
+
+	if ds.count == 0 {
+		return sqlitex.Exec(idx.conn, qDeleteDocumentState(), nil, rid)
+	}
+
+	return idx.saveDocumentState(rid, ds)
+}
+
+// attachChanges records the applied changes as part of the document,
+// and makes their searchable text findable in the document.
+// Only the changes from the authorized writers are applied,
+// so nobody else can get their text or links attributed to the document.
+func (idx *indexingCtx) attachChanges(rid int64, changes []int64) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	if err := sqlitex.Exec(idx.conn, qDocumentChangesInsert(), nil, rid, string(data)); err != nil {
+		return err
+	}
+
+	return sqlitex.Exec(idx.conn, qFTSIndexAttach(), nil, rid, string(data))
+}
+
+// detachChanges forgets all the changes attributed to the document.
+func (idx *indexingCtx) detachChanges(rid int64) error {
+	if err := sqlitex.Exec(idx.conn, qDocumentChangesClear(), nil, rid); err != nil {
+		return err
+	}
+
+	return sqlitex.Exec(idx.conn, qFTSIndexDetach(), nil, rid)
+}
+
+var qDocumentChangesInsert = dqb.Str(`
+	INSERT OR IGNORE INTO document_changes (resource, change)
+	SELECT :resource, value FROM json_each(:changes);
+`)
+
+var qDocumentChangesClear = dqb.Str(`
+	DELETE FROM document_changes
+	WHERE resource = :resource;
+`)
+
+var qFTSIndexAttach = dqb.Str(`
+	UPDATE fts_index
+	SET resource = :resource
+	WHERE blob_id IN (SELECT value FROM json_each(:changes));
+`)
+
+var qFTSIndexDetach = dqb.Str(`
+	UPDATE fts_index
+	SET resource = NULL
+	WHERE resource = :resource;
+`)
+
+// applyNewRefs applies the changes from the refs that are not covered by the current state yet,
+// by walking back from the heads of the refs until reaching the current heads of the state.
+// It returns false if the state can't be updated incrementally,
+// i.e. when the refs point to some old changes, and the walk goes all the way back to the genesis.
+func (idx *indexingCtx) applyNewRefs(ds *documentState, iri IRI, owner core.Principal, refs []int64) (ok bool, err error) {
+	heads := make([]int64, 0, len(ds.heads))
+	for c := range ds.heads {
+		id, err := idx.ensureBlob(c)
+		if err != nil {
+			return false, err
+		}
+		heads = append(heads, id)
+	}
+
+	headsJSON, err := json.Marshal(heads)
+	if err != nil {
+		return false, err
+	}
+
+	refsJSON, err := json.Marshal(refs)
+	if err != nil {
+		return false, err
+	}
+
+	// Keeping the current heads in case the changes must be replayed from scratch.
+	oldHeads := maps.Clone(ds.heads)
+
+	genesis, err := idx.applyChanges(ds, qIterNewChanges(), string(refsJSON), iri, owner, string(headsJSON))
+	if err != nil {
+		return false, err
+	}
+
+	if genesis > 0 {
+		ds.heads = oldHeads
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// loadDocumentState loads the current materialized state of the document.
+// It returns false if there's no state, or it can't be updated incrementally.
+func (idx *indexingCtx) loadDocumentState(rid int64) (ds *documentState, ok bool, err error) {
+	var (
+		version  string
+		state    []byte
+		authors  []byte
+		hasState bool
+	)
+	if err := sqlitex.Exec(idx.conn, qLoadDocumentState(), func(stmt *sqlite.Stmt) error {
+		version = stmt.ColumnText(0)
+		hasState = stmt.ColumnType(1) != sqlite.SQLITE_NULL
+		state = stmt.ColumnBytes(1)
+		authors = stmt.ColumnBytes(2)
+		ds = newDocumentState()
+		ds.createTime = stmt.ColumnInt64(3)
+		ds.updateTime = stmt.ColumnInt64(4)
+		ds.count = stmt.ColumnInt(5)
+		return nil
+	}, rid); err != nil {
+		return nil, false, err
+	}
+
+	// States saved before we started to keep the CRDT state can't be updated incrementally.
+	if ds == nil || !hasState || ds.count == 0 {
+		return nil, false, nil
+	}
+
+	heads, err := Version(version).Parse()
+	if err != nil {
+		return nil, false, err
+	}
+	for _, h := range heads {
+		ds.heads[h] = struct{}{}
+	}
+
+	var nodes []crdt2.Node
+	if err := json.Unmarshal(state, &nodes); err != nil {
+		return nil, false, fmt.Errorf("failed to decode document metadata state: %w", err)
+	}
+	if err := ds.metadata.LoadNodes(nodes); err != nil {
+		return nil, false, err
+	}
+
+	var authorsList []string
+	if err := json.Unmarshal(authors, &authorsList); err != nil {
+		return nil, false, fmt.Errorf("failed to decode document authors: %w", err)
+	}
+	for _, a := range authorsList {
+		ds.authors[a] = struct{}{}
+	}
+
+	return ds, true, nil
+}
+
+var qLoadDocumentState = dqb.Str(`
+	SELECT version, metadata_state, authors, create_time, update_time, change_count
+	FROM document_states
+	WHERE resource = :resource;
+`)
+
+func (idx *indexingCtx) saveDocumentState(rid int64, ds *documentState) error {
+	metadata := make(map[string]string)
+	for _, k := range ds.metadata.Keys("metadata") {
+		v, ok := ds.metadata.GetAny("metadata", k).(string)
+		if ok && v != "" {
+			metadata[k] = v
+		}
+	}
+
+	mdjson, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+
+	statejson, err := json.Marshal(ds.metadata.Nodes())
+	if err != nil {
+		return err
+	}
+
+	authorsjson, err := json.Marshal(slices.Sorted(maps.Keys(ds.authors)))
+	if err != nil {
+		return err
+	}
+
+	version := NewVersion(slices.Collect(maps.Keys(ds.heads))...)
+
+	return sqlitex.Exec(idx.conn, qUpsertDocumentState(), nil, rid, version.String(), string(mdjson), string(statejson), string(authorsjson), ds.createTime, ds.updateTime, ds.count)
+}
+
+// qIterNewChanges walks back from the heads of the given refs,
+// stopping at the given current heads of the document.
+var qIterNewChanges = dqb.Q(func() string {
+	return `
+	WITH RECURSIVE
+	refs (id) AS (
+		SELECT id
+		FROM structural_blobs
+		WHERE type = 'Ref'
+		AND id IN (SELECT value FROM json_each(:refs))
+		-- resource
+		AND resource = (SELECT id FROM resources WHERE iri = :iri)
+		-- author
+		AND ` + sqlCanWrite("structural_blobs") + `
+	),
+	changes (id) AS (
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN refs r ON r.id = bl.source AND bl.type = 'ref/head'
+		WHERE bl.target NOT IN (SELECT value FROM json_each(:heads))
+
+		UNION
+
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN changes c ON c.id = bl.source
+		WHERE bl.type = 'change/dep'
+		AND bl.target NOT IN (SELECT value FROM json_each(:heads))
+	)
+	SELECT
+		codec,
+		multihash,
+		data,
+		b.id
+	FROM blobs b
+	JOIN structural_blobs sb ON sb.id = b.id
+	JOIN changes c ON c.id = b.id
+	ORDER BY sb.ts;
+`
+})
+
+// changeOrigin returns the origin of the change for the CRDT state.
+// Must be the same as in the document model.
+func changeOrigin(c cid.Cid) string {
+	str, err := c.StringOfBase(multibase.Base58BTC)
+	if err != nil {
+		panic(err)
+	}
+	return str[len(str)-9:]
+}
+
+var qUpsertDocumentState = dqb.Str(`
+	INSERT OR REPLACE INTO document_states (resource, version, metadata, metadata_state, authors, create_time, update_time, change_count)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+`)
+
+var qDeleteDocumentState = dqb.Str(`
+	DELETE FROM document_states WHERE resource = ?;
+`)
@@ -0,0 +1,62 @@
+package index
+
+import "sync"
+
+// documentWatchers keeps track of the subscribers interested in updates of documents.
+type documentWatchers struct {
+	mu   sync.Mutex
+	subs map[IRI]map[chan struct{}]struct{}
+}
+
+// WatchDocument returns a channel that receives a signal every time
+// the indexer updates the state of the document, i.e. when new Refs or Changes for it are indexed.
+// The channel has a buffer of one item, and signals are not blocking,
+// so multiple updates that happen while the subscriber is busy are coalesced into one.
+// The returned function must be called to release the subscription.
+func (idx *Index) WatchDocument(iri IRI) (updates <-chan struct{}, cancel func()) {
+	ch := make(chan struct{}, 1)
+
+	w := &idx.watchers
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.subs == nil {
+		w.subs = make(map[IRI]map[chan struct{}]struct{})
+	}
+
+	if w.subs[iri] == nil {
+		w.subs[iri] = make(map[chan struct{}]struct{})
+	}
+	w.subs[iri][ch] = struct{}{}
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.subs[iri], ch)
+		if len(w.subs[iri]) == 0 {
+			delete(w.subs, iri)
+		}
+	}
+}
+
+// notify the subscribers of the updated documents.
+// Must be called after the indexing transaction is committed,
+// to make sure subscribers can see the new state.
+func (w *documentWatchers) notify(docs []IRI) {
+	if len(docs) == 0 {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, iri := range docs {
+		for ch := range w.subs[iri] {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
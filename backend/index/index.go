@@ -21,6 +21,7 @@ import (
 	"github.com/ipfs/boxo/provider"
 	"github.com/ipfs/go-cid"
 	cbornode "github.com/ipfs/go-ipld-cbor"
+	"github.com/klauspost/compress/zstd"
 	"github.com/multiformats/go-multicodec"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -51,6 +52,9 @@ type Index struct {
 	db       *sqlitex.Pool
 	log      *zap.Logger
 	provider provider.Provider
+	watchers documentWatchers
+
+	blobWatchers blobWatchers
 }
 
 func NewIndex(db *sqlitex.Pool, log *zap.Logger, prov provider.Provider) *Index {
@@ -79,9 +83,7 @@ func (idx *Index) IPFSBlockstore() blockstore.Blockstore {
 // indexBlob is an uber-function that knows about all types of blobs we want to index.
 // This is probably a bad idea to put here, but for now it's easier to work with that way.
 // TODO(burdiyan): eventually we might want to make this package agnostic to blob types.
-func (idx *Index) indexBlob(conn *sqlite.Conn, id int64, c cid.Cid, data []byte) error {
-	ictx := newCtx(conn, idx.provider, idx.log)
-
+func (idx *Index) indexBlob(ictx *indexingCtx, id int64, c cid.Cid, data []byte) error {
 	for _, fn := range indexersList {
 		if err := fn(ictx, id, c, data); err != nil {
 			return err
@@ -124,6 +126,10 @@ type ChangeRecord struct {
 }
 
 func (idx *Index) IterChanges(ctx context.Context, resource IRI, author core.Principal) (it iter.Seq2[int, ChangeRecord], check func() error) {
+	return idx.iterChanges(ctx, qIterChanges(), resource, author)
+}
+
+func (idx *Index) iterChanges(ctx context.Context, query string, args ...any) (it iter.Seq2[int, ChangeRecord], check func() error) {
 	var outErr error
 
 	check = func() error { return outErr }
@@ -136,7 +142,7 @@ func (idx *Index) IterChanges(ctx context.Context, resource IRI, author core.Pri
 		defer release()
 
 		buf := make([]byte, 0, 1024*1024) // preallocating 1MB for decompression.
-		rows, check := sqlitex.Query(conn, qIterChanges(), resource, author, author, resource)
+		rows, check := sqlitex.Query(conn, query, args...)
 		var i int
 		for row := range rows {
 			next := sqlite.NewIncrementor(0)
@@ -155,7 +161,7 @@ func (idx *Index) IterChanges(ctx context.Context, resource IRI, author core.Pri
 			chcid := cid.NewCidV1(uint64(codec), hash)
 			ch := &Change{}
 			if err := cbornode.DecodeInto(buf, ch); err != nil {
-				outErr = errors.Join(outErr, fmt.Errorf("WalkChanges: failed to decode change %s for entity %s: %w", chcid, resource, err))
+				outErr = errors.Join(outErr, fmt.Errorf("WalkChanges: failed to decode change %s: %w", chcid, err))
 				break
 			}
 
@@ -178,24 +184,17 @@ func (idx *Index) IterChanges(ctx context.Context, resource IRI, author core.Pri
 	return it, check
 }
 
-var qIterChanges = dqb.Str(`
+var qIterChanges = dqb.Q(func() string {
+	return `
 	WITH RECURSIVE
 	refs (id) AS (
 		SELECT id
 		FROM structural_blobs
 		WHERE type = 'Ref'
 		-- resource
-		AND resource = (SELECT id FROM resources WHERE iri = ?)
+		AND resource = (SELECT id FROM resources WHERE iri = :iri)
 		-- author
-		AND (author = (SELECT id FROM public_keys WHERE principal = ?) OR author IN (
-			SELECT DISTINCT extra_attrs->>'del'
-			FROM structural_blobs
-			WHERE type = 'Capability'
-			-- author
-			AND author = (SELECT id FROM public_keys WHERE principal = ?)
-			-- iri
-			AND resource IN (SELECT id FROM resources WHERE ? BETWEEN iri AND iri || '~~~~~~')
-		))
+		AND ` + sqlCanWrite("structural_blobs") + `
 	),
 	changes (id) AS (
 		SELECT bl.target
@@ -212,12 +211,14 @@ var qIterChanges = dqb.Str(`
 	SELECT
 		codec,
 		multihash,
-		data
+		data,
+		b.id
 	FROM blobs b
 	JOIN structural_blobs sb ON sb.id = b.id
 	JOIN changes c ON c.id = b.id
 	ORDER BY sb.ts
-`)
+`
+})
 
 func (idx *Index) WalkCapabilities(ctx context.Context, resource IRI, author core.Principal, fn func(cid.Cid, *Capability) error) error {
 	conn, release, err := idx.db.Conn(ctx)
@@ -242,7 +243,7 @@ func (idx *Index) WalkCapabilities(ctx context.Context, resource IRI, author cor
 		chcid := cid.NewCidV1(uint64(codec), hash)
 		cpb := &Capability{}
 		if err := cbornode.DecodeInto(buf, cpb); err != nil {
-			return fmt.Errorf("WalkChanges: failed to decode change %s for entity %s: %w", chcid, resource, err)
+			return fmt.Errorf("WalkChanges: failed to decode change %s: %w", chcid, err)
 		}
 
 		if err := fn(chcid, cpb); err != nil {
@@ -259,24 +260,153 @@ func (idx *Index) WalkCapabilities(ctx context.Context, resource IRI, author cor
 	return nil
 }
 
-var qWalkCapabilities = dqb.Str(`
+var qWalkCapabilities = dqb.Q(func() string {
+	return `
 	SELECT
 		b.codec,
 		b.multihash,
 		b.data
 	FROM structural_blobs sb
 	JOIN blobs b ON b.id = sb.id
+	JOIN resources res ON res.id = sb.resource
 	WHERE sb.type = 'Capability'
-	AND sb.resource IN (SELECT id FROM resources WHERE :iri BETWEEN iri AND iri || '~~~~~~')
-	AND sb.author = (SELECT id FROM public_keys WHERE principal = :author)
+	AND ` + sqlValidCapability("sb", "res") + `
 	ORDER BY sb.ts
-`)
+`
+})
+
+// ListCapabilitiesOptions are the filters for listing capabilities.
+type ListCapabilitiesOptions struct {
+	// Delegate is the optional delegate of the capabilities.
+	Delegate core.Principal
+	// Issuer is the optional issuer of the capabilities.
+	Issuer core.Principal
+	// Role is the optional role of the capabilities.
+	Role string
+	// IgnoreInherited skips the capabilities issued for the parent paths.
+	IgnoreInherited bool
+	// Cursor is the ID of the last capability from the previous page.
+	Cursor int64
+	// Limit is the maximum number of capabilities to return.
+	Limit int
+}
+
+// ListCapabilities lists the valid capabilities for the resource matching the options, ordered by their ID.
+// Along with each capability, the callback receives its ID to be used as a cursor,
+// and the effective role of the delegate, i.e. the highest role among all of their capabilities for the resource.
+func (idx *Index) ListCapabilities(ctx context.Context, resource IRI, owner core.Principal, opts ListCapabilitiesOptions, fn func(id int64, c cid.Cid, cpb *Capability, effectiveRole string) error) error {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var delegate, issuer int64
+	if opts.Delegate != nil {
+		delegate, err = dbPublicKeysLookupID(conn, opts.Delegate)
+		if err != nil {
+			return err
+		}
+		if delegate == 0 {
+			return nil
+		}
+	}
 
-func (idx *Index) IterComments(ctx context.Context, resource IRI) (it iter.Seq2[cid.Cid, *Comment], check func() error) {
+	if opts.Issuer != nil {
+		issuer, err = dbPublicKeysLookupID(conn, opts.Issuer)
+		if err != nil {
+			return err
+		}
+		if issuer == 0 {
+			return nil
+		}
+	}
+
+	var buf []byte
+	return sqlitex.Exec(conn, qListCapabilities(), func(stmt *sqlite.Stmt) error {
+		var (
+			id            = stmt.ColumnInt64(0)
+			codec         = stmt.ColumnInt64(1)
+			hash          = stmt.ColumnBytesUnsafe(2)
+			data          = stmt.ColumnBytesUnsafe(3)
+			effectiveRole = stmt.ColumnText(4)
+		)
+
+		buf, err = idx.bs.decoder.DecodeAll(data, buf[:0])
+		if err != nil {
+			return err
+		}
+
+		c := cid.NewCidV1(uint64(codec), hash)
+		cpb := &Capability{}
+		if err := cbornode.DecodeInto(buf, cpb); err != nil {
+			return fmt.Errorf("ListCapabilities: failed to decode capability %s: %w", c, err)
+		}
+
+		return fn(id, c, cpb, effectiveRole)
+	}, resource, owner, opts.Cursor, delegate, issuer, opts.Role, opts.IgnoreInherited, opts.Limit)
+}
+
+var qListCapabilities = dqb.Q(func() string {
+	return `
+	WITH valid (id, author, del, role, iri) AS (
+		SELECT
+			sb.id,
+			sb.author,
+			sb.extra_attrs->>'del',
+			sb.extra_attrs->>'role',
+			res.iri
+		FROM structural_blobs sb
+		JOIN resources res ON res.id = sb.resource
+		WHERE sb.type = 'Capability'
+		AND ` + sqlValidCapability("sb", "res") + `
+	)
+	SELECT
+		v.id,
+		b.codec,
+		b.multihash,
+		b.data,
+		(
+			SELECT v2.role
+			FROM valid v2
+			WHERE v2.del = v.del
+			ORDER BY CASE v2.role WHEN 'EDITOR' THEN 1 WHEN 'WRITER' THEN 2 ELSE 3 END
+			LIMIT 1
+		) AS effective_role
+	FROM valid v
+	JOIN blobs b ON b.id = v.id
+	WHERE v.id > :cursor
+	AND (:delegate = 0 OR v.del = :delegate)
+	AND (:issuer = 0 OR v.author = :issuer)
+	AND (:role = '' OR v.role = :role)
+	AND (NOT :ignore_inherited OR v.iri = :iri)
+	ORDER BY v.id
+	LIMIT :limit;
+`
+})
+
+// IterCommentsOptions are the filters for iterating over comments.
+type IterCommentsOptions struct {
+	// Authors filters the comments by the authorization of their authors.
+	Authors CommentAuthors
+	// RootsOnly limits the comments to the roots of the threads.
+	RootsOnly bool
+	// ThreadRoot limits the comments to the replies in the thread with the given root.
+	ThreadRoot cid.Cid
+	// CursorTs and CursorID are the timestamp and the ID of the last comment from the previous page.
+	CursorTs int64
+	CursorID int64
+	// Limit is the maximum number of comments to return. Zero means no limit.
+	Limit int
+}
+
+// IterComments iterates over the comments of the resource owned by the owner, ordered by their creation time.
+// Comments are identified by the CID of their original version, and resolved to their latest version.
+func (idx *Index) IterComments(ctx context.Context, resource IRI, owner core.Principal, opts IterCommentsOptions) (it iter.Seq2[cid.Cid, CommentRecord], check func() error) {
 	var outErr error
 
 	check = func() error { return outErr }
-	it = func(yield func(cid.Cid, *Comment) bool) {
+	it = func(yield func(cid.Cid, CommentRecord) bool) {
 		conn, release, err := idx.db.Conn(ctx)
 		if err != nil {
 			outErr = err
@@ -284,29 +414,38 @@ func (idx *Index) IterComments(ctx context.Context, resource IRI) (it iter.Seq2[
 		}
 		defer release()
 
+		var threadRoot int64
+		if opts.ThreadRoot.Defined() {
+			res, err := dbBlobsGetSize(conn, opts.ThreadRoot.Hash())
+			if err != nil {
+				outErr = err
+				return
+			}
+			if res.BlobsID == 0 {
+				return
+			}
+			threadRoot = res.BlobsID
+		}
+
+		limit := opts.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+
 		buf := make([]byte, 0, 1024*1024) // preallocating 1MB for decompression.
-		rows, check := sqlitex.Query(conn, qWalkComments(), resource)
+		rows, check := sqlitex.Query(conn, qIterComments(), resource, opts.CursorTs, opts.CursorID, opts.RootsOnly, threadRoot, opts.Authors, owner, limit)
 		for row := range rows {
 			var (
-				codec = row.ColumnInt64(0)
-				hash  = row.ColumnBytesUnsafe(1)
-				data  = row.ColumnBytesUnsafe(2)
+				c   cid.Cid
+				rec CommentRecord
 			)
-
-			buf, err = idx.bs.decoder.DecodeAll(data, buf)
+			c, rec, buf, err = idx.scanCommentRecord(row, buf)
 			if err != nil {
 				outErr = err
 				break
 			}
 
-			chcid := cid.NewCidV1(uint64(codec), hash)
-			cmt := &Comment{}
-			if err := cbornode.DecodeInto(buf, cmt); err != nil {
-				outErr = fmt.Errorf("WalkChanges: failed to decode change %s for entity %s: %w", chcid, resource, err)
-				break
-			}
-
-			if !yield(chcid, cmt) {
+			if !yield(c, rec) {
 				break
 			}
 
@@ -319,6 +458,24 @@ func (idx *Index) IterComments(ctx context.Context, resource IRI) (it iter.Seq2[
 	return it, check
 }
 
+var qIterComments = dqb.Q(func() string {
+	return `
+	SELECT ` + sqlCommentRecordColumns + `
+	FROM structural_blobs sb
+	JOIN blobs ob ON ob.id = sb.id
+	JOIN blobs lb ON lb.id = ` + sqlLatestCommentVersion + `
+	WHERE sb.type = 'Comment'
+	AND sb.resource = (SELECT id FROM resources WHERE iri = :iri)
+	AND (sb.ts, sb.id) > (:cursor_ts, :cursor_id)
+	AND NOT EXISTS (SELECT 1 FROM blob_links WHERE source = sb.id AND type = 'comment/original')
+	AND (NOT :roots_only OR NOT EXISTS (SELECT 1 FROM blob_links WHERE source = sb.id AND type = 'comment/thread-root'))
+	AND (:thread_root = 0 OR EXISTS (SELECT 1 FROM blob_links WHERE source = sb.id AND type = 'comment/thread-root' AND target = :thread_root))
+	AND (:authors = 0 OR (:authors = 1) = ` + sqlCanComment("sb") + `)
+	ORDER BY sb.ts, sb.id
+	LIMIT :limit
+`
+})
+
 func (idx *Index) WalkComments(ctx context.Context, resource IRI, fn func(cid.Cid, *Comment) error) error {
 	conn, release, err := idx.db.Conn(ctx)
 	if err != nil {
@@ -342,7 +499,7 @@ func (idx *Index) WalkComments(ctx context.Context, resource IRI, fn func(cid.Ci
 		chcid := cid.NewCidV1(uint64(codec), hash)
 		cmt := &Comment{}
 		if err := cbornode.DecodeInto(buf, cmt); err != nil {
-			return fmt.Errorf("WalkChanges: failed to decode change %s for entity %s: %w", chcid, resource, err)
+			return fmt.Errorf("WalkChanges: failed to decode change %s: %w", chcid, err)
 		}
 
 		if err := fn(chcid, cmt); err != nil {
@@ -415,23 +572,36 @@ func (eb EncodedBlob[T]) Loggable() map[string]interface{} {
 type indexingCtx struct {
 	conn     *sqlite.Conn
 	provider provider.Provider
+	decoder  *zstd.Decoder
 	log      *zap.Logger
 
 	// Lookup tables for internal database IDs.
 	pubKeys   map[string]int64
 	resources map[IRI]int64
 	blobs     map[cid.Cid]int64
+
+	// Documents whose materialized state must be updated
+	// after all the blobs in the batch are indexed.
+	staleDocs map[IRI]*staleDocument
+
+	// Documents whose materialized state was updated in this batch.
+	updatedDocs []IRI
+
+	// IDs of the blobs stored in this batch that we didn't have before.
+	newBlobs []int64
 }
 
-func newCtx(conn *sqlite.Conn, provider provider.Provider, log *zap.Logger) *indexingCtx {
+func (idx *Index) newCtx(conn *sqlite.Conn) *indexingCtx {
 	return &indexingCtx{
 		conn:     conn,
-		provider: provider,
-		log:      log,
+		provider: idx.provider,
+		decoder:  idx.bs.decoder,
+		log:      idx.log,
 		// Setting arbitrary size for maps, to avoid dynamic resizing in most cases.
 		pubKeys:   make(map[string]int64, 16),
 		resources: make(map[IRI]int64, 16),
 		blobs:     make(map[cid.Cid]int64, 16),
+		staleDocs: make(map[IRI]*staleDocument, 16),
 	}
 }
 
@@ -519,6 +689,16 @@ func (idx *indexingCtx) SaveBlob(id int64, b StructuralBlob) error {
 		}
 	}
 
+	for _, tc := range b.TextContent {
+		if b.Ts.IsZero() {
+			return fmt.Errorf("blobs with text content must have a timestamp")
+		}
+
+		if err := dbFTSIndexInsert(idx.conn, id, tc.Type, tc.Key, b.Ts.UnixMicro(), tc.Text); err != nil {
+			return fmt.Errorf("failed to insert text content: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -18,19 +18,39 @@ func (idx *Index) Put(ctx context.Context, blk blocks.Block) error {
 	}
 	defer release()
 
-	return sqlitex.WithTx(conn, func() error {
+	ictx := idx.newCtx(conn)
+	if err := sqlitex.WithTx(conn, func() error {
 		codec, hash := ipfs.DecodeCID(blk.Cid())
 		id, exists, err := idx.bs.putBlock(conn, 0, uint64(codec), hash, blk.RawData())
 		if err != nil {
 			return err
 		}
 
-		if exists || !isIndexable(multicodec.Code(codec)) {
+		if exists {
 			return nil
 		}
+		ictx.newBlobs = append(ictx.newBlobs, id)
 
-		return idx.indexBlob(conn, id, blk.Cid(), blk.RawData())
-	})
+		if !isIndexable(multicodec.Code(codec)) {
+			return nil
+		}
+
+		if err := idx.indexBlob(ictx, id, blk.Cid(), blk.RawData()); err != nil {
+			return err
+		}
+
+		return ictx.updateDocumentStates()
+	}); err != nil {
+		return err
+	}
+
+	idx.watchers.notify(ictx.updatedDocs)
+	if len(ictx.newBlobs) > 0 {
+		idx.bs.blobSets.added(ictx.newBlobs...)
+		idx.blobWatchers.notify()
+	}
+
+	return nil
 }
 
 func (idx *Index) PutMany(ctx context.Context, blks []blocks.Block) error {
@@ -40,7 +60,8 @@ func (idx *Index) PutMany(ctx context.Context, blks []blocks.Block) error {
 	}
 	defer release()
 
-	return sqlitex.WithTx(conn, func() error {
+	ictx := idx.newCtx(conn)
+	if err := sqlitex.WithTx(conn, func() error {
 		for _, blk := range blks {
 			codec, hash := ipfs.DecodeCID(blk.Cid())
 			id, exists, err := idx.bs.putBlock(conn, 0, uint64(codec), hash, blk.RawData())
@@ -48,17 +69,32 @@ func (idx *Index) PutMany(ctx context.Context, blks []blocks.Block) error {
 				return err
 			}
 
-			if exists || !isIndexable(multicodec.Code(codec)) {
+			if exists {
 				continue
 			}
+			ictx.newBlobs = append(ictx.newBlobs, id)
 
-			if err := idx.indexBlob(conn, id, blk.Cid(), blk.RawData()); err != nil {
+			if !isIndexable(multicodec.Code(codec)) {
+				continue
+			}
+
+			if err := idx.indexBlob(ictx, id, blk.Cid(), blk.RawData()); err != nil {
 				return err
 			}
 		}
 
-		return nil
-	})
+		return ictx.updateDocumentStates()
+	}); err != nil {
+		return err
+	}
+
+	idx.watchers.notify(ictx.updatedDocs)
+	if len(ictx.newBlobs) > 0 {
+		idx.bs.blobSets.added(ictx.newBlobs...)
+		idx.blobWatchers.notify()
+	}
+
+	return nil
 }
 
 func (idx *Index) DeleteBlock(ctx context.Context, c cid.Cid) error {
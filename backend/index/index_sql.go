@@ -58,6 +58,15 @@ var qResourceLinksInsert = dqb.Str(`
 	VALUES (?, ?, ?, ?, ?);
 `)
 
+func dbFTSIndexInsert(conn *sqlite.Conn, blobID int64, contentType, key string, ts int64, text string) error {
+	return sqlitex.Exec(conn, qFTSIndexInsert(), nil, blobID, contentType, key, ts, text)
+}
+
+var qFTSIndexInsert = dqb.Str(`
+	INSERT INTO fts_index (blob_id, type, key, ts, raw_content)
+	VALUES (?, ?, ?, ?, ?);
+`)
+
 type blobsGetSizeResult struct {
 	BlobsID   int64
 	BlobsSize int64
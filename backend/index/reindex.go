@@ -37,6 +37,9 @@ func (bs *Index) reindex(conn *sqlite.Conn) (err error) {
 	derivedTables := []string{
 		storage.T_BlobLinks,
 		storage.T_ResourceLinks,
+		storage.T_FtsIndex,
+		storage.T_DocumentChanges,
+		storage.T_DocumentStates,
 		storage.T_StructuralBlobs,
 		// Not deleting from resources yet, because they are referenced in the drafts table,
 		// and we can't yet reconstruct the drafts table purely from the blobs.
@@ -52,6 +55,13 @@ func (bs *Index) reindex(conn *sqlite.Conn) (err error) {
 			}
 		}
 
+		// Deletions made with tombstones are derived from the blobs too,
+		// unlike the ones made locally by the user.
+		if err := sqlitex.ExecTransient(conn, "DELETE FROM "+storage.T_DeletedResources+" WHERE extra_attrs->>'tombstone' IS NOT NULL", nil); err != nil {
+			return err
+		}
+
+		ictx := bs.newCtx(conn)
 		scratch := make([]byte, 0, 1024*1024) // 1MB preallocated slice to reuse for decompressing.
 		if err := sqlitex.ExecTransient(conn, q, func(stmt *sqlite.Stmt) error {
 			codec := stmt.ColumnInt64(stmt.ColumnIndex(storage.BlobsCodec.ShortName()))
@@ -84,16 +94,25 @@ func (bs *Index) reindex(conn *sqlite.Conn) (err error) {
 				return fmt.Errorf("BUG: failed to clone decompressed data: %s", c)
 			}
 
-			return bs.indexBlob(conn, id, c, data)
+			return bs.indexBlob(ictx, id, c, data)
 		}); err != nil {
 			return err
 		}
 
+		if err := ictx.updateDocumentStates(); err != nil {
+			return err
+		}
+
+		if err := rebuildBlobSet(conn); err != nil {
+			return err
+		}
+
 		return dbSetReindexTime(conn, time.Now().UTC().String())
 	}); err != nil {
 		return err
 	}
 
+	bs.bs.blobSets.reset()
 	return nil
 }
 
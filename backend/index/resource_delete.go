@@ -0,0 +1,205 @@
+package index
+
+import (
+	"context"
+	"seed/backend/util/dqb"
+	"seed/backend/util/sqlite"
+	"seed/backend/util/sqlite/sqlitex"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeleteResource removes the resource from the local database along with its content blobs:
+// the changes, the refs, the comments, and the snapshots.
+// Capabilities, revocations, and tombstones are kept, because they affect other resources,
+// or the state of the resource after it's undeleted.
+// The deletion is recorded in the deleted_resources table with the given reason,
+// and the metadata of the document, to be able to tell what was deleted.
+// Blob records are kept without data, to prevent syncing them back until the resource is undeleted.
+func (idx *Index) DeleteResource(ctx context.Context, resource IRI, reason string) error {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := sqlitex.WithTx(conn, func() error {
+		res, err := dbEntitiesLookupID(conn, string(resource))
+		if err != nil {
+			return err
+		}
+		if res.ResourcesID == 0 {
+			return status.Errorf(codes.NotFound, "resource %s not found", resource)
+		}
+
+		if err := sqlitex.Exec(conn, qDeletedResourcesInsert(), nil, resource, reason, res.ResourcesID); err != nil {
+			return err
+		}
+
+		if err := sqlitex.Exec(conn, qDeletedBlobsInsert(), nil, res.ResourcesID, resource); err != nil {
+			return err
+		}
+
+		for _, q := range []string{
+			qDeletedBlobsPurgeFTS(),
+			qDeletedBlobsPurgeResourceLinks(),
+			qDeletedBlobsPurgeBlobLinks(),
+			qDeletedBlobsPurgeStructural(),
+			qDeletedBlobsPurgeData(),
+			qDeletedBlobsMarkBlobSet(),
+		} {
+			if err := sqlitex.Exec(conn, q, nil, resource); err != nil {
+				return err
+			}
+		}
+
+		return sqlitex.Exec(conn, qDeleteDocumentState(), nil, res.ResourcesID)
+	}); err != nil {
+		return err
+	}
+
+	idx.bs.blobSets.reset()
+	return nil
+}
+
+// UndeleteResource removes the deletion record of the resource,
+// allowing its blobs to be synced back from other peers.
+func (idx *Index) UndeleteResource(ctx context.Context, resource IRI) error {
+	conn, release, err := idx.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	if err := sqlitex.WithTx(conn, func() error {
+		// The blobs are not in the database anymore, so they must be fetched again.
+		if err := sqlitex.Exec(conn, qDeletedBlobsUnmarkBlobSet(), nil, resource); err != nil {
+			return err
+		}
+
+		if err := sqlitex.Exec(conn, qDeletedResourcesDelete(), nil, resource); err != nil {
+			return err
+		}
+
+		if conn.Changes() == 0 {
+			return status.Errorf(codes.NotFound, "resource %s is not deleted", resource)
+		}
+
+		// Resources deleted by their owners must stay deleted after undoing the local deletion.
+		owner, err := resourceOwner(resource)
+		if err != nil {
+			return err
+		}
+
+		return refreshTombstone(conn, resource, owner)
+	}); err != nil {
+		return err
+	}
+
+	idx.bs.blobSets.reset()
+	return nil
+}
+
+// isBlobDeleted checks whether the blob was removed along with a deleted resource.
+func isBlobDeleted(conn *sqlite.Conn, id int64) (deleted bool, err error) {
+	if err := sqlitex.Exec(conn, qIsBlobDeleted(), func(*sqlite.Stmt) error {
+		deleted = true
+		return nil
+	}, id); err != nil {
+		return false, err
+	}
+
+	return deleted, nil
+}
+
+var qIsBlobDeleted = dqb.Str(`
+	SELECT 1 FROM deleted_blobs WHERE blob = :id LIMIT 1;
+`)
+
+// Local deletions replace the records created by tombstones.
+// The tombstone blobs are kept, so the records are restored from them when the resource is undeleted.
+var qDeletedResourcesInsert = dqb.Str(`
+	INSERT INTO deleted_resources (iri, reason, extra_attrs)
+	VALUES (:iri, :reason, jsonb_object('metadata', (SELECT json(metadata) FROM document_states WHERE resource = :resource)))
+	ON CONFLICT (iri) DO UPDATE SET
+		delete_time = excluded.delete_time,
+		reason = excluded.reason,
+		extra_attrs = excluded.extra_attrs;
+`)
+
+var qDeletedResourcesDelete = dqb.Str(`
+	DELETE FROM deleted_resources WHERE iri = :iri;
+`)
+
+// Besides the blobs that point to the resource directly,
+// we collect all the changes reachable from the refs of the resource.
+var qDeletedBlobsInsert = dqb.Str(`
+	WITH RECURSIVE
+	resource_blobs (id) AS (
+		SELECT id
+		FROM structural_blobs
+		WHERE resource = :resource
+		AND type IN ('Ref', 'Change', 'Comment', 'Snapshot')
+	),
+	changes (id) AS (
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN structural_blobs sb ON sb.id = bl.source
+		WHERE sb.resource = :resource
+		AND sb.type = 'Ref'
+		AND bl.type = 'ref/head'
+
+		UNION
+
+		SELECT genesis_blob
+		FROM structural_blobs
+		WHERE resource = :resource
+		AND genesis_blob IS NOT NULL
+
+		UNION
+
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN changes c ON c.id = bl.source
+		WHERE bl.type = 'change/dep'
+	)
+	INSERT OR IGNORE INTO deleted_blobs (blob, iri)
+	SELECT id, :iri FROM resource_blobs
+	UNION
+	SELECT id, :iri FROM changes;
+`)
+
+var qDeletedBlobsPurgeFTS = dqb.Str(`
+	DELETE FROM fts_index WHERE blob_id IN (SELECT blob FROM deleted_blobs WHERE iri = :iri);
+`)
+
+var qDeletedBlobsPurgeResourceLinks = dqb.Str(`
+	DELETE FROM resource_links WHERE source IN (SELECT blob FROM deleted_blobs WHERE iri = :iri);
+`)
+
+var qDeletedBlobsPurgeBlobLinks = dqb.Str(`
+	DELETE FROM blob_links WHERE source IN (SELECT blob FROM deleted_blobs WHERE iri = :iri);
+`)
+
+var qDeletedBlobsPurgeStructural = dqb.Str(`
+	DELETE FROM structural_blobs WHERE id IN (SELECT blob FROM deleted_blobs WHERE iri = :iri);
+`)
+
+var qDeletedBlobsMarkBlobSet = dqb.Str(`
+	UPDATE rbsr_blobs
+	SET deleted = true
+	WHERE blob IN (SELECT blob FROM deleted_blobs WHERE iri = :iri);
+`)
+
+var qDeletedBlobsUnmarkBlobSet = dqb.Str(`
+	DELETE FROM rbsr_blobs
+	WHERE deleted
+	AND blob IN (SELECT blob FROM deleted_blobs WHERE iri = :iri);
+`)
+
+var qDeletedBlobsPurgeData = dqb.Str(`
+	UPDATE blobs
+	SET data = NULL, size = -1
+	WHERE id IN (SELECT blob FROM deleted_blobs WHERE iri = :iri);
+`)
@@ -21,6 +21,7 @@ type StructuralBlob struct {
 	}
 	BlobLinks     []BlobLink
 	ResourceLinks []ResourceLink
+	TextContent   []TextContent
 	Meta          any
 }
 
@@ -51,6 +52,13 @@ func (sb *StructuralBlob) AddResourceLink(linkType string, target IRI, isPinned
 	sb.ResourceLinks = append(sb.ResourceLinks, ResourceLink{Type: linkType, Target: target, IsPinned: isPinned, Meta: meta})
 }
 
+// AddTextContent adds a piece of text to be indexed for full-text search.
+// Key must identify the content within the resource, e.g. block ID or metadata key,
+// so that newer blobs can supersede the content of the older ones.
+func (sb *StructuralBlob) AddTextContent(contentType, key, text string) {
+	sb.TextContent = append(sb.TextContent, TextContent{Type: contentType, Key: key, Text: text})
+}
+
 type BlobLink struct {
 	Type   string
 	Target cid.Cid
@@ -62,3 +70,9 @@ type ResourceLink struct {
 	IsPinned bool
 	Meta     any
 }
+
+type TextContent struct {
+	Type string
+	Key  string
+	Text string
+}
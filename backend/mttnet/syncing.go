@@ -2,123 +2,304 @@ package mttnet
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"seed/backend/core"
 	p2p "seed/backend/genproto/p2p/v1alpha"
 	"seed/backend/syncing/rbsr"
+	"seed/backend/util/dqb"
+	"strings"
 
 	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
 
 	"github.com/ipfs/go-cid"
+	"google.golang.org/protobuf/proto"
 )
 
 // ReconcileBlobs reconciles a set of blobs from the initiator. Finds the difference from what we have.
 func (srv *rpcMux) ReconcileBlobs(ctx context.Context, in *p2p.ReconcileBlobsRequest) (*p2p.ReconcileBlobsResponse, error) {
-	store := rbsr.NewSliceStore()
+	var store rbsr.Store
+	if len(in.Filters) != 0 {
+		key, err := FiltersKey(in.Filters)
+		if err != nil {
+			return nil, err
+		}
+
+		// The set is cached, so it's only listed again when blobs change, and not on every round.
+		set, err := srv.Node.index.FilteredBlobSet(ctx, "reconcile:"+key, func(conn *sqlite.Conn, fn func(cid.Cid, int64, string) error) error {
+			return ListRelatedBlobs(conn, in.Filters, fn)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Could not list related blobs: %w", err)
+		}
+		store = set
+	} else {
+		// We don't offer the blobs of the deleted resources, because we don't have them.
+		set, err := srv.Node.index.BlobSet(ctx, false)
+		if err != nil {
+			return nil, fmt.Errorf("Could not list blobs: %w", err)
+		}
+		store = set
+	}
+
 	ne, err := rbsr.NewSession(store, 50000)
 	if err != nil {
 		return nil, err
 	}
+	out, err := ne.Reconcile(in.Ranges)
+	if err != nil {
+		return nil, err
+	}
+	return &p2p.ReconcileBlobsResponse{
+		Ranges: out,
+	}, nil
+}
 
-	conn, release, err := srv.Node.db.Conn(ctx)
+// FiltersKey identifies the set of filters, e.g. to cache the blobs related to them.
+func FiltersKey(filters []*p2p.Filter) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(&p2p.ReconcileBlobsRequest{Filters: filters})
 	if err != nil {
-		return nil, fmt.Errorf("Could not get connection: %w", err)
+		return "", err
 	}
-	defer release()
-	var query string = qListAllBlobsStr
-	var queryParams []interface{}
-	if len(in.Filters) != 0 {
-		query = QListrelatedBlobsStr
-		for i, filter := range in.Filters {
-			query += "?"
-			if filter.Recursive {
-				queryParams = append(queryParams, filter.Resource+"*")
-			} else {
-				queryParams = append(queryParams, filter.Resource)
-			}
-			if i < len(in.Filters)-1 {
-				query += " OR iri GLOB "
-			}
+	return string(data), nil
+}
+
+// ListRelatedBlobs calls fn for every local blob related to the resources of the filters,
+// honoring the blob type, author, and depth restrictions of each filter.
+// The dependencies of the selected refs, snapshots, and changes are always selected,
+// even if they don't pass the filters, otherwise the documents couldn't be loaded.
+// Both sides of the reconciliation must use it to select the same set of blobs.
+// Each blob is reported only once, along with the IRI of the resource it was selected for.
+func ListRelatedBlobs(conn *sqlite.Conn, filters []*p2p.Filter, fn func(c cid.Cid, ts int64, iri string) error) error {
+	matchers := make([]filterMatcher, len(filters))
+	patterns := make([]any, len(filters))
+	for i, f := range filters {
+		m, err := newFilterMatcher(f)
+		if err != nil {
+			return err
 		}
-		query += QListrelatedCapabilitiesStr
-		for i, filter := range in.Filters {
-			query += "?"
-			if filter.Recursive {
-				queryParams = append(queryParams, filter.Resource+"*")
-			} else {
-				queryParams = append(queryParams, filter.Resource)
+		matchers[i] = m
+		patterns[i] = m.pattern()
+	}
+
+	var (
+		seen  = make(map[cid.Cid]struct{})
+		roots [][2]any // ID and IRI.
+	)
+
+	query := qListRelatedBlobsStr + strings.Repeat("? OR iri GLOB ", len(patterns)-1) + "?" + qListRelatedBlobsContStr
+	if err := sqlitex.Exec(conn, query, func(stmt *sqlite.Stmt) error {
+		var (
+			codec    = stmt.ColumnInt64(0)
+			hash     = stmt.ColumnBytesUnsafe(1)
+			ts       = stmt.ColumnInt64(2)
+			blobType = stmt.ColumnText(3)
+			author   = stmt.ColumnBytesUnsafe(4)
+			iri      = stmt.ColumnText(5)
+			id       = stmt.ColumnInt64(6)
+		)
+
+		c := cid.NewCidV1(uint64(codec), hash)
+		if _, ok := seen[c]; ok {
+			return nil
+		}
+
+		for _, m := range matchers {
+			if m.match(iri, blobType, author) {
+				seen[c] = struct{}{}
+				if contentBlobTypes[blobType] == "Change" {
+					roots = append(roots, [2]any{id, iri})
+				}
+				return fn(c, ts, iri)
 			}
-			if i < len(in.Filters)-1 {
-				query += " OR iri GLOB "
+		}
+
+		return nil
+	}, patterns...); err != nil {
+		return fmt.Errorf("Could not list related blobs: %w", err)
+	}
+
+	if len(roots) > 0 {
+		rootsJSON, err := json.Marshal(roots)
+		if err != nil {
+			return err
+		}
+
+		if err := sqlitex.Exec(conn, qListBlobDependencies(), func(stmt *sqlite.Stmt) error {
+			codec := stmt.ColumnInt64(0)
+			hash := stmt.ColumnBytesUnsafe(1)
+			ts := stmt.ColumnInt64(2)
+			iri := stmt.ColumnText(3)
+			c := cid.NewCidV1(uint64(codec), hash)
+			if _, ok := seen[c]; ok {
+				return nil
 			}
+			seen[c] = struct{}{}
+			return fn(c, ts, iri)
+		}, string(rootsJSON)); err != nil {
+			return fmt.Errorf("Could not list blob dependencies: %w", err)
+		}
+	}
+
+	// Blobs of the resources linked from the content are only selected for unrestricted filters,
+	// because we can't tell which filter they belong to.
+	var embedPatterns []any
+	for _, m := range matchers {
+		if !m.restricted() {
+			embedPatterns = append(embedPatterns, m.pattern())
 		}
-		query += QListRelatedBlobsContStr
 	}
-	if err = sqlitex.Exec(conn, query, func(stmt *sqlite.Stmt) error {
+
+	if len(embedPatterns) == 0 {
+		return nil
+	}
+
+	query = QListEmbeddedBlobsStr + strings.Repeat("? OR res.iri GLOB ", len(embedPatterns)-1) + "?" + QListEmbeddedBlobsContStr
+	if err := sqlitex.Exec(conn, query, func(stmt *sqlite.Stmt) error {
 		codec := stmt.ColumnInt64(0)
 		hash := stmt.ColumnBytesUnsafe(1)
 		ts := stmt.ColumnInt64(2)
+		iri := stmt.ColumnText(3)
 		c := cid.NewCidV1(uint64(codec), hash)
-		return store.Insert(ts, c.Bytes())
-	}, queryParams...); err != nil {
-		return nil, fmt.Errorf("Could not list related blobs: %w", err)
+		if _, ok := seen[c]; ok {
+			return nil
+		}
+		seen[c] = struct{}{}
+		return fn(c, ts, iri)
+	}, embedPatterns...); err != nil {
+		return fmt.Errorf("Could not list related embeds: %w", err)
 	}
 
-	var queryParams2 []interface{}
-	if len(in.Filters) != 0 {
-		query = QListEmbeddedBlobsStr
-		for i, filter := range in.Filters {
-			query += "?"
-			if filter.Recursive {
-				queryParams2 = append(queryParams2, filter.Resource+"*")
-			} else {
-				queryParams2 = append(queryParams2, filter.Resource)
+	return nil
+}
+
+// contentBlobTypes maps the types of the content blobs to the types used in the filters.
+// Refs and snapshots follow the restrictions of the changes, because they are useless without them.
+// The rest of the related blobs are always selected.
+var contentBlobTypes = map[string]string{
+	"Change":           "Change",
+	"Ref":              "Change",
+	"Snapshot":         "Change",
+	"Comment":          "Comment",
+	"ThreadResolution": "Comment",
+}
+
+// ValidateFilter checks the restrictions of the filter.
+func ValidateFilter(f *p2p.Filter) error {
+	_, err := newFilterMatcher(f)
+	return err
+}
+
+type filterMatcher struct {
+	resource  string
+	recursive bool
+	maxDepth  int
+	types     map[string]struct{}
+	authors   map[string]struct{}
+}
+
+func newFilterMatcher(f *p2p.Filter) (m filterMatcher, err error) {
+	if f.Resource == "" {
+		return m, fmt.Errorf("filter must have a resource")
+	}
+
+	if f.MaxDepth < 0 {
+		return m, fmt.Errorf("max depth must not be negative: got %d", f.MaxDepth)
+	}
+
+	m = filterMatcher{
+		resource:  f.Resource,
+		recursive: f.Recursive,
+		maxDepth:  int(f.MaxDepth),
+	}
+
+	if len(f.BlobTypes) > 0 {
+		m.types = make(map[string]struct{}, len(f.BlobTypes))
+		for _, t := range f.BlobTypes {
+			if contentBlobTypes[t] != t {
+				return m, fmt.Errorf("unsupported blob type '%s': only Change and Comment are supported", t)
 			}
-			if i < len(in.Filters)-1 {
-				query += " OR res.iri GLOB "
+			m.types[t] = struct{}{}
+		}
+	}
+
+	if len(f.Authors) > 0 {
+		m.authors = make(map[string]struct{}, len(f.Authors))
+		for _, a := range f.Authors {
+			author, err := core.DecodePrincipal(a)
+			if err != nil {
+				return m, fmt.Errorf("failed to parse author '%s': %w", a, err)
 			}
+			m.authors[string(author)] = struct{}{}
 		}
-		query += QListEmbeddedBlobsContStr
 	}
-	if err = sqlitex.Exec(conn, query, func(stmt *sqlite.Stmt) error {
-		codec := stmt.ColumnInt64(0)
-		hash := stmt.ColumnBytesUnsafe(1)
-		ts := stmt.ColumnInt64(2)
-		c := cid.NewCidV1(uint64(codec), hash)
-		return store.Insert(ts, c.Bytes())
-	}, queryParams2...); err != nil {
-		return nil, fmt.Errorf("Could not list related embeds: %w", err)
+
+	return m, nil
+}
+
+// pattern returns the GLOB pattern to select the resources of the filter.
+func (m filterMatcher) pattern() string {
+	if m.recursive {
+		return m.resource + "*"
 	}
+	return m.resource
+}
 
-	if err = store.Seal(); err != nil {
-		return nil, err
+func (m filterMatcher) restricted() bool {
+	return len(m.types) > 0 || len(m.authors) > 0 || m.maxDepth > 0
+}
+
+func (m filterMatcher) match(iri, blobType string, author []byte) bool {
+	if !matchResource(m.resource, m.recursive, m.maxDepth, iri) {
+		return false
 	}
-	out, err := ne.Reconcile(in.Ranges)
-	if err != nil {
-		return nil, err
+
+	kind, ok := contentBlobTypes[blobType]
+	if !ok {
+		return true
 	}
-	return &p2p.ReconcileBlobsResponse{
-		Ranges: out,
-	}, nil
+
+	if m.types != nil {
+		if _, ok := m.types[kind]; !ok {
+			return false
+		}
+	}
+
+	if m.authors != nil {
+		if _, ok := m.authors[string(author)]; !ok {
+			return false
+		}
+	}
+
+	return true
 }
 
-const qListAllBlobsStr = (`
-SELECT
-	blobs.codec,
-	blobs.multihash,
-	blobs.insert_time,
-	?
-FROM blobs INDEXED BY blobs_metadata LEFT JOIN structural_blobs sb ON sb.id = blobs.id
-WHERE blobs.size >= 0
-ORDER BY sb.ts, blobs.multihash;
-`)
+// MatchResource checks whether the resource is selected by the filter,
+// regardless of the blob type and author restrictions.
+func MatchResource(f *p2p.Filter, iri string) bool {
+	return matchResource(f.Resource, f.Recursive, int(f.MaxDepth), iri)
+}
+
+func matchResource(resource string, recursive bool, maxDepth int, iri string) bool {
+	if iri == resource {
+		return true
+	}
+
+	if !recursive || !strings.HasPrefix(iri, resource) {
+		return false
+	}
+
+	return maxDepth == 0 || strings.Count(iri[len(resource):], "/") <= maxDepth
+}
 
 // QListEmbeddedBlobsStr gets embedded blobs related to multiple eids
 const QListEmbeddedBlobsStr = `
 SELECT distinct
 blobs.codec,
 blobs.multihash,
-blobs.insert_time
+blobs.insert_time,
+res.iri
 FROM blobs INDEXED BY blobs_metadata 
 LEFT JOIN structural_blobs sb ON sb.id = blobs.id
 LEFT JOIN structural_blobs sb2 ON sb.ts = sb2.ts
@@ -163,79 +344,92 @@ JOIN structural_blobs sb ON sb.id = b.id
 ))
 ORDER BY sb.ts, blobs.multihash;`
 
-// QListrelatedBlobsString gets blobs related to multiple eids
-const QListrelatedBlobsStr = `
+// qListRelatedBlobsStr gets blobs related to the resources matching any of the GLOB patterns,
+// which are inserted at the end of the first CTE.
+// Along with the blob, we select its type, author, and the resource it's related to,
+// to apply the rest of the filter restrictions.
+const qListRelatedBlobsStr = `
 WITH RECURSIVE
-refs (id) AS (
+matched_resources (id) AS (
 	SELECT id
-	FROM structural_blobs
-	WHERE type = 'Ref'
-	AND resource IN (SELECT id FROM resources WHERE iri GLOB `
+	FROM resources
+	WHERE iri GLOB `
 
-// QListrelatedCapabilitiesStr gets blobs related to multiple eids
-const QListrelatedCapabilitiesStr = `)
+const qListRelatedBlobsContStr = `
 ),
-capabilities (id) AS (
-	SELECT id
+refs (id, resource) AS (
+	SELECT id, resource
 	FROM structural_blobs
-	WHERE type = 'Capability'
-	AND resource IN (SELECT id FROM resources WHERE iri GLOB `
-
-// QListRelatedBlobsContStr gets blobs related to multiple eids
-const QListRelatedBlobsContStr = `)
+	WHERE type IN ('Ref', 'Tombstone', 'Snapshot')
+	AND resource IN matched_resources
 ),
-comments (id) AS (
-	SELECT bl.source
-	FROM blob_links bl
-	WHERE bl.type = 'comment/target'
+capabilities (id, resource) AS (
+	SELECT id, resource
+	FROM structural_blobs
+	WHERE type IN ('Capability', 'Revocation')
+	AND resource IN matched_resources
 ),
-changes (id) AS (
-	SELECT bl.target
+comments (id, resource) AS (
+	SELECT id, resource
+	FROM structural_blobs
+	WHERE type IN ('Comment', 'ThreadResolution')
+	AND resource IN matched_resources
+),
+changes (id, resource) AS (
+	SELECT bl.target, r.resource
 	FROM blob_links bl
 	JOIN refs r ON r.id = bl.source AND bl.type = 'ref/head'
 	UNION
-	SELECT bl.target
+	SELECT bl.target, c.resource
 	FROM blob_links bl
 	JOIN changes c ON c.id = bl.source
 	WHERE bl.type = 'change/dep'
+),
+related (id, resource) AS (
+	SELECT * FROM refs
+	UNION ALL
+	SELECT * FROM changes
+	UNION ALL
+	SELECT * FROM comments
+	UNION ALL
+	SELECT * FROM capabilities
 )
 SELECT
-codec,
-b.multihash,
-insert_time,
-b.id,
-sb.ts
-FROM blobs b
-JOIN refs r ON r.id = b.id
-JOIN structural_blobs sb ON sb.id = b.id
-UNION ALL
-SELECT
-codec,
-b.multihash,
-insert_time,
-b.id,
-sb.ts
-FROM blobs b
-JOIN changes ch ON ch.id = b.id
-JOIN structural_blobs sb ON sb.id = b.id
-UNION ALL
-SELECT
-codec,
-b.multihash,
-insert_time,
-b.id,
-sb.ts
-FROM blobs b
-JOIN comments co ON co.id = b.id
-JOIN structural_blobs sb ON sb.id = b.id
-UNION ALL
-SELECT
-codec,
-b.multihash,
-insert_time,
-b.id,
-sb.ts
-FROM blobs b
-JOIN capabilities cap ON cap.id = b.id
+	b.codec,
+	b.multihash,
+	b.insert_time,
+	sb.type,
+	pk.principal,
+	res.iri,
+	b.id
+FROM related rel
+JOIN blobs b ON b.id = rel.id
 JOIN structural_blobs sb ON sb.id = b.id
+JOIN resources res ON res.id = rel.resource
+LEFT JOIN public_keys pk ON pk.id = sb.author
 ORDER BY sb.ts, b.multihash;`
+
+// qListBlobDependencies gets the changes the given refs, snapshots, and changes depend on, transitively.
+// The roots are pairs of blob ID and the IRI of its resource, which is passed down to the dependencies.
+var qListBlobDependencies = dqb.Str(`
+	WITH RECURSIVE
+	deps (id, iri) AS (
+		SELECT bl.target, r.value->>1
+		FROM json_each(:roots) r
+		JOIN blob_links bl ON bl.source = r.value->>0
+		AND bl.type IN ('ref/head', 'snapshot/head', 'change/dep')
+		UNION
+		SELECT bl.target, d.iri
+		FROM blob_links bl
+		JOIN deps d ON d.id = bl.source
+		WHERE bl.type = 'change/dep'
+	)
+	SELECT
+		b.codec,
+		b.multihash,
+		b.insert_time,
+		deps.iri
+	FROM deps
+	JOIN blobs b ON b.id = deps.id
+	WHERE b.size >= 0;
+`)
@@ -0,0 +1,56 @@
+package mttnet
+
+import (
+	"seed/backend/core/coretest"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatcher(t *testing.T) {
+	alice := coretest.NewTester("alice")
+	bob := coretest.NewTester("bob")
+	root := "hm://" + alice.Account.Principal().String()
+
+	m, err := newFilterMatcher(&p2p.Filter{
+		Resource:  root,
+		Recursive: true,
+		BlobTypes: []string{"Comment"},
+		Authors:   []string{bob.Account.Principal().String()},
+		MaxDepth:  1,
+	})
+	require.NoError(t, err)
+	require.True(t, m.restricted())
+	require.Equal(t, root+"*", m.pattern())
+
+	bobKey := []byte(bob.Account.Principal())
+	aliceKey := []byte(alice.Account.Principal())
+
+	require.True(t, m.match(root, "Comment", bobKey))
+	require.True(t, m.match(root+"/cars", "ThreadResolution", bobKey))
+	require.False(t, m.match(root+"/cars/honda", "Comment", bobKey), "must honor max depth")
+	require.False(t, m.match(root, "Change", bobKey), "must honor blob types")
+	require.False(t, m.match(root, "Comment", aliceKey), "must honor authors")
+	require.True(t, m.match(root, "Capability", aliceKey), "non-content blobs must always match")
+	require.True(t, m.match(root, "Tombstone", aliceKey), "non-content blobs must always match")
+	require.False(t, m.match(root, "Ref", bobKey), "refs must follow the restrictions of the changes")
+	require.False(t, m.match(root, "Snapshot", bobKey), "snapshots must follow the restrictions of the changes")
+	require.False(t, m.match("hm://"+bob.Account.Principal().String(), "Comment", bobKey))
+
+	m, err = newFilterMatcher(&p2p.Filter{Resource: root + "/cars"})
+	require.NoError(t, err)
+	require.False(t, m.restricted())
+	require.True(t, m.match(root+"/cars", "Change", aliceKey))
+	require.False(t, m.match(root+"/cars/honda", "Change", aliceKey))
+
+	m, err = newFilterMatcher(&p2p.Filter{Resource: root, Authors: []string{bob.Account.Principal().String()}})
+	require.NoError(t, err)
+	require.True(t, m.match(root, "Ref", bobKey))
+	require.False(t, m.match(root, "Ref", aliceKey))
+
+	require.Error(t, ValidateFilter(&p2p.Filter{}))
+	require.Error(t, ValidateFilter(&p2p.Filter{Resource: root, BlobTypes: []string{"Ref"}}))
+	require.Error(t, ValidateFilter(&p2p.Filter{Resource: root, Authors: []string{"foo"}}))
+	require.Error(t, ValidateFilter(&p2p.Filter{Resource: root, MaxDepth: -1}))
+}
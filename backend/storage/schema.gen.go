@@ -44,6 +44,20 @@ const (
 	C_BlobsSize       = "blobs.size"
 )
 
+// Table deleted_blobs.
+const (
+	DeletedBlobs     sqlitegen.Table  = "deleted_blobs"
+	DeletedBlobsBlob sqlitegen.Column = "deleted_blobs.blob"
+	DeletedBlobsIRI  sqlitegen.Column = "deleted_blobs.iri"
+)
+
+// Table deleted_blobs. Plain strings.
+const (
+	T_DeletedBlobs     = "deleted_blobs"
+	C_DeletedBlobsBlob = "deleted_blobs.blob"
+	C_DeletedBlobsIRI  = "deleted_blobs.iri"
+)
+
 // Table deleted_resources.
 const (
 	DeletedResources           sqlitegen.Table  = "deleted_resources"
@@ -62,6 +76,144 @@ const (
 	C_DeletedResourcesReason     = "deleted_resources.reason"
 )
 
+// Table document_changes.
+const (
+	DocumentChanges         sqlitegen.Table  = "document_changes"
+	DocumentChangesChange   sqlitegen.Column = "document_changes.change"
+	DocumentChangesResource sqlitegen.Column = "document_changes.resource"
+)
+
+// Table document_changes. Plain strings.
+const (
+	T_DocumentChanges         = "document_changes"
+	C_DocumentChangesChange   = "document_changes.change"
+	C_DocumentChangesResource = "document_changes.resource"
+)
+
+// Table document_states.
+const (
+	DocumentStates              sqlitegen.Table  = "document_states"
+	DocumentStatesAuthors       sqlitegen.Column = "document_states.authors"
+	DocumentStatesChangeCount   sqlitegen.Column = "document_states.change_count"
+	DocumentStatesCreateTime    sqlitegen.Column = "document_states.create_time"
+	DocumentStatesMetadata      sqlitegen.Column = "document_states.metadata"
+	DocumentStatesMetadataState sqlitegen.Column = "document_states.metadata_state"
+	DocumentStatesResource      sqlitegen.Column = "document_states.resource"
+	DocumentStatesUpdateTime    sqlitegen.Column = "document_states.update_time"
+	DocumentStatesVersion       sqlitegen.Column = "document_states.version"
+)
+
+// Table document_states. Plain strings.
+const (
+	T_DocumentStates              = "document_states"
+	C_DocumentStatesAuthors       = "document_states.authors"
+	C_DocumentStatesChangeCount   = "document_states.change_count"
+	C_DocumentStatesCreateTime    = "document_states.create_time"
+	C_DocumentStatesMetadata      = "document_states.metadata"
+	C_DocumentStatesMetadataState = "document_states.metadata_state"
+	C_DocumentStatesResource      = "document_states.resource"
+	C_DocumentStatesUpdateTime    = "document_states.update_time"
+	C_DocumentStatesVersion       = "document_states.version"
+)
+
+// Table fts.
+const (
+	Fts           sqlitegen.Table  = "fts"
+	FtsFts        sqlitegen.Column = "fts.fts"
+	FtsRank       sqlitegen.Column = "fts.rank"
+	FtsRawContent sqlitegen.Column = "fts.raw_content"
+)
+
+// Table fts. Plain strings.
+const (
+	T_Fts           = "fts"
+	C_FtsFts        = "fts.fts"
+	C_FtsRank       = "fts.rank"
+	C_FtsRawContent = "fts.raw_content"
+)
+
+// Table fts_config.
+const (
+	FtsConfig  sqlitegen.Table  = "fts_config"
+	FtsConfigK sqlitegen.Column = "fts_config.k"
+	FtsConfigV sqlitegen.Column = "fts_config.v"
+)
+
+// Table fts_config. Plain strings.
+const (
+	T_FtsConfig  = "fts_config"
+	C_FtsConfigK = "fts_config.k"
+	C_FtsConfigV = "fts_config.v"
+)
+
+// Table fts_data.
+const (
+	FtsData      sqlitegen.Table  = "fts_data"
+	FtsDataBlock sqlitegen.Column = "fts_data.block"
+	FtsDataID    sqlitegen.Column = "fts_data.id"
+)
+
+// Table fts_data. Plain strings.
+const (
+	T_FtsData      = "fts_data"
+	C_FtsDataBlock = "fts_data.block"
+	C_FtsDataID    = "fts_data.id"
+)
+
+// Table fts_docsize.
+const (
+	FtsDocsize   sqlitegen.Table  = "fts_docsize"
+	FtsDocsizeID sqlitegen.Column = "fts_docsize.id"
+	FtsDocsizeSz sqlitegen.Column = "fts_docsize.sz"
+)
+
+// Table fts_docsize. Plain strings.
+const (
+	T_FtsDocsize   = "fts_docsize"
+	C_FtsDocsizeID = "fts_docsize.id"
+	C_FtsDocsizeSz = "fts_docsize.sz"
+)
+
+// Table fts_idx.
+const (
+	FtsIdx      sqlitegen.Table  = "fts_idx"
+	FtsIdxPgno  sqlitegen.Column = "fts_idx.pgno"
+	FtsIdxSegid sqlitegen.Column = "fts_idx.segid"
+	FtsIdxTerm  sqlitegen.Column = "fts_idx.term"
+)
+
+// Table fts_idx. Plain strings.
+const (
+	T_FtsIdx      = "fts_idx"
+	C_FtsIdxPgno  = "fts_idx.pgno"
+	C_FtsIdxSegid = "fts_idx.segid"
+	C_FtsIdxTerm  = "fts_idx.term"
+)
+
+// Table fts_index.
+const (
+	FtsIndex           sqlitegen.Table  = "fts_index"
+	FtsIndexBlobID     sqlitegen.Column = "fts_index.blob_id"
+	FtsIndexID         sqlitegen.Column = "fts_index.id"
+	FtsIndexKey        sqlitegen.Column = "fts_index.key"
+	FtsIndexRawContent sqlitegen.Column = "fts_index.raw_content"
+	FtsIndexResource   sqlitegen.Column = "fts_index.resource"
+	FtsIndexTs         sqlitegen.Column = "fts_index.ts"
+	FtsIndexType       sqlitegen.Column = "fts_index.type"
+)
+
+// Table fts_index. Plain strings.
+const (
+	T_FtsIndex           = "fts_index"
+	C_FtsIndexBlobID     = "fts_index.blob_id"
+	C_FtsIndexID         = "fts_index.id"
+	C_FtsIndexKey        = "fts_index.key"
+	C_FtsIndexRawContent = "fts_index.raw_content"
+	C_FtsIndexResource   = "fts_index.resource"
+	C_FtsIndexTs         = "fts_index.ts"
+	C_FtsIndexType       = "fts_index.type"
+)
+
 // Table kv.
 const (
 	KV      sqlitegen.Table  = "kv"
@@ -122,6 +274,24 @@ const (
 	C_PublicKeysPrincipal = "public_keys.principal"
 )
 
+// Table rbsr_blobs.
+const (
+	RbsrBlobs        sqlitegen.Table  = "rbsr_blobs"
+	RbsrBlobsBlob    sqlitegen.Column = "rbsr_blobs.blob"
+	RbsrBlobsCID     sqlitegen.Column = "rbsr_blobs.cid"
+	RbsrBlobsDeleted sqlitegen.Column = "rbsr_blobs.deleted"
+	RbsrBlobsTs      sqlitegen.Column = "rbsr_blobs.ts"
+)
+
+// Table rbsr_blobs. Plain strings.
+const (
+	T_RbsrBlobs        = "rbsr_blobs"
+	C_RbsrBlobsBlob    = "rbsr_blobs.blob"
+	C_RbsrBlobsCID     = "rbsr_blobs.cid"
+	C_RbsrBlobsDeleted = "rbsr_blobs.deleted"
+	C_RbsrBlobsTs      = "rbsr_blobs.ts"
+)
+
 // Table resource_links.
 const (
 	ResourceLinks           sqlitegen.Table  = "resource_links"
@@ -202,22 +372,52 @@ const (
 	C_StructuralBlobsType        = "structural_blobs.type"
 )
 
+// Table subscription_syncs.
+const (
+	SubscriptionSyncs             sqlitegen.Table  = "subscription_syncs"
+	SubscriptionSyncsBlobsFetched sqlitegen.Column = "subscription_syncs.blobs_fetched"
+	SubscriptionSyncsHasContent   sqlitegen.Column = "subscription_syncs.has_content"
+	SubscriptionSyncsLastError    sqlitegen.Column = "subscription_syncs.last_error"
+	SubscriptionSyncsLastOkTime   sqlitegen.Column = "subscription_syncs.last_ok_time"
+	SubscriptionSyncsPeer         sqlitegen.Column = "subscription_syncs.peer"
+	SubscriptionSyncsSubscription sqlitegen.Column = "subscription_syncs.subscription"
+	SubscriptionSyncsUpdateTime   sqlitegen.Column = "subscription_syncs.update_time"
+)
+
+// Table subscription_syncs. Plain strings.
+const (
+	T_SubscriptionSyncs             = "subscription_syncs"
+	C_SubscriptionSyncsBlobsFetched = "subscription_syncs.blobs_fetched"
+	C_SubscriptionSyncsHasContent   = "subscription_syncs.has_content"
+	C_SubscriptionSyncsLastError    = "subscription_syncs.last_error"
+	C_SubscriptionSyncsLastOkTime   = "subscription_syncs.last_ok_time"
+	C_SubscriptionSyncsPeer         = "subscription_syncs.peer"
+	C_SubscriptionSyncsSubscription = "subscription_syncs.subscription"
+	C_SubscriptionSyncsUpdateTime   = "subscription_syncs.update_time"
+)
+
 // Table subscriptions.
 const (
 	Subscriptions            sqlitegen.Table  = "subscriptions"
+	SubscriptionsAuthors     sqlitegen.Column = "subscriptions.authors"
+	SubscriptionsBlobTypes   sqlitegen.Column = "subscriptions.blob_types"
 	SubscriptionsID          sqlitegen.Column = "subscriptions.id"
 	SubscriptionsInsertTime  sqlitegen.Column = "subscriptions.insert_time"
 	SubscriptionsIRI         sqlitegen.Column = "subscriptions.iri"
 	SubscriptionsIsRecursive sqlitegen.Column = "subscriptions.is_recursive"
+	SubscriptionsMaxDepth    sqlitegen.Column = "subscriptions.max_depth"
 )
 
 // Table subscriptions. Plain strings.
 const (
 	T_Subscriptions            = "subscriptions"
+	C_SubscriptionsAuthors     = "subscriptions.authors"
+	C_SubscriptionsBlobTypes   = "subscriptions.blob_types"
 	C_SubscriptionsID          = "subscriptions.id"
 	C_SubscriptionsInsertTime  = "subscriptions.insert_time"
 	C_SubscriptionsIRI         = "subscriptions.iri"
 	C_SubscriptionsIsRecursive = "subscriptions.is_recursive"
+	C_SubscriptionsMaxDepth    = "subscriptions.max_depth"
 )
 
 // Table wallets.
@@ -246,63 +446,179 @@ const (
 	C_WalletsType     = "wallets.type"
 )
 
+// Table webhook_deliveries.
+const (
+	WebhookDeliveries                sqlitegen.Table  = "webhook_deliveries"
+	WebhookDeliveriesAttempts        sqlitegen.Column = "webhook_deliveries.attempts"
+	WebhookDeliveriesBlob            sqlitegen.Column = "webhook_deliveries.blob"
+	WebhookDeliveriesCreateTime      sqlitegen.Column = "webhook_deliveries.create_time"
+	WebhookDeliveriesError           sqlitegen.Column = "webhook_deliveries.error"
+	WebhookDeliveriesID              sqlitegen.Column = "webhook_deliveries.id"
+	WebhookDeliveriesNextAttemptTime sqlitegen.Column = "webhook_deliveries.next_attempt_time"
+	WebhookDeliveriesPayload         sqlitegen.Column = "webhook_deliveries.payload"
+	WebhookDeliveriesResponseCode    sqlitegen.Column = "webhook_deliveries.response_code"
+	WebhookDeliveriesStatus          sqlitegen.Column = "webhook_deliveries.status"
+	WebhookDeliveriesUpdateTime      sqlitegen.Column = "webhook_deliveries.update_time"
+	WebhookDeliveriesWebhook         sqlitegen.Column = "webhook_deliveries.webhook"
+)
+
+// Table webhook_deliveries. Plain strings.
+const (
+	T_WebhookDeliveries                = "webhook_deliveries"
+	C_WebhookDeliveriesAttempts        = "webhook_deliveries.attempts"
+	C_WebhookDeliveriesBlob            = "webhook_deliveries.blob"
+	C_WebhookDeliveriesCreateTime      = "webhook_deliveries.create_time"
+	C_WebhookDeliveriesError           = "webhook_deliveries.error"
+	C_WebhookDeliveriesID              = "webhook_deliveries.id"
+	C_WebhookDeliveriesNextAttemptTime = "webhook_deliveries.next_attempt_time"
+	C_WebhookDeliveriesPayload         = "webhook_deliveries.payload"
+	C_WebhookDeliveriesResponseCode    = "webhook_deliveries.response_code"
+	C_WebhookDeliveriesStatus          = "webhook_deliveries.status"
+	C_WebhookDeliveriesUpdateTime      = "webhook_deliveries.update_time"
+	C_WebhookDeliveriesWebhook         = "webhook_deliveries.webhook"
+)
+
+// Table webhooks.
+const (
+	Webhooks           sqlitegen.Table  = "webhooks"
+	WebhooksCreateTime sqlitegen.Column = "webhooks.create_time"
+	WebhooksCursor     sqlitegen.Column = "webhooks.cursor"
+	WebhooksFilter     sqlitegen.Column = "webhooks.filter"
+	WebhooksID         sqlitegen.Column = "webhooks.id"
+	WebhooksSecret     sqlitegen.Column = "webhooks.secret"
+	WebhooksURL        sqlitegen.Column = "webhooks.url"
+)
+
+// Table webhooks. Plain strings.
+const (
+	T_Webhooks           = "webhooks"
+	C_WebhooksCreateTime = "webhooks.create_time"
+	C_WebhooksCursor     = "webhooks.cursor"
+	C_WebhooksFilter     = "webhooks.filter"
+	C_WebhooksID         = "webhooks.id"
+	C_WebhooksSecret     = "webhooks.secret"
+	C_WebhooksURL        = "webhooks.url"
+)
+
 // Schema describes SQLite columns.
 var Schema = sqlitegen.Schema{
 	Columns: map[sqlitegen.Column]sqlitegen.ColumnInfo{
-		BlobLinksSource:            {Table: BlobLinks, SQLType: "INTEGER"},
-		BlobLinksTarget:            {Table: BlobLinks, SQLType: "INTEGER"},
-		BlobLinksType:              {Table: BlobLinks, SQLType: "TEXT"},
-		BlobsCodec:                 {Table: Blobs, SQLType: "INTEGER"},
-		BlobsData:                  {Table: Blobs, SQLType: "BLOB"},
-		BlobsID:                    {Table: Blobs, SQLType: "INTEGER"},
-		BlobsInsertTime:            {Table: Blobs, SQLType: "INTEGER"},
-		BlobsMultihash:             {Table: Blobs, SQLType: "BLOB"},
-		BlobsSize:                  {Table: Blobs, SQLType: "INTEGER"},
-		DeletedResourcesDeleteTime: {Table: DeletedResources, SQLType: "INTEGER"},
-		DeletedResourcesExtraAttrs: {Table: DeletedResources, SQLType: "JSONB"},
-		DeletedResourcesIRI:        {Table: DeletedResources, SQLType: "TEXT"},
-		DeletedResourcesReason:     {Table: DeletedResources, SQLType: "TEXT"},
-		KVKey:                      {Table: KV, SQLType: "TEXT"},
-		KVValue:                    {Table: KV, SQLType: "TEXT"},
-		MetaViewExtraAttrs:         {Table: MetaView, SQLType: "JSONB"},
-		MetaViewIRI:                {Table: MetaView, SQLType: "TEXT"},
-		MetaViewPrincipal:          {Table: MetaView, SQLType: "BLOB"},
-		PeersAddresses:             {Table: Peers, SQLType: "TEXT"},
-		PeersID:                    {Table: Peers, SQLType: "INTEGER"},
-		PeersPid:                   {Table: Peers, SQLType: "TEXT"},
-		PublicKeysID:               {Table: PublicKeys, SQLType: "INTEGER"},
-		PublicKeysPrincipal:        {Table: PublicKeys, SQLType: "BLOB"},
-		ResourceLinksExtraAttrs:    {Table: ResourceLinks, SQLType: "JSONB"},
-		ResourceLinksID:            {Table: ResourceLinks, SQLType: "INTEGER"},
-		ResourceLinksIsPinned:      {Table: ResourceLinks, SQLType: "INTEGER"},
-		ResourceLinksSource:        {Table: ResourceLinks, SQLType: "INTEGER"},
-		ResourceLinksTarget:        {Table: ResourceLinks, SQLType: "INTEGER"},
-		ResourceLinksType:          {Table: ResourceLinks, SQLType: "TEXT"},
-		ResourcesCreateTime:        {Table: Resources, SQLType: "INTEGER"},
-		ResourcesGenesisBlob:       {Table: Resources, SQLType: "INTEGER"},
-		ResourcesID:                {Table: Resources, SQLType: "INTEGER"},
-		ResourcesIRI:               {Table: Resources, SQLType: "TEXT"},
-		ResourcesOwner:             {Table: Resources, SQLType: "INTEGER"},
-		SQLiteSequenceName:         {Table: SQLiteSequence, SQLType: ""},
-		SQLiteSequenceSeq:          {Table: SQLiteSequence, SQLType: ""},
-		StructuralBlobsAuthor:      {Table: StructuralBlobs, SQLType: "INTEGER"},
-		StructuralBlobsExtraAttrs:  {Table: StructuralBlobs, SQLType: "JSONB"},
-		StructuralBlobsGenesisBlob: {Table: StructuralBlobs, SQLType: "INTEGER"},
-		StructuralBlobsID:          {Table: StructuralBlobs, SQLType: "INTEGER"},
-		StructuralBlobsResource:    {Table: StructuralBlobs, SQLType: "INTEGER"},
-		StructuralBlobsTs:          {Table: StructuralBlobs, SQLType: "INTEGER"},
-		StructuralBlobsType:        {Table: StructuralBlobs, SQLType: "TEXT"},
-		SubscriptionsID:            {Table: Subscriptions, SQLType: "INTEGER"},
-		SubscriptionsInsertTime:    {Table: Subscriptions, SQLType: "INTEGER"},
-		SubscriptionsIRI:           {Table: Subscriptions, SQLType: "TEXT"},
-		SubscriptionsIsRecursive:   {Table: Subscriptions, SQLType: "BOOLEAN"},
-		WalletsAddress:             {Table: Wallets, SQLType: "TEXT"},
-		WalletsBalance:             {Table: Wallets, SQLType: "INTEGER"},
-		WalletsID:                  {Table: Wallets, SQLType: "TEXT"},
-		WalletsLogin:               {Table: Wallets, SQLType: "BLOB"},
-		WalletsName:                {Table: Wallets, SQLType: "TEXT"},
-		WalletsPassword:            {Table: Wallets, SQLType: "BLOB"},
-		WalletsToken:               {Table: Wallets, SQLType: "BLOB"},
-		WalletsType:                {Table: Wallets, SQLType: "TEXT"},
+		BlobLinksSource:                  {Table: BlobLinks, SQLType: "INTEGER"},
+		BlobLinksTarget:                  {Table: BlobLinks, SQLType: "INTEGER"},
+		BlobLinksType:                    {Table: BlobLinks, SQLType: "TEXT"},
+		BlobsCodec:                       {Table: Blobs, SQLType: "INTEGER"},
+		BlobsData:                        {Table: Blobs, SQLType: "BLOB"},
+		BlobsID:                          {Table: Blobs, SQLType: "INTEGER"},
+		BlobsInsertTime:                  {Table: Blobs, SQLType: "INTEGER"},
+		BlobsMultihash:                   {Table: Blobs, SQLType: "BLOB"},
+		BlobsSize:                        {Table: Blobs, SQLType: "INTEGER"},
+		DeletedBlobsBlob:                 {Table: DeletedBlobs, SQLType: "INTEGER"},
+		DeletedBlobsIRI:                  {Table: DeletedBlobs, SQLType: "TEXT"},
+		DeletedResourcesDeleteTime:       {Table: DeletedResources, SQLType: "INTEGER"},
+		DeletedResourcesExtraAttrs:       {Table: DeletedResources, SQLType: "JSONB"},
+		DeletedResourcesIRI:              {Table: DeletedResources, SQLType: "TEXT"},
+		DeletedResourcesReason:           {Table: DeletedResources, SQLType: "TEXT"},
+		DocumentChangesChange:            {Table: DocumentChanges, SQLType: "INTEGER"},
+		DocumentChangesResource:          {Table: DocumentChanges, SQLType: "INTEGER"},
+		DocumentStatesAuthors:            {Table: DocumentStates, SQLType: "JSONB"},
+		DocumentStatesChangeCount:        {Table: DocumentStates, SQLType: "INTEGER"},
+		DocumentStatesCreateTime:         {Table: DocumentStates, SQLType: "INTEGER"},
+		DocumentStatesMetadata:           {Table: DocumentStates, SQLType: "JSONB"},
+		DocumentStatesMetadataState:      {Table: DocumentStates, SQLType: "JSONB"},
+		DocumentStatesResource:           {Table: DocumentStates, SQLType: "INTEGER"},
+		DocumentStatesUpdateTime:         {Table: DocumentStates, SQLType: "INTEGER"},
+		DocumentStatesVersion:            {Table: DocumentStates, SQLType: "TEXT"},
+		FtsFts:                           {Table: Fts, SQLType: ""},
+		FtsRank:                          {Table: Fts, SQLType: ""},
+		FtsRawContent:                    {Table: Fts, SQLType: ""},
+		FtsConfigK:                       {Table: FtsConfig, SQLType: ""},
+		FtsConfigV:                       {Table: FtsConfig, SQLType: ""},
+		FtsDataBlock:                     {Table: FtsData, SQLType: "BLOB"},
+		FtsDataID:                        {Table: FtsData, SQLType: "INTEGER"},
+		FtsDocsizeID:                     {Table: FtsDocsize, SQLType: "INTEGER"},
+		FtsDocsizeSz:                     {Table: FtsDocsize, SQLType: "BLOB"},
+		FtsIdxPgno:                       {Table: FtsIdx, SQLType: ""},
+		FtsIdxSegid:                      {Table: FtsIdx, SQLType: ""},
+		FtsIdxTerm:                       {Table: FtsIdx, SQLType: ""},
+		FtsIndexBlobID:                   {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexID:                       {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexKey:                      {Table: FtsIndex, SQLType: "TEXT"},
+		FtsIndexRawContent:               {Table: FtsIndex, SQLType: "TEXT"},
+		FtsIndexResource:                 {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexTs:                       {Table: FtsIndex, SQLType: "INTEGER"},
+		FtsIndexType:                     {Table: FtsIndex, SQLType: "TEXT"},
+		KVKey:                            {Table: KV, SQLType: "TEXT"},
+		KVValue:                          {Table: KV, SQLType: "TEXT"},
+		MetaViewExtraAttrs:               {Table: MetaView, SQLType: "JSONB"},
+		MetaViewIRI:                      {Table: MetaView, SQLType: "TEXT"},
+		MetaViewPrincipal:                {Table: MetaView, SQLType: "BLOB"},
+		PeersAddresses:                   {Table: Peers, SQLType: "TEXT"},
+		PeersID:                          {Table: Peers, SQLType: "INTEGER"},
+		PeersPid:                         {Table: Peers, SQLType: "TEXT"},
+		PublicKeysID:                     {Table: PublicKeys, SQLType: "INTEGER"},
+		PublicKeysPrincipal:              {Table: PublicKeys, SQLType: "BLOB"},
+		RbsrBlobsBlob:                    {Table: RbsrBlobs, SQLType: "INTEGER"},
+		RbsrBlobsCID:                     {Table: RbsrBlobs, SQLType: "BLOB"},
+		RbsrBlobsDeleted:                 {Table: RbsrBlobs, SQLType: "BOOLEAN"},
+		RbsrBlobsTs:                      {Table: RbsrBlobs, SQLType: "INTEGER"},
+		ResourceLinksExtraAttrs:          {Table: ResourceLinks, SQLType: "JSONB"},
+		ResourceLinksID:                  {Table: ResourceLinks, SQLType: "INTEGER"},
+		ResourceLinksIsPinned:            {Table: ResourceLinks, SQLType: "INTEGER"},
+		ResourceLinksSource:              {Table: ResourceLinks, SQLType: "INTEGER"},
+		ResourceLinksTarget:              {Table: ResourceLinks, SQLType: "INTEGER"},
+		ResourceLinksType:                {Table: ResourceLinks, SQLType: "TEXT"},
+		ResourcesCreateTime:              {Table: Resources, SQLType: "INTEGER"},
+		ResourcesGenesisBlob:             {Table: Resources, SQLType: "INTEGER"},
+		ResourcesID:                      {Table: Resources, SQLType: "INTEGER"},
+		ResourcesIRI:                     {Table: Resources, SQLType: "TEXT"},
+		ResourcesOwner:                   {Table: Resources, SQLType: "INTEGER"},
+		SQLiteSequenceName:               {Table: SQLiteSequence, SQLType: ""},
+		SQLiteSequenceSeq:                {Table: SQLiteSequence, SQLType: ""},
+		StructuralBlobsAuthor:            {Table: StructuralBlobs, SQLType: "INTEGER"},
+		StructuralBlobsExtraAttrs:        {Table: StructuralBlobs, SQLType: "JSONB"},
+		StructuralBlobsGenesisBlob:       {Table: StructuralBlobs, SQLType: "INTEGER"},
+		StructuralBlobsID:                {Table: StructuralBlobs, SQLType: "INTEGER"},
+		StructuralBlobsResource:          {Table: StructuralBlobs, SQLType: "INTEGER"},
+		StructuralBlobsTs:                {Table: StructuralBlobs, SQLType: "INTEGER"},
+		StructuralBlobsType:              {Table: StructuralBlobs, SQLType: "TEXT"},
+		SubscriptionSyncsBlobsFetched:    {Table: SubscriptionSyncs, SQLType: "INTEGER"},
+		SubscriptionSyncsHasContent:      {Table: SubscriptionSyncs, SQLType: "BOOLEAN"},
+		SubscriptionSyncsLastError:       {Table: SubscriptionSyncs, SQLType: "TEXT"},
+		SubscriptionSyncsLastOkTime:      {Table: SubscriptionSyncs, SQLType: "INTEGER"},
+		SubscriptionSyncsPeer:            {Table: SubscriptionSyncs, SQLType: "TEXT"},
+		SubscriptionSyncsSubscription:    {Table: SubscriptionSyncs, SQLType: "INTEGER"},
+		SubscriptionSyncsUpdateTime:      {Table: SubscriptionSyncs, SQLType: "INTEGER"},
+		SubscriptionsAuthors:             {Table: Subscriptions, SQLType: "JSONB"},
+		SubscriptionsBlobTypes:           {Table: Subscriptions, SQLType: "JSONB"},
+		SubscriptionsID:                  {Table: Subscriptions, SQLType: "INTEGER"},
+		SubscriptionsInsertTime:          {Table: Subscriptions, SQLType: "INTEGER"},
+		SubscriptionsIRI:                 {Table: Subscriptions, SQLType: "TEXT"},
+		SubscriptionsIsRecursive:         {Table: Subscriptions, SQLType: "BOOLEAN"},
+		SubscriptionsMaxDepth:            {Table: Subscriptions, SQLType: "INTEGER"},
+		WalletsAddress:                   {Table: Wallets, SQLType: "TEXT"},
+		WalletsBalance:                   {Table: Wallets, SQLType: "INTEGER"},
+		WalletsID:                        {Table: Wallets, SQLType: "TEXT"},
+		WalletsLogin:                     {Table: Wallets, SQLType: "BLOB"},
+		WalletsName:                      {Table: Wallets, SQLType: "TEXT"},
+		WalletsPassword:                  {Table: Wallets, SQLType: "BLOB"},
+		WalletsToken:                     {Table: Wallets, SQLType: "BLOB"},
+		WalletsType:                      {Table: Wallets, SQLType: "TEXT"},
+		WebhookDeliveriesAttempts:        {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhookDeliveriesBlob:            {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhookDeliveriesCreateTime:      {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhookDeliveriesError:           {Table: WebhookDeliveries, SQLType: "TEXT"},
+		WebhookDeliveriesID:              {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhookDeliveriesNextAttemptTime: {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhookDeliveriesPayload:         {Table: WebhookDeliveries, SQLType: "TEXT"},
+		WebhookDeliveriesResponseCode:    {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhookDeliveriesStatus:          {Table: WebhookDeliveries, SQLType: "TEXT"},
+		WebhookDeliveriesUpdateTime:      {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhookDeliveriesWebhook:         {Table: WebhookDeliveries, SQLType: "INTEGER"},
+		WebhooksCreateTime:               {Table: Webhooks, SQLType: "INTEGER"},
+		WebhooksCursor:                   {Table: Webhooks, SQLType: "INTEGER"},
+		WebhooksFilter:                   {Table: Webhooks, SQLType: "TEXT"},
+		WebhooksID:                       {Table: Webhooks, SQLType: "INTEGER"},
+		WebhooksSecret:                   {Table: Webhooks, SQLType: "TEXT"},
+		WebhooksURL:                      {Table: Webhooks, SQLType: "TEXT"},
 	},
 }
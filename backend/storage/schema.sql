@@ -139,6 +139,33 @@ CREATE TABLE deleted_resources (
     extra_attrs JSONB
 );
 
+-- Stores the blobs that were removed locally along with a deleted resource.
+-- We keep the blob records without data, so that we don't fetch them again
+-- during syncing, until the resource is undeleted.
+CREATE TABLE deleted_blobs (
+    blob INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    iri TEXT REFERENCES deleted_resources (iri) ON DELETE CASCADE NOT NULL
+) WITHOUT ROWID;
+
+CREATE INDEX deleted_blobs_by_iri ON deleted_blobs (iri);
+
+-- Stores the set of blobs for the range-based set reconciliation (RBSR),
+-- ordered the same way as the reconciliation items, to avoid sorting all the blobs on every sync.
+-- Maintained by the indexer.
+CREATE TABLE rbsr_blobs (
+    -- Timestamp of the reconciliation item. It's the local insert time of the blob.
+    ts INTEGER NOT NULL,
+    -- Binary CID of the blob.
+    cid BLOB NOT NULL,
+    blob INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    -- Whether the blob belongs to a locally deleted resource.
+    -- We pretend to have these blobs when syncing, but we don't offer them to other peers.
+    deleted BOOLEAN DEFAULT false NOT NULL,
+    PRIMARY KEY (ts, cid)
+) WITHOUT ROWID;
+
+CREATE INDEX rbsr_blobs_by_blob ON rbsr_blobs (blob);
+
 -- Stores content-addressable links between blobs.
 -- Links are typed (rel) and directed.
 CREATE TABLE blob_links (
@@ -169,6 +196,80 @@ CREATE TABLE resource_links (
 CREATE INDEX resource_links_by_source ON resource_links (source, is_pinned, target);
 CREATE INDEX resource_links_by_target ON resource_links (target, source);
 
+-- Stores the materialized state of the latest version of each document,
+-- derived from all the changes we know about.
+-- Allows reading document attributes without replaying all the changes.
+CREATE TABLE document_states (
+    resource INTEGER PRIMARY KEY REFERENCES resources (id) ON DELETE CASCADE NOT NULL,
+    -- Version of the document: sorted CIDs of the head changes separated by dots.
+    version TEXT NOT NULL,
+    -- JSON object with the metadata attributes of the document.
+    metadata JSONB NOT NULL,
+    -- JSON array with the sorted principals of the authors of all the changes.
+    authors JSONB NOT NULL,
+    -- HLC timestamp of the first change (see hlc.Timestamp).
+    create_time INTEGER NOT NULL,
+    -- HLC timestamp of the latest change (see hlc.Timestamp).
+    update_time INTEGER NOT NULL,
+    -- JSON array with the nodes of the CRDT map used to merge the metadata,
+    -- to be able to apply new changes without replaying the whole history.
+    -- NULL if the state must be rebuilt from scratch.
+    metadata_state JSONB,
+    -- Number of changes included in the state.
+    change_count INTEGER DEFAULT 0 NOT NULL
+) WITHOUT ROWID;
+
+-- Stores the changes applied to the materialized state of each document.
+-- Only the changes from the authorized writers are applied.
+CREATE TABLE document_changes (
+    resource INTEGER REFERENCES resources (id) ON DELETE CASCADE NOT NULL,
+    change INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    PRIMARY KEY (resource, change)
+) WITHOUT ROWID;
+
+CREATE INDEX document_changes_by_change ON document_changes (change);
+
+-- Stores the text content extracted from document changes for full-text search.
+-- Each change produces one row for every block or metadata value it sets.
+CREATE TABLE fts_index (
+    id INTEGER PRIMARY KEY,
+    -- The change blob where the content comes from.
+    blob_id INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+    -- Type of the content: 'block' or 'metadata'.
+    type TEXT NOT NULL,
+    -- Block ID for block content, or metadata key for metadata content.
+    key TEXT NOT NULL,
+    -- Timestamp of the change in microseconds.
+    -- Used to find the latest content for each key.
+    ts INTEGER NOT NULL,
+    -- The indexed text.
+    raw_content TEXT NOT NULL,
+    -- The document the content is found in.
+    -- Set when the change is applied to the document state,
+    -- i.e. only for the changes from the authorized writers.
+    resource INTEGER REFERENCES resources (id) ON DELETE SET NULL
+);
+
+CREATE INDEX fts_index_by_blob ON fts_index (blob_id);
+CREATE INDEX fts_index_by_resource ON fts_index (resource, type, key, ts);
+
+-- Full-text search index over the content of the fts_index table.
+CREATE VIRTUAL TABLE fts USING fts5(
+    raw_content,
+    content = 'fts_index',
+    content_rowid = 'id',
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+-- Keeping the full-text index in sync with the content table.
+CREATE TRIGGER fts_index_after_insert AFTER INSERT ON fts_index BEGIN
+    INSERT INTO fts (rowid, raw_content) VALUES (new.id, new.raw_content);
+END;
+
+CREATE TRIGGER fts_index_after_delete AFTER DELETE ON fts_index BEGIN
+    INSERT INTO fts (fts, rowid, raw_content) VALUES ('delete', old.id, old.raw_content);
+END;
+
 -- Stores subscribed resources. Once we subscribe to a resource,
 -- we will sync the latest versions of it periodically.
 CREATE TABLE subscriptions (
@@ -178,9 +279,74 @@ CREATE TABLE subscriptions (
     -- Whether we subscribe recursively to all documents in the directory or not
     is_recursive BOOLEAN DEFAULT false NOT NULL,
     -- The time when the resource was subscribed.
-    insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+    insert_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+    -- Maximum depth of the documents to sync for recursive subscriptions. 0 means no limit.
+    max_depth INTEGER DEFAULT 0 NOT NULL,
+    -- JSON array with the content blob types to sync. Empty means all.
+    blob_types JSONB DEFAULT '[]' NOT NULL,
+    -- JSON array with the accounts whose content blobs we sync. Empty means all.
+    authors JSONB DEFAULT '[]' NOT NULL
 );
 
+-- Stores the outcome of the last sync of each subscription with each peer.
+CREATE TABLE subscription_syncs (
+    subscription INTEGER REFERENCES subscriptions (id) ON DELETE CASCADE NOT NULL,
+    -- Peer ID of the remote peer.
+    peer TEXT NOT NULL,
+    -- The time of the last successful sync with the peer. 0 if never succeeded.
+    last_ok_time INTEGER DEFAULT 0 NOT NULL,
+    -- Error of the last sync attempt. Empty if it succeeded.
+    last_error TEXT DEFAULT '' NOT NULL,
+    -- Whether the peer had any of the subscribed content in the last successful sync.
+    has_content BOOLEAN DEFAULT false NOT NULL,
+    -- Number of blobs fetched from the peer in the last sync attempt.
+    blobs_fetched INTEGER DEFAULT 0 NOT NULL,
+    -- The time of the last sync attempt.
+    update_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+    PRIMARY KEY (subscription, peer)
+) WITHOUT ROWID;
+
+-- Stores outgoing webhooks for activity events.
+CREATE TABLE webhooks (
+    id INTEGER PRIMARY KEY,
+    -- The HTTP endpoint where the events are delivered.
+    url TEXT NOT NULL,
+    -- The secret key used to sign the payloads.
+    secret TEXT NOT NULL,
+    -- The event filter in the JSON-encoded protobuf format.
+    filter TEXT NOT NULL,
+    -- The ID of the last blob that was checked for matching events.
+    cursor INTEGER NOT NULL,
+    -- The time when the webhook was registered.
+    create_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+);
+
+-- Stores the delivery log of the webhooks.
+-- Delivered and failed deliveries are removed after the retention period.
+CREATE TABLE webhook_deliveries (
+    id INTEGER PRIMARY KEY,
+    webhook INTEGER REFERENCES webhooks (id) ON DELETE CASCADE NOT NULL,
+    -- The blob the event was created from.
+    blob INTEGER REFERENCES blobs (id) ON DELETE CASCADE NOT NULL,
+    -- The JSON payload sent to the endpoint.
+    payload TEXT NOT NULL,
+    status TEXT CHECK( status IN ('pending', 'delivered', 'failed') ) DEFAULT 'pending' NOT NULL,
+    -- Number of attempts made to deliver the payload.
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    -- HTTP status code of the last attempt. 0 if the request failed before getting a response.
+    response_code INTEGER DEFAULT 0 NOT NULL,
+    -- Error of the last failed attempt.
+    error TEXT DEFAULT '' NOT NULL,
+    create_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+    update_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+    -- The time when pending deliveries must be attempted next.
+    next_attempt_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+    UNIQUE (webhook, blob)
+);
+
+CREATE INDEX webhook_deliveries_by_status ON webhook_deliveries (status, next_attempt_time);
+CREATE INDEX webhook_deliveries_by_blob ON webhook_deliveries (blob);
+
 -- Stores seed peers we know about.
 CREATE TABLE peers (
     -- Internal index used for pagination
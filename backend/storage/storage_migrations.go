@@ -11,6 +11,7 @@ import (
 	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
 
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/crypto"
 
 	"golang.org/x/exp/slices"
@@ -74,6 +75,250 @@ var migrations = []migration{
 
 		return nil
 	}},
+	{Version: "2024-09-17.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS fts_index (
+				id INTEGER PRIMARY KEY,
+				blob_id INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				type TEXT NOT NULL,
+				key TEXT NOT NULL,
+				ts INTEGER NOT NULL,
+				raw_content TEXT NOT NULL
+			);
+
+			CREATE INDEX IF NOT EXISTS fts_index_by_blob ON fts_index (blob_id);
+			CREATE INDEX IF NOT EXISTS fts_index_by_key ON fts_index (key, type, ts);
+
+			CREATE VIRTUAL TABLE IF NOT EXISTS fts USING fts5(
+				raw_content,
+				content = 'fts_index',
+				content_rowid = 'id',
+				tokenize = 'unicode61 remove_diacritics 2'
+			);
+
+			CREATE TRIGGER IF NOT EXISTS fts_index_after_insert AFTER INSERT ON fts_index BEGIN
+				INSERT INTO fts (rowid, raw_content) VALUES (new.id, new.raw_content);
+			END;
+
+			CREATE TRIGGER IF NOT EXISTS fts_index_after_delete AFTER DELETE ON fts_index BEGIN
+				INSERT INTO fts (fts, rowid, raw_content) VALUES ('delete', old.id, old.raw_content);
+			END;
+
+			-- Forcing reindexing to populate the full-text index with the existing content.
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`)); err != nil {
+			return err
+		}
+
+		return nil
+	}},
+	{Version: "2024-09-20.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS document_states (
+				resource INTEGER PRIMARY KEY REFERENCES resources (id) ON DELETE CASCADE NOT NULL,
+				version TEXT NOT NULL,
+				metadata JSONB NOT NULL,
+				authors JSONB NOT NULL,
+				create_time INTEGER NOT NULL,
+				update_time INTEGER NOT NULL
+			) WITHOUT ROWID;
+
+			-- Forcing reindexing to populate the document states.
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`)); err != nil {
+			return err
+		}
+
+		return nil
+	}},
+	{Version: "2024-09-24.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS deleted_blobs (
+				blob INTEGER PRIMARY KEY REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				iri TEXT REFERENCES deleted_resources (iri) ON DELETE CASCADE NOT NULL
+			) WITHOUT ROWID;
+
+			CREATE INDEX IF NOT EXISTS deleted_blobs_by_iri ON deleted_blobs (iri);
+		`)); err != nil {
+			return err
+		}
+
+		return nil
+	}},
+	{Version: "2024-09-25.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS webhooks (
+				id INTEGER PRIMARY KEY,
+				url TEXT NOT NULL,
+				secret TEXT NOT NULL,
+				filter TEXT NOT NULL,
+				cursor INTEGER NOT NULL,
+				create_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL
+			);
+
+			CREATE TABLE IF NOT EXISTS webhook_deliveries (
+				id INTEGER PRIMARY KEY,
+				webhook INTEGER REFERENCES webhooks (id) ON DELETE CASCADE NOT NULL,
+				blob INTEGER REFERENCES blobs (id) ON DELETE CASCADE NOT NULL,
+				payload TEXT NOT NULL,
+				status TEXT CHECK( status IN ('pending', 'delivered', 'failed') ) DEFAULT 'pending' NOT NULL,
+				attempts INTEGER DEFAULT 0 NOT NULL,
+				response_code INTEGER DEFAULT 0 NOT NULL,
+				error TEXT DEFAULT '' NOT NULL,
+				create_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+				update_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+				next_attempt_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+				UNIQUE (webhook, blob)
+			);
+
+			CREATE INDEX IF NOT EXISTS webhook_deliveries_by_status ON webhook_deliveries (status, next_attempt_time);
+			CREATE INDEX IF NOT EXISTS webhook_deliveries_by_blob ON webhook_deliveries (blob);
+		`)); err != nil {
+			return err
+		}
+
+		return nil
+	}},
+	{Version: "2024-09-26.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			ALTER TABLE subscriptions ADD COLUMN blob_types TEXT DEFAULT '' NOT NULL;
+			ALTER TABLE subscriptions ADD COLUMN authors TEXT DEFAULT '' NOT NULL;
+			ALTER TABLE subscriptions ADD COLUMN max_depth INTEGER DEFAULT 0 NOT NULL;
+		`)); err != nil {
+			return err
+		}
+
+		return nil
+	}},
+	{Version: "2024-09-27.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS subscription_syncs (
+				subscription INTEGER REFERENCES subscriptions (id) ON DELETE CASCADE NOT NULL,
+				peer TEXT NOT NULL,
+				last_ok_time INTEGER DEFAULT 0 NOT NULL,
+				last_error TEXT DEFAULT '' NOT NULL,
+				has_content BOOLEAN DEFAULT false NOT NULL,
+				blobs_fetched INTEGER DEFAULT 0 NOT NULL,
+				update_time INTEGER DEFAULT (strftime('%s', 'now')) NOT NULL,
+				PRIMARY KEY (subscription, peer)
+			) WITHOUT ROWID;
+		`)); err != nil {
+			return err
+		}
+
+		return nil
+	}},
+	{Version: "2024-09-28.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		if err := sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS rbsr_blobs (
+				ts INTEGER NOT NULL,
+				cid BLOB NOT NULL,
+				blob INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				deleted BOOLEAN DEFAULT false NOT NULL,
+				PRIMARY KEY (ts, cid)
+			) WITHOUT ROWID;
+
+			CREATE INDEX IF NOT EXISTS rbsr_blobs_by_blob ON rbsr_blobs (blob);
+		`)); err != nil {
+			return err
+		}
+
+		return populateBlobSet(conn)
+	}},
+	{Version: "2024-09-29.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		// Existing states have zero change count, so they will be rebuilt on the next update.
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			ALTER TABLE document_states ADD COLUMN metadata_state JSONB;
+			ALTER TABLE document_states ADD COLUMN change_count INTEGER DEFAULT 0 NOT NULL;
+		`))
+	}},
+	{Version: "2024-09-30.01", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			ALTER TABLE fts_index ADD COLUMN resource INTEGER REFERENCES resources (id) ON DELETE SET NULL;
+			DROP INDEX IF EXISTS fts_index_by_key;
+			CREATE INDEX IF NOT EXISTS fts_index_by_resource ON fts_index (resource, type, key, ts);
+
+			-- Forcing reindexing to attribute the existing content to the documents.
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`))
+	}},
+	{Version: "2024-09-30.02", Run: func(_ *Store, conn *sqlite.Conn) error {
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			CREATE TABLE IF NOT EXISTS document_changes (
+				resource INTEGER REFERENCES resources (id) ON DELETE CASCADE NOT NULL,
+				change INTEGER REFERENCES blobs (id) ON UPDATE CASCADE ON DELETE CASCADE NOT NULL,
+				PRIMARY KEY (resource, change)
+			) WITHOUT ROWID;
+
+			CREATE INDEX IF NOT EXISTS document_changes_by_change ON document_changes (change);
+
+			-- Forcing reindexing to record the changes of the existing documents.
+			DELETE FROM kv WHERE key = 'last_reindex_time';
+		`))
+	}},
+	{Version: "2024-09-30.03", Run: func(_ *Store, conn *sqlite.Conn) error {
+		// SQLite can't change the type of a column, so we recreate the filter columns as JSON arrays.
+		// The table itself can't be recreated without losing the sync status of the subscriptions.
+		return sqlitex.ExecScript(conn, sqlfmt(`
+			ALTER TABLE subscriptions RENAME COLUMN blob_types TO blob_types_old;
+			ALTER TABLE subscriptions RENAME COLUMN authors TO authors_old;
+			ALTER TABLE subscriptions ADD COLUMN blob_types JSONB DEFAULT '[]' NOT NULL;
+			ALTER TABLE subscriptions ADD COLUMN authors JSONB DEFAULT '[]' NOT NULL;
+
+			UPDATE subscriptions SET
+				blob_types = CASE blob_types_old WHEN '' THEN '[]' ELSE json('["' || replace(blob_types_old, ',', '","') || '"]') END,
+				authors = CASE authors_old WHEN '' THEN '[]' ELSE json('["' || replace(authors_old, ',', '","') || '"]') END;
+
+			ALTER TABLE subscriptions DROP COLUMN blob_types_old;
+			ALTER TABLE subscriptions DROP COLUMN authors_old;
+		`))
+	}},
+}
+
+// populateBlobSet fills the RBSR blob set with the blobs we have,
+// and the blobs of the locally deleted resources, which we pretend to have.
+func populateBlobSet(conn *sqlite.Conn) error {
+	type blobSetItem struct {
+		ID      int64
+		TS      int64
+		CID     cid.Cid
+		Deleted bool
+	}
+
+	var items []blobSetItem
+	if err := sqlitex.Exec(conn, sqlfmt(`
+		SELECT
+			blobs.id,
+			blobs.codec,
+			blobs.multihash,
+			blobs.insert_time,
+			blobs.id IN (SELECT blob FROM deleted_blobs)
+		FROM blobs
+		WHERE blobs.size >= 0
+		OR blobs.id IN (SELECT blob FROM deleted_blobs);
+	`), func(stmt *sqlite.Stmt) error {
+		items = append(items, blobSetItem{
+			ID:      stmt.ColumnInt64(0),
+			CID:     cid.NewCidV1(uint64(stmt.ColumnInt64(1)), stmt.ColumnBytes(2)),
+			TS:      stmt.ColumnInt64(3),
+			Deleted: stmt.ColumnInt(4) != 0,
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := sqlitex.Exec(conn, sqlfmt(`
+			INSERT INTO rbsr_blobs (ts, cid, blob, deleted)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT DO NOTHING;
+		`), nil, item.TS, item.CID.Bytes(), item.ID, item.Deleted); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func desiredVersion() string {
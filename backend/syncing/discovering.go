@@ -2,7 +2,10 @@ package syncing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	p2p "seed/backend/genproto/p2p/v1alpha"
+	"seed/backend/index"
 	"seed/backend/ipfs"
 	"seed/backend/mttnet"
 	"seed/backend/util/dqb"
@@ -11,11 +14,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
 	"github.com/multiformats/go-multicodec"
 	"go.uber.org/zap"
 )
 
+// ErrDiscoveryDisabled is returned when remote content discovery is disabled in the config.
+var ErrDiscoveryDisabled = errors.New("remote content discovery is disabled")
+
+// ErrObjectNotFound is returned when none of the peers could provide the requested object.
+var ErrObjectNotFound = errors.New("object not found")
+
 // DefaultDiscoveryTimeout is how long do we wait to discover a peer and sync with it
 const (
 	DefaultDiscoveryTimeout = time.Second * 30
@@ -26,157 +37,186 @@ const (
 // DiscoverObject discovers an object in the network. If not found, then it returns an error
 // If found, this function will store the object locally so that it can be gotten like any
 // other local object. This function blocks until either success or fails to find providers.
-func (s *Service) DiscoverObject(ctx context.Context, entityID, version string) error {
+// If version is provided, discovery only succeeds once all the heads of the version are available locally.
+// It returns the peers we've synced the object with.
+func (s *Service) DiscoverObject(ctx context.Context, entityID, version string) (peers []peer.ID, err error) {
 	if s.cfg.NoDiscovery {
-		return fmt.Errorf("remote content discovery is disabled")
+		return nil, ErrDiscoveryDisabled
+	}
+
+	heads, err := index.Version(version).Parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", version, err)
 	}
-	if version != "" {
-		return fmt.Errorf("Discovering by version is not implemented yet")
+
+	// We can't know what the latest version is without asking other peers,
+	// but if the client wants a specific version we might already have it.
+	if len(heads) > 0 {
+		ok, err := s.hasObject(ctx, entityID, heads)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			s.log.Debug("Requested version is already available locally, we avoided syncing with peers")
+			return nil, nil
+		}
 	}
 
-	ctxLocalPeers, cancel := context.WithTimeout(ctx, DefaultSyncingTimeout)
-	defer cancel()
 	c, err := ipfs.NewCID(uint64(multicodec.Raw), uint64(multicodec.Identity), []byte(entityID))
 	if err != nil {
-		return fmt.Errorf("Couldn't encode eid into CID: %w", err)
+		return nil, fmt.Errorf("Couldn't encode eid into CID: %w", err)
 	}
 
-	conn, release, err := s.db.Conn(ctxLocalPeers)
+	eids := []*p2p.Filter{{Resource: entityID}}
+
+	localPeers, err := s.listPeers(ctx)
 	if err != nil {
-		s.log.Debug("Could not grab a connection", zap.Error(err))
-		return err
+		return nil, err
 	}
-	// TODO(juligasa): Activate this once we have versions. We check for the specific version in the db
-	// If the client wants the latest we don't have other option than to sync with peers since we don't
-	// know what the latests is.
-	/*
-		var haveIt bool
-		if err = sqlitex.Exec(conn, qGetEntity(), func(stmt *sqlite.Stmt) error {
-			eid := stmt.ColumnText(0)
-			if eid != entityID {
-				return fmt.Errorf("Got a different eid")
-			}
-			haveIt = true
-			return nil
-		}, entityID); err != nil {
-			s.log.Warn("Problem finding eid before local discovery", zap.Error(err))
-		} else if haveIt {
-			s.log.Debug("It's your lucky day, the document was already in the db!. we avoided syncing with peers.")
-			return nil
-		}
-	*/
-	subsMap := make(subscriptionMap)
-	allPeers := []peer.ID{} // TODO:(juligasa): Remove this when we have providers store
-	if err = sqlitex.Exec(conn, qListPeersWithPid(), func(stmt *sqlite.Stmt) error {
-		addresStr := stmt.ColumnText(0)
-		pid := stmt.ColumnText(1)
-		addrList := strings.Split(addresStr, ",")
-		info, err := mttnet.AddrInfoFromStrings(addrList...)
-		if err != nil {
-			s.log.Warn("Can't discover from peer since it has malformed addresses", zap.String("PID", pid), zap.Error(err))
-			return nil
-		}
-		allPeers = append(allPeers, info.ID)
-		return nil
-	}); err != nil {
-		release()
-		return err
-	}
-	release()
-	if len(allPeers) != 0 {
-		s.log.Debug("Discovering via local peers first", zap.Error(err))
-		eidsMap := make(map[string]bool)
-		eidsMap[entityID] = false
-		for _, pid := range allPeers {
-			// TODO(juligasa): look into the providers store who has each eid
-			// instead of pasting all peers in all documents.
-			subsMap[pid] = eidsMap
+
+	if len(localPeers) != 0 {
+		s.log.Debug("Discovering via local peers first")
+		ctxLocalPeers, cancel := context.WithTimeout(ctx, DefaultSyncingTimeout)
+		// TODO(juligasa): look into the providers store who has each eid
+		// instead of pasting all peers in all documents.
+		subsMap := make(subscriptionMap, len(localPeers))
+		for _, pid := range localPeers {
+			subsMap[pid] = eids
 		}
 
 		ret := s.SyncWithManyPeers(ctxLocalPeers, subsMap)
-		ctxDHT, cancelDHTCtx := context.WithTimeout(ctx, DefaultDHTTimeout)
-		defer cancelDHTCtx()
+		cancel()
+		for i, pid := range ret.Peers {
+			if ret.Errs[i] == nil {
+				peers = append(peers, pid)
+			}
+		}
+
 		if ret.NumSyncOK > 0 {
-			conn, release, err := s.db.Conn(ctxDHT)
+			ok, err := s.hasObject(ctx, entityID, heads)
 			if err != nil {
-				s.log.Debug("Could not grab a connection", zap.Error(err))
-				return err
+				return nil, err
 			}
-			var haveIt bool
-			if err = sqlitex.Exec(conn, qGetEntity(), func(stmt *sqlite.Stmt) error {
-				eid := stmt.ColumnText(0)
-				if eid != entityID {
-					return fmt.Errorf("Got a different eid")
-				}
-				haveIt = true
-				return nil
-			}, entityID); err != nil {
-				s.log.Warn("Problem finding eid after local discovery", zap.Error(err))
-			} else if haveIt {
-				release()
+			if ok {
 				s.log.Debug("Discovered content via local peer, we avoided hitting the DHT!")
-				return nil
+				return peers, nil
 			}
-			release()
 		}
 	}
+
 	s.log.Debug("None of the local peers have the document, hitting the DHT :(")
 	// Arbitrary number of maximum providers
 	maxProviders := 15
 
 	// If we are looking for a specific version, we don't need to limit the number of providers,
 	// because we will short-circuit as soon as we found the desired version.
-	if version != "" {
+	if len(heads) > 0 {
 		maxProviders = 0
 	}
 	ctxDHT, cancelDHTCtx := context.WithTimeout(ctx, DefaultDHTTimeout)
 	defer cancelDHTCtx()
-	peers := s.bitswap.FindProvidersAsync(ctxDHT, c, maxProviders)
-	if len(peers) == 0 {
-		return fmt.Errorf("After checking local peers, no dht providers found for CID %s", c.String())
+
+	var numProviders int
+	for p := range s.bitswap.FindProvidersAsync(ctxDHT, c, maxProviders) {
+		numProviders++
+		if len(p.Addrs) > 0 {
+			s.host.Peerstore().AddAddrs(p.ID, p.Addrs, peerstore.TempAddrTTL)
+		}
+
+		if err := s.SyncWithPeer(ctxDHT, p.ID, eids); err != nil {
+			s.log.Debug("Could not sync with DHT provider", zap.String("PID", p.ID.String()), zap.Error(err))
+			continue
+		}
+		peers = append(peers, p.ID)
+
+		ok, err := s.hasObject(ctxDHT, entityID, heads)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			s.log.Debug("Discovered content via DHT")
+			return peers, nil
+		}
 	}
 
-	eidsMap := make(map[string]bool)
-	eidsMap[entityID] = false
-	subsMap = make(subscriptionMap)
-	for p := range peers {
-		p := p
-		// TODO(juligasa): look into the providers store who has each eid
-		// instead of pasting all peers in all documents.
-		subsMap[p.ID] = eidsMap
+	if numProviders == 0 {
+		return nil, fmt.Errorf("%w: after checking local peers, no dht providers found for CID %s", ErrObjectNotFound, c.String())
+	}
+
+	if len(heads) > 0 {
+		return nil, fmt.Errorf("%w: found some DHT providers but couldn't find the desired version %q of %s", ErrObjectNotFound, version, entityID)
 	}
 
-	ret := s.SyncWithManyPeers(ctxDHT, subsMap)
-	if ret.NumSyncOK > 0 {
-		conn, release, err := s.db.Conn(ctxDHT)
+	return nil, fmt.Errorf("%w: found some DHT providers but could not sync from them %s", ErrObjectNotFound, c.String())
+}
+
+func (s *Service) listPeers(ctx context.Context) (peers []peer.ID, err error) {
+	conn, release, err := s.db.Conn(ctx)
+	if err != nil {
+		s.log.Debug("Could not grab a connection", zap.Error(err))
+		return nil, err
+	}
+	defer release()
+
+	// TODO:(juligasa): Remove this when we have providers store
+	if err = sqlitex.Exec(conn, qListPeersWithPid(), func(stmt *sqlite.Stmt) error {
+		addresStr := stmt.ColumnText(0)
+		pid := stmt.ColumnText(1)
+		addrList := strings.Split(addresStr, ",")
+		info, err := mttnet.AddrInfoFromStrings(addrList...)
 		if err != nil {
-			s.log.Debug("Could not grab a connection", zap.Error(err))
-			return err
+			s.log.Warn("Can't discover from peer since it has malformed addresses", zap.String("PID", pid), zap.Error(err))
+			return nil
 		}
-		defer release()
-		var haveIt bool
-		if err = sqlitex.Exec(conn, qGetEntity(), func(stmt *sqlite.Stmt) error {
-			eid := stmt.ColumnText(0)
-			if eid != entityID {
-				return fmt.Errorf("Got a different eid")
+		s.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.TempAddrTTL)
+		peers = append(peers, info.ID)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return peers, nil
+}
+
+// hasObject checks whether we have the object locally.
+// When heads are provided all of them must be available,
+// otherwise any content for the object is enough.
+func (s *Service) hasObject(ctx context.Context, entityID string, heads []cid.Cid) (bool, error) {
+	if len(heads) > 0 {
+		for _, h := range heads {
+			ok, err := s.indexer.Has(ctx, h)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return false, nil
 			}
-			haveIt = true
-			return nil
-		}, entityID); err != nil {
-			s.log.Warn("Problem finding eid after dht discovery", zap.Error(err))
-		} else if haveIt {
-			s.log.Debug("Discovered content via DHT")
-			return nil
 		}
+		return true, nil
+	}
+
+	conn, release, err := s.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+
+	var haveIt bool
+	if err := sqlitex.Exec(conn, qEntityHasContent(), func(*sqlite.Stmt) error {
+		haveIt = true
+		return nil
+	}, entityID); err != nil {
+		return false, err
 	}
-	return fmt.Errorf("Found some DHT providers but could not sync from them %s", c.String())
 
+	return haveIt, nil
 }
 
-var qGetEntity = dqb.Str(`
-		SELECT
-			iri
-		FROM resources 
-		WHERE iri = :iri
-		LIMIT 1;
-	`)
+// We might know about the resource from links, so we need to check that we have its content.
+var qEntityHasContent = dqb.Str(`
+	SELECT 1
+	FROM structural_blobs
+	WHERE type = 'Ref'
+	AND resource = (SELECT id FROM resources WHERE iri = :iri)
+	LIMIT 1;
+`)
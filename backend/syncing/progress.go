@@ -0,0 +1,206 @@
+package syncing
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Finished syncs are forgotten after this time.
+const progressRetention = 10 * time.Minute
+
+// Progress is a snapshot of syncing a resource with a single peer.
+type Progress struct {
+	Resource   string
+	Peer       peer.ID
+	Wanted     int
+	Fetched    int
+	Failed     int
+	Done       bool
+	Err        error
+	StartTime  time.Time
+	UpdateTime time.Time
+}
+
+type progressEntry struct {
+	seq      uint64
+	progress Progress
+}
+
+// progressBroker keeps the latest progress of each sync,
+// and notifies the watchers when anything changes.
+// Updates never block the syncing, slow watchers just miss the intermediate states.
+type progressBroker struct {
+	mu    sync.Mutex
+	seq   uint64
+	syncs map[peer.ID]map[string]progressEntry // Indexed by peer and subscribed resource.
+
+	// Finished syncs in the order they finished, to forget them after the retention period.
+	finished []progressEntry
+
+	watchers map[chan struct{}]struct{}
+}
+
+func newProgressBroker() *progressBroker {
+	return &progressBroker{
+		syncs:    make(map[peer.ID]map[string]progressEntry),
+		watchers: make(map[chan struct{}]struct{}),
+	}
+}
+
+func (b *progressBroker) update(p Progress) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e := progressEntry{seq: b.seq, progress: p}
+
+	peerSyncs := b.syncs[p.Peer]
+	if peerSyncs == nil {
+		peerSyncs = make(map[string]progressEntry)
+		b.syncs[p.Peer] = peerSyncs
+	}
+	peerSyncs[p.Resource] = e
+
+	if p.Done {
+		b.finished = append(b.finished, e)
+	}
+
+	for len(b.finished) > 0 && p.UpdateTime.Sub(b.finished[0].progress.UpdateTime) > progressRetention {
+		old := b.finished[0]
+		b.finished = b.finished[1:]
+
+		// The sync may have been restarted since it finished.
+		peerSyncs := b.syncs[old.progress.Peer]
+		if peerSyncs[old.progress.Resource].seq != old.seq {
+			continue
+		}
+		delete(peerSyncs, old.progress.Resource)
+		if len(peerSyncs) == 0 {
+			delete(b.syncs, old.progress.Peer)
+		}
+	}
+
+	for c := range b.watchers {
+		select {
+		case c <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ProgressWatcher receives the progress of the syncs.
+type ProgressWatcher struct {
+	b   *progressBroker
+	c   chan struct{}
+	seq uint64
+}
+
+// WatchProgress starts watching the progress of the syncs.
+// The watcher is notified right away to get the current state.
+// Callers must close the watcher when done.
+func (s *Service) WatchProgress() *ProgressWatcher {
+	return s.progress.watch()
+}
+
+func (b *progressBroker) watch() *ProgressWatcher {
+	w := &ProgressWatcher{
+		b: b,
+		c: make(chan struct{}, 1),
+	}
+	w.c <- struct{}{}
+
+	b.mu.Lock()
+	b.watchers[w.c] = struct{}{}
+	b.mu.Unlock()
+
+	return w
+}
+
+// C returns the channel that receives a value when there are new updates.
+func (w *ProgressWatcher) C() <-chan struct{} {
+	return w.c
+}
+
+// Next returns the latest state of the syncs that changed since the previous call.
+func (w *ProgressWatcher) Next() []Progress {
+	w.b.mu.Lock()
+	defer w.b.mu.Unlock()
+
+	var entries []progressEntry
+	for _, peerSyncs := range w.b.syncs {
+		for _, e := range peerSyncs {
+			if e.seq > w.seq {
+				entries = append(entries, e)
+			}
+		}
+	}
+	w.seq = w.b.seq
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+
+	out := make([]Progress, len(entries))
+	for i, e := range entries {
+		out[i] = e.progress
+	}
+
+	return out
+}
+
+// Close stops watching the progress.
+func (w *ProgressWatcher) Close() {
+	w.b.mu.Lock()
+	delete(w.b.watchers, w.c)
+	w.b.mu.Unlock()
+}
+
+// progressTracker reports the progress of a single sync.
+type progressTracker struct {
+	b *progressBroker
+	p Progress
+}
+
+func (b *progressBroker) track(resource string, pid peer.ID) *progressTracker {
+	now := time.Now()
+	t := &progressTracker{
+		b: b,
+		p: Progress{
+			Resource:   resource,
+			Peer:       pid,
+			StartTime:  now,
+			UpdateTime: now,
+		},
+	}
+	b.update(t.p)
+	return t
+}
+
+func (t *progressTracker) wanted(n int) {
+	t.p.Wanted = n
+	t.publish()
+}
+
+func (t *progressTracker) fetched() {
+	t.p.Fetched++
+	t.publish()
+}
+
+func (t *progressTracker) failed() {
+	t.p.Failed++
+	t.publish()
+}
+
+func (t *progressTracker) finish(err error) {
+	t.p.Done = true
+	t.p.Err = err
+	t.publish()
+}
+
+func (t *progressTracker) publish() {
+	t.p.UpdateTime = time.Now()
+	t.b.update(t.p)
+}
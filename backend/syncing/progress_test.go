@@ -0,0 +1,61 @@
+package syncing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgressBroker(t *testing.T) {
+	b := newProgressBroker()
+
+	alice := peer.ID("alice")
+	bob := peer.ID("bob")
+
+	t1 := b.track("hm://foo", alice)
+	t1.wanted(2)
+
+	w := b.watch()
+	defer w.Close()
+
+	// The current state is available right away.
+	<-w.C()
+	got := w.Next()
+	require.Len(t, got, 1)
+	require.Equal(t, "hm://foo", got[0].Resource)
+	require.Equal(t, 2, got[0].Wanted)
+	require.Empty(t, w.Next(), "must not return the same state twice")
+
+	// Intermediate states are coalesced for slow watchers.
+	t2 := b.track("hm://foo", bob)
+	t1.fetched()
+	t1.fetched()
+	t1.finish(nil)
+	t2.failed()
+	t2.finish(errors.New("boom"))
+
+	<-w.C()
+	got = w.Next()
+	require.Len(t, got, 2)
+	require.Equal(t, alice, got[0].Peer)
+	require.True(t, got[0].Done)
+	require.Equal(t, 2, got[0].Fetched)
+	require.NoError(t, got[0].Err)
+	require.Equal(t, bob, got[1].Peer)
+	require.True(t, got[1].Done)
+	require.Equal(t, 1, got[1].Failed)
+	require.EqualError(t, got[1].Err, "boom")
+
+	w.Close()
+	require.Empty(t, b.watchers)
+
+	// Finished syncs are forgotten after the retention period.
+	b = newProgressBroker()
+	b.update(Progress{Resource: "hm://foo", Peer: bob, Done: true, UpdateTime: time.Now().Add(-2 * progressRetention)})
+	b.track("hm://bar", alice).finish(nil)
+	require.NotContains(t, b.syncs, bob)
+	require.Contains(t, b.syncs[alice], "hm://bar")
+}
@@ -3,6 +3,7 @@ package rbsr
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"math/bits"
 	"unsafe"
 )
 
@@ -43,6 +44,19 @@ func (acc *accumulator) Add(other [32]byte) {
 	}
 }
 
+// Sub removes the sum of the other accumulator from this one.
+// It's the inverse of Add, i.e. both operations wrap around the same way.
+func (acc *accumulator) Sub(other [32]byte) {
+	var borrow uint64
+
+	p := (*[4]uint64)(unsafe.Pointer(&acc.sum[0]))
+	po := (*[4]uint64)(unsafe.Pointer(&other[0]))
+
+	for i := 0; i < 4; i++ {
+		p[i], borrow = bits.Sub64(p[i], po[i], borrow)
+	}
+}
+
 func (acc *accumulator) Fingerprint() Fingerprint {
 	buf := make([]byte, 0, len(acc.sum)+8) // sum + len will be hashed.
 	buf = append(buf, acc.sum[:]...)
@@ -54,3 +68,49 @@ func (acc *accumulator) Fingerprint() Fingerprint {
 	copy(fingerprint[:], hash[:fingerprintSize])
 	return fingerprint
 }
+
+// fingerprintCache stores the running sums of the item hashes,
+// so the fingerprint of any range is computed without rehashing the items of the range.
+type fingerprintCache struct {
+	// sums[i] is the sum of the hashes of the first i items.
+	sums [][32]byte
+}
+
+func newFingerprintCache(items []Item) fingerprintCache {
+	var acc accumulator
+	sums := make([][32]byte, len(items)+1)
+	for i, item := range items {
+		acc.Add(sha256.Sum256(item.Value))
+		sums[i+1] = acc.sum
+	}
+
+	return fingerprintCache{sums: sums}
+}
+
+// extend returns the cache for the items, whose first n items are the same as the cached ones.
+// The sums are appended to the cached ones if inPlace is true,
+// so the caller must make sure nothing else appends to them.
+func (c fingerprintCache) extend(n int, items []Item, inPlace bool) fingerprintCache {
+	var sums [][32]byte
+	if inPlace {
+		sums = c.sums[:n+1]
+	} else {
+		sums = make([][32]byte, n+1, len(items)+1)
+		copy(sums, c.sums[:n+1])
+	}
+
+	acc := accumulator{sum: sums[n]}
+	for _, item := range items[n:] {
+		acc.Add(sha256.Sum256(item.Value))
+		sums = append(sums, acc.sum)
+	}
+
+	return fingerprintCache{sums: sums}
+}
+
+// Fingerprint of the items in the range [begin, end).
+func (c fingerprintCache) Fingerprint(begin, end int) Fingerprint {
+	acc := accumulator{sum: c.sums[end]}
+	acc.Sub(c.sums[begin])
+	return acc.Fingerprint()
+}
@@ -8,7 +8,6 @@ package rbsr
 
 import (
 	"bytes"
-	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"math"
@@ -363,18 +362,10 @@ func (n *Session) checkMessageSize(size int) (ok bool) {
 	return n.msgSizeLimit != 0 && size > int(n.msgSizeLimit)-200
 }
 
+// Fingerprint of the items in the range [begin, end).
+// Stores cache the hashes of the items, so it's cheap to call it on every round.
 func (n *Session) Fingerprint(begin, end int) (Fingerprint, error) {
-	var out accumulator
-
-	if err := n.store.ForEach(begin, end, func(_ int, item Item) bool {
-		h := sha256.Sum256(item.Value) // TODO(burdiyan): cache the value of the hash between rounds.
-		out.Add(h)
-		return true
-	}); err != nil {
-		return Fingerprint{}, err
-	}
-
-	return out.Fingerprint(), nil
+	return n.store.Fingerprint(begin, end)
 }
 
 func ensureLittleEndian() {
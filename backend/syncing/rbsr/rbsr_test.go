@@ -1,6 +1,7 @@
 package rbsr
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"os"
@@ -179,6 +180,93 @@ func TestSmoke(t *testing.T) {
 	}
 }
 
+func TestFingerprintCache(t *testing.T) {
+	store := NewSliceStore()
+	for i := 0; i < 1000; i++ {
+		must.Do(store.Insert(int64(i/7), []byte("Hello "+strconv.Itoa(i))))
+	}
+	// Duplicates must be ignored.
+	must.Do(store.Insert(0, []byte("Hello 0")))
+	must.Do(store.Seal())
+	require.Equal(t, 1000, store.Size())
+
+	// Fingerprints must be the same as the ones computed by hashing every item,
+	// otherwise we won't be able to reconcile with other peers.
+	naive := func(begin, end int) Fingerprint {
+		var acc accumulator
+		must.Do(store.ForEach(begin, end, func(_ int, item Item) bool {
+			acc.Add(sha256.Sum256(item.Value))
+			return true
+		}))
+		return acc.Fingerprint()
+	}
+
+	for _, rng := range [][2]int{{0, 0}, {0, 1}, {0, 1000}, {13, 14}, {100, 377}, {999, 1000}} {
+		got, err := store.Fingerprint(rng[0], rng[1])
+		require.NoError(t, err)
+		require.Equal(t, naive(rng[0], rng[1]), got, "range %v", rng)
+	}
+
+	_, err := store.Fingerprint(10, 1001)
+	require.Error(t, err)
+}
+
+func TestExtend(t *testing.T) {
+	newStore := func(items ...Item) Store {
+		store := NewSliceStore()
+		for _, item := range items {
+			must.Do(store.Insert(item.Timestamp, item.Value))
+		}
+		must.Do(store.Seal())
+		return store
+	}
+
+	items := func(from, to int) []Item {
+		out := make([]Item, 0, to-from)
+		for i := from; i < to; i++ {
+			out = append(out, NewItem(int64(i/7), []byte("Hello "+strconv.Itoa(i))))
+		}
+		return out
+	}
+
+	// The stores must be the same as the ones built from scratch.
+	requireEqual := func(want, got Store) {
+		t.Helper()
+		require.Equal(t, want.Size(), got.Size())
+		must.Do(want.ForEach(0, want.Size(), func(i int, item Item) bool {
+			must.Do(got.ForEach(i, i+1, func(_ int, other Item) bool {
+				require.Equal(t, item, other, "item %d", i)
+				return true
+			}))
+			return true
+		}))
+		for _, rng := range [][2]int{{0, 0}, {0, want.Size()}, {3, want.Size()}, {0, want.Size() / 2}} {
+			require.Equal(t, must.Do2(want.Fingerprint(rng[0], rng[1])), must.Do2(got.Fingerprint(rng[0], rng[1])), "range %v", rng)
+		}
+	}
+
+	base := newStore(items(0, 500)...)
+
+	// Newer items are appended in place.
+	appended := must.Do2(Extend(base, items(500, 600)))
+	requireEqual(newStore(items(0, 600)...), appended)
+	requireEqual(newStore(items(0, 500)...), base)
+
+	// Extending the same store again must not overwrite the previous extension.
+	other := must.Do2(Extend(base, append(items(700, 710), items(490, 510)...)))
+	requireEqual(newStore(append(items(0, 510), items(700, 710)...)...), other)
+	requireEqual(newStore(items(0, 600)...), appended)
+
+	// Older items are merged.
+	merged := must.Do2(Extend(appended, []Item{NewItem(0, []byte("Old")), NewItem(50, []byte("Middle")), NewItem(50, []byte("Middle"))}))
+	requireEqual(newStore(append(items(0, 600), NewItem(0, []byte("Old")), NewItem(50, []byte("Middle")))...), merged)
+	requireEqual(newStore(items(0, 600)...), appended)
+
+	// Nothing new.
+	same := must.Do2(Extend(merged, items(10, 20)))
+	require.Same(t, merged, same)
+}
+
 type peer struct {
 	store Store
 	ne    *Session
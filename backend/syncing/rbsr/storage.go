@@ -2,7 +2,9 @@ package rbsr
 
 import (
 	"errors"
+	"fmt"
 	"sort"
+	"sync/atomic"
 
 	"golang.org/x/exp/slices"
 )
@@ -13,6 +15,7 @@ type Store interface {
 	Insert(ts int64, data []byte) error
 	ForEach(start, end int, fn func(i int, item Item) bool) error
 	FindLowerBound(startHint int, value Item) (int, error)
+	Fingerprint(start, end int) (Fingerprint, error)
 	Seal() error
 }
 
@@ -20,6 +23,11 @@ type Store interface {
 type sliceStore struct {
 	items  []Item
 	sealed bool
+	fp     fingerprintCache
+
+	// extended is set when the store is extended in place,
+	// i.e. when another store appends to the same underlying arrays.
+	extended atomic.Bool
 }
 
 // NewSliceStore creates a store instance that is backed by a slice.
@@ -35,13 +43,8 @@ func (v *sliceStore) Insert(createdAt int64, id []byte) error {
 		return errors.New("already sealed")
 	}
 
-	item := NewItem(createdAt, id)
-	for _, it := range v.items {
-		if it.Cmp(item) == 0 {
-			return nil
-		}
-	}
-	v.items = append(v.items, item)
+	// Duplicates are removed when sealing.
+	v.items = append(v.items, NewItem(createdAt, id))
 	return nil
 }
 
@@ -56,18 +59,79 @@ func (v *sliceStore) Seal() error {
 	v.sealed = true
 
 	slices.SortFunc(v.items, func(a, b Item) int {
-		x := a.Cmp(b)
-		if x == 0 {
-			panic("BUG: duplicate items in store when sealing")
-		}
-		return x
+		return a.Cmp(b)
+	})
+	v.items = slices.CompactFunc(v.items, func(a, b Item) bool {
+		return a.Cmp(b) == 0
 	})
 
+	v.fp = newFingerprintCache(v.items)
+
 	return nil
 }
 
+// Extend returns a sealed store with the items of the sealed store s along with the new items,
+// which don't have to be sorted or unique. The store s is not modified, so it remains usable.
+// The items after the first new one are copied and hashed again,
+// so extending is cheap when the new items are the most recent ones.
+func Extend(s Store, items []Item) (Store, error) {
+	v, ok := s.(*sliceStore)
+	if !ok {
+		return nil, fmt.Errorf("can't extend store of type %T", s)
+	}
+
+	if err := v.checkSealed(); err != nil {
+		return nil, err
+	}
+
+	items = slices.Clone(items)
+	slices.SortFunc(items, func(a, b Item) int {
+		return a.Cmp(b)
+	})
+	items = slices.CompactFunc(items, func(a, b Item) bool {
+		return a.Cmp(b) == 0
+	})
+	items = slices.DeleteFunc(items, func(item Item) bool {
+		i := v.lowerBound(item)
+		return i < len(v.items) && v.items[i].Cmp(item) == 0
+	})
+	if len(items) == 0 {
+		return v, nil
+	}
+
+	out := &sliceStore{sealed: true}
+
+	start := v.lowerBound(items[0])
+	// Only one store can append to the arrays of the original one.
+	// Stores sharing the arrays never read past their own length.
+	if start == len(v.items) && v.extended.CompareAndSwap(false, true) {
+		out.items = append(v.items, items...)
+		out.fp = v.fp.extend(start, out.items, true)
+		return out, nil
+	}
+
+	out.items = make([]Item, 0, len(v.items)+len(items))
+	out.items = append(out.items, v.items[:start]...)
+	tail := v.items[start:]
+	for len(tail) > 0 && len(items) > 0 {
+		if tail[0].Cmp(items[0]) < 0 {
+			out.items = append(out.items, tail[0])
+			tail = tail[1:]
+		} else {
+			out.items = append(out.items, items[0])
+			items = items[1:]
+		}
+	}
+	out.items = append(out.items, tail...)
+	out.items = append(out.items, items...)
+	out.fp = v.fp.extend(start, out.items, false)
+
+	return out, nil
+}
+
 func (v *sliceStore) Unseal() {
 	v.sealed = false
+	v.fp = fingerprintCache{}
 }
 
 func (v *sliceStore) Size() int {
@@ -108,6 +172,24 @@ func (v *sliceStore) FindLowerBound(startHint int, bound Item) (int, error) {
 	return startHint + i, nil
 }
 
+func (v *sliceStore) lowerBound(bound Item) int {
+	return sort.Search(len(v.items), func(i int) bool {
+		return v.items[i].Cmp(bound) >= 0
+	})
+}
+
+func (v *sliceStore) Fingerprint(start, end int) (Fingerprint, error) {
+	if err := v.checkSealed(); err != nil {
+		return Fingerprint{}, err
+	}
+
+	if err := v.checkBounds(start, end); err != nil {
+		return Fingerprint{}, err
+	}
+
+	return v.fp.Fingerprint(start, end), nil
+}
+
 func (v *sliceStore) checkSealed() error {
 	if !v.sealed {
 		return errors.New("not sealed")
@@ -2,6 +2,7 @@ package syncing
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -24,7 +25,6 @@ import (
 	"seed/backend/util/sqlite"
 	"seed/backend/util/sqlite/sqlitex"
 
-	"github.com/ipfs/boxo/blockstore"
 	"github.com/ipfs/boxo/exchange"
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p/core/event"
@@ -101,7 +101,7 @@ func init() {
 // of a Syncing RPC client for a given remote Device ID.
 type netDialFunc func(context.Context, peer.ID) (p2p.SyncingClient, error)
 
-type subscriptionMap map[peer.ID]map[string]bool
+type subscriptionMap map[peer.ID][]*p2p.Filter
 
 // bitswap is a subset of the bitswap that is used by syncing service.
 type bitswap interface {
@@ -133,6 +133,7 @@ type Service struct {
 	pc         protocolChecker
 	mu         sync.Mutex // Ensures only one sync loop is running at a time.
 	sstore     SubscriptionStore
+	progress   *progressBroker
 	wg         sync.WaitGroup
 	workers    map[peer.ID]*worker
 	semaphore  chan struct{}
@@ -154,6 +155,7 @@ func NewService(cfg config.Syncing, log *zap.Logger, db *sqlitex.Pool, indexer *
 		workers:    make(map[peer.ID]*worker),
 		semaphore:  make(chan struct{}, peerRoutingConcurrency),
 		sstore:     sstore,
+		progress:   newProgressBroker(),
 	}
 	svc.pc = protocolChecker{
 		checker: net.CheckHyperMediaProtocolVersion,
@@ -239,7 +241,7 @@ func (s *Service) refreshWorkers(ctx context.Context) error {
 	// Starting workers for newly added trusted peers.
 	for pid := range peers {
 		if _, ok := s.workers[pid]; !ok {
-			w := newWorker(s.cfg, pid, s.log, s.rbsrClient, s.host, s.indexer, s.bitswap, s.db, s.semaphore, s.sstore)
+			w := newWorker(s.cfg, pid, s.log, s.rbsrClient, s.host, s.indexer, s.bitswap, s.db, s.semaphore, s.sstore, s.progress)
 			s.wg.Add(1)
 			go w.start(ctx, &s.wg, s.cfg.Interval)
 			workersDiff++
@@ -426,10 +428,9 @@ func (s *Service) SyncSubscribedContent(ctx context.Context, subscriptions ...*a
 	}
 	release()
 	s.log.Debug("Got list of peers", zap.Int("Number of total peers", len(allPeers)))
-	eidsMap := make(map[string]bool)
-	for _, subs := range subscriptions {
-		eid := "hm://" + subs.Account + subs.Path
-		eidsMap[eid] = subs.Recursive
+	filters := make([]*p2p.Filter, len(subscriptions))
+	for i, subs := range subscriptions {
+		filters[i] = SubscriptionFilter(subs)
 	}
 	if len(allPeers) == 0 {
 		s.log.Debug("Defaulting to DHT since we don't have providers")
@@ -450,16 +451,27 @@ func (s *Service) SyncSubscribedContent(ctx context.Context, subscriptions ...*a
 	if len(allPeers) == 0 {
 		return res, fmt.Errorf("Could not find any provider for any of the subscribed content")
 	}
-	s.log.Debug("Syncing Subscribed content", zap.Int("Number of documents", len(eidsMap)), zap.Int("Number of peers", len(allPeers)))
+	s.log.Debug("Syncing Subscribed content", zap.Int("Number of documents", len(filters)), zap.Int("Number of peers", len(allPeers)))
 	for _, pid := range allPeers {
 		// TODO(juligasa): look into the providers store who has each eid
 		// instead of pasting all peers in all documents.
-		subsMap[pid] = eidsMap
+		subsMap[pid] = filters
 	}
 
 	return s.SyncWithManyPeers(ctx, subsMap), nil
 }
 
+// SubscriptionFilter converts the subscription into the reconciliation filter.
+func SubscriptionFilter(sub *activity_proto.Subscription) *p2p.Filter {
+	return &p2p.Filter{
+		Resource:  "hm://" + sub.Account + sub.Path,
+		Recursive: sub.Recursive,
+		BlobTypes: sub.BlobTypes,
+		Authors:   sub.Authors,
+		MaxDepth:  sub.MaxDepth,
+	}
+}
+
 // SyncWithManyPeers syncs with many peers in parallel
 func (s *Service) SyncWithManyPeers(ctx context.Context, subsMap subscriptionMap) (res SyncResult) {
 	var i int
@@ -468,7 +480,7 @@ func (s *Service) SyncWithManyPeers(ctx context.Context, subsMap subscriptionMap
 	res.Peers = make([]peer.ID, len(subsMap))
 	res.Errs = make([]error, len(subsMap))
 	for pid, eids := range subsMap {
-		go func(i int, pid peer.ID, eids map[string]bool) {
+		go func(i int, pid peer.ID, eids []*p2p.Filter) {
 			var err error
 			defer func() {
 				res.Errs[i] = err
@@ -497,8 +509,8 @@ func (s *Service) SyncWithManyPeers(ctx context.Context, subsMap subscriptionMap
 }
 
 // SyncWithPeer syncs all documents from a given peer. given no initial objectsOptionally.
-// If a non empty entity is provided, then only syncs blobs related to that entities.
-func (s *Service) SyncWithPeer(ctx context.Context, pid peer.ID, eids map[string]bool) error {
+// If filters are provided, then only syncs blobs related to the entities of the filters.
+func (s *Service) SyncWithPeer(ctx context.Context, pid peer.ID, eids []*p2p.Filter) error {
 	// Can't sync with self.
 	if s.host.Network().LocalPeer() == pid {
 		s.log.Debug("Sync with self attempted")
@@ -521,7 +533,7 @@ func (s *Service) SyncWithPeer(ctx context.Context, pid peer.ID, eids map[string
 
 	if len(eids) != 0 {
 		s.log.Debug("Sync with entities", zap.Int("num entities", len(eids)), zap.String("Peer", pid.String()))
-		return syncEntities(ctx, pid, c, s.indexer, bswap, s.db, s.log, eids)
+		return syncEntities(ctx, pid, c, s.indexer, bswap, s.db, s.log, s.progress, eids)
 	}
 	s.log.Debug("Sync Everything", zap.String("Peer", pid.String()))
 	return syncPeerRbsr(ctx, pid, c, s.indexer, bswap, s.db, s.log)
@@ -616,11 +628,12 @@ func syncEntities(
 	ctx context.Context,
 	pid peer.ID,
 	c p2p.SyncingClient,
-	idx blockstore.Blockstore,
+	idx *index.Index,
 	sess exchange.Fetcher,
 	db *sqlitex.Pool,
 	log *zap.Logger,
-	eids map[string]bool,
+	prog *progressBroker,
+	eids []*p2p.Filter,
 ) (err error) {
 	mSyncsInFlight.Inc()
 	defer func() {
@@ -634,96 +647,47 @@ func syncEntities(
 		zap.String("peer", pid.String()),
 	)
 	if _, ok := ctx.Deadline(); !ok {
-		return fmt.Errorf("BUG: syncEntity must have timeout")
+		return fmt.Errorf("BUG: syncEntities must have timeout")
 	}
 
-	// If we know what we want, it's a 1 way syncing so we don't share anything
-	store := rbsr.NewSliceStore()
-	ne, err := rbsr.NewSession(store, 50000)
-
-	if err != nil {
-		return fmt.Errorf("Failed to Init Syncing Session: %w", err)
-	}
-	conn, release, err := db.Conn(ctx)
-	if err != nil {
-		return fmt.Errorf("Could not get connection: %w", err)
-	}
-	var queryString = mttnet.QListrelatedBlobsStr
-	var queryParams []interface{}
-	var i int
-	for eid, recursive := range eids {
-		queryString += "?"
-		if recursive {
-			queryParams = append(queryParams, eid+"*")
-		} else {
-			queryParams = append(queryParams, eid)
-		}
-		if i < len(eids)-1 {
-			queryString += " OR iri GLOB "
+	// All the entities are reconciled with the peer at once,
+	// and the fetched blobs are assigned to the subscriptions by their resources
+	// to track the outcome of each subscription.
+	subs := make([]*subscriptionSync, len(eids))
+	for i, eid := range eids {
+		subs[i] = &subscriptionSync{
+			filter: eid,
+			track:  prog.track(eid.Resource, pid),
 		}
-		i++
 	}
-	i = 0
-	queryString += mttnet.QListrelatedCapabilitiesStr
-	for eid, recursive := range eids {
-		queryString += "?"
-		if recursive {
-			queryParams = append(queryParams, eid+"*")
-		} else {
-			queryParams = append(queryParams, eid)
-		}
-		if i < len(eids)-1 {
-			queryString += " OR iri GLOB "
+	defer func() {
+		for _, sub := range subs {
+			sub.track.finish(err)
+			// The sync may have failed because of the context, but we still want to record the outcome.
+			if xerr := recordSubscriptionSync(context.WithoutCancel(ctx), db, sub.filter.Resource, pid, sub.stats, err); xerr != nil {
+				log.Warn("FailedToRecordSubscriptionSync", zap.String("resource", sub.filter.Resource), zap.Error(xerr))
+			}
 		}
-		i++
+	}()
+
+	// If we know what we want, it's a 1 way syncing so we don't share anything.
+	// The set is cached by the subscriptions, so it's only listed again when blobs change.
+	key, err := mttnet.FiltersKey(eids)
+	if err != nil {
+		return err
 	}
-	queryString += mttnet.QListRelatedBlobsContStr
-
-	localHaves := make(map[cid.Cid]struct{})
-	if err = sqlitex.Exec(conn, queryString, func(stmt *sqlite.Stmt) error {
-		codec := stmt.ColumnInt64(0)
-		hash := stmt.ColumnBytesUnsafe(1)
-		ts := stmt.ColumnInt64(2)
-		rawCid := cid.NewCidV1(uint64(codec), hash)
-		localHaves[rawCid] = struct{}{}
-		return store.Insert(ts, rawCid.Bytes())
-	}, queryParams...); err != nil {
-		release()
+	store, err := idx.FilteredBlobSet(ctx, "sync:"+key, func(conn *sqlite.Conn, fn func(cid.Cid, int64, string) error) error {
+		return listSyncedBlobs(conn, eids, fn)
+	})
+	if err != nil {
 		return fmt.Errorf("Could not list related blobs: %w", err)
 	}
 
-	var queryParams2 []interface{}
-	queryString = mttnet.QListEmbeddedBlobsStr
-	i = 0
-	for eid, recursive := range eids {
-		queryString += "?"
-		if recursive {
-			queryParams2 = append(queryParams2, eid+"*")
-		} else {
-			queryParams2 = append(queryParams2, eid)
-		}
-		if i < len(eids)-1 {
-			queryString += " OR res.iri GLOB "
-		}
-		i++
-	}
-	queryString += mttnet.QListEmbeddedBlobsContStr
-	if err = sqlitex.Exec(conn, queryString, func(stmt *sqlite.Stmt) error {
-		codec := stmt.ColumnInt64(0)
-		hash := stmt.ColumnBytesUnsafe(1)
-		ts := stmt.ColumnInt64(2)
-		c := cid.NewCidV1(uint64(codec), hash)
-		localHaves[c] = struct{}{}
-		return store.Insert(ts, c.Bytes())
-	}, queryParams2...); err != nil {
-		release()
-		return fmt.Errorf("Could not list related embeds: %w", err)
+	ne, err := rbsr.NewSession(store, 50000)
+	if err != nil {
+		return fmt.Errorf("Failed to Init Syncing Session: %w", err)
 	}
 
-	release()
-	if err = store.Seal(); err != nil {
-		return fmt.Errorf("Failed to seal store: %w", err)
-	}
 	msg, err := ne.Initiate()
 	if err != nil {
 		return err
@@ -731,20 +695,18 @@ func syncEntities(
 
 	allWants := list.New()
 
-	var rounds int
+	var (
+		rounds  int
+		missing = make(map[string]int) // Number of our blobs the peer doesn't have by resource.
+	)
 	for msg != nil {
 		rounds++
 		if rounds > 1000 {
 			return fmt.Errorf("Too many rounds of interactive syncing")
 		}
-		filters := []*p2p.Filter{}
-		for eid, recursive := range eids {
-			log.Debug("Inserting reconciling filters", zap.String("Resource", eid), zap.Bool("Recursive", recursive))
-			filters = append(filters, &p2p.Filter{Resource: eid, Recursive: recursive})
-		}
 		res, err := c.ReconcileBlobs(ctx, &p2p.ReconcileBlobsRequest{
 			Ranges:  msg,
-			Filters: filters,
+			Filters: eids,
 		})
 		if err != nil {
 			return err
@@ -755,12 +717,34 @@ func syncEntities(
 		if err != nil {
 			return err
 		}
+		for _, have := range haves {
+			hc, err := cid.Cast(have)
+			if err != nil {
+				return err
+			}
+			iri, _ := store.Resource(hc)
+			missing[iri]++
+		}
 		for _, want := range wants {
 			allWants.PushBack(want)
 		}
 		log.Debug("Blobs Reconciled", zap.Int("Wants", allWants.Len()))
 	}
 
+	// The peer has some content for the subscription if it's not missing everything we have,
+	// or if it has anything we don't, which we only know once the wanted blobs are assigned.
+	for _, sub := range subs {
+		var numLocal, numMissing int
+		for iri, n := range store.Resources() {
+			if mttnet.MatchResource(sub.filter, iri) {
+				numLocal += n
+				numMissing += missing[iri]
+			}
+		}
+		sub.stats.HasContent = numMissing < numLocal
+		sub.track.wanted(allWants.Len())
+	}
+
 	if allWants.Len() == 0 {
 		log.Debug("Peer does not have new content")
 		return nil
@@ -769,6 +753,12 @@ func syncEntities(
 	MSyncingWantedBlobs.WithLabelValues("syncing").Add(float64(allWants.Len()))
 	defer MSyncingWantedBlobs.WithLabelValues("syncing").Sub(float64(allWants.Len()))
 
+	assigner := &blobAssigner{
+		db:      db,
+		subs:    subs,
+		pending: make(map[int64]struct{}),
+	}
+
 	var failed int
 	for allWants.Len() > 0 {
 		item := allWants.Front()
@@ -780,12 +770,13 @@ func syncEntities(
 			return err
 		}
 
-		if _, ok := localHaves[blobCid]; !ok {
+		if iri, ok := store.Resource(blobCid); !ok {
 			blk, err := sess.GetBlock(ctx, blobCid)
 			if err != nil {
 				log.Debug("FailedToGetWantedBlob", zap.String("cid", blobCid.String()), zap.Error(err))
 				allWants.MoveAfter(item, allWants.Back())
 				failed++
+				assigner.failed()
 				continue
 			}
 
@@ -793,11 +784,17 @@ func syncEntities(
 				log.Debug("FailedToSaveWantedBlob", zap.String("cid", blobCid.String()), zap.Error(err))
 				allWants.MoveAfter(item, allWants.Back())
 				failed++
+				assigner.failed()
 				continue
 			}
 			log.Debug("Blob synced", zap.String("blobCid", blobCid.String()))
+
+			if err := assigner.assignFetched(ctx, blobCid); err != nil {
+				return err
+			}
 		} else {
 			log.Debug("Already had wanted blob", zap.String("blobCid", blobCid.String()))
+			assigner.assign(iri, false)
 		}
 		failed = 0
 		allWants.Remove(item)
@@ -806,6 +803,178 @@ func syncEntities(
 	return nil
 }
 
+// entitySyncStats is the outcome of syncing a single entity with a peer.
+type entitySyncStats struct {
+	HasContent bool
+	Fetched    int
+}
+
+// subscriptionSync is the state of a single subscription synced with a peer along with the others.
+type subscriptionSync struct {
+	filter *p2p.Filter
+	track  *progressTracker
+	stats  entitySyncStats
+}
+
+// blobAssigner assigns the wanted blobs to the subscriptions matching their resources.
+// Changes don't belong to any resource on their own,
+// so they are assigned when the ref pointing to them is fetched.
+type blobAssigner struct {
+	db      *sqlitex.Pool
+	subs    []*subscriptionSync
+	pending map[int64]struct{} // Fetched changes waiting for their refs.
+}
+
+func (a *blobAssigner) assign(iri string, fetched bool) {
+	if iri == "" {
+		return
+	}
+
+	for _, sub := range a.subs {
+		if !mttnet.MatchResource(sub.filter, iri) {
+			continue
+		}
+
+		sub.stats.HasContent = true
+		if fetched {
+			sub.stats.Fetched++
+		}
+		sub.track.fetched()
+	}
+}
+
+func (a *blobAssigner) failed() {
+	for _, sub := range a.subs {
+		sub.track.failed()
+	}
+}
+
+// assignFetched assigns the blob that was just fetched and indexed.
+func (a *blobAssigner) assignFetched(ctx context.Context, c cid.Cid) error {
+	conn, release, err := a.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var (
+		id       int64
+		blobType string
+		iri      string
+	)
+	if err := sqlitex.Exec(conn, qGetFetchedBlob(), func(stmt *sqlite.Stmt) error {
+		id = stmt.ColumnInt64(0)
+		blobType = stmt.ColumnText(1)
+		iri = stmt.ColumnText(2)
+		return nil
+	}, []byte(c.Hash())); err != nil {
+		return err
+	}
+
+	switch blobType {
+	case "Change":
+		a.pending[id] = struct{}{}
+		return nil
+	case "Ref":
+		if len(a.pending) == 0 {
+			break
+		}
+
+		ids := make([]int64, 0, len(a.pending))
+		for change := range a.pending {
+			ids = append(ids, change)
+		}
+
+		pendingJSON, err := json.Marshal(ids)
+		if err != nil {
+			return err
+		}
+
+		if err := sqlitex.Exec(conn, qListPendingRefChanges(), func(stmt *sqlite.Stmt) error {
+			delete(a.pending, stmt.ColumnInt64(0))
+			a.assign(iri, true)
+			return nil
+		}, string(pendingJSON), id); err != nil {
+			return err
+		}
+	}
+
+	a.assign(iri, true)
+	return nil
+}
+
+var qGetFetchedBlob = dqb.Str(`
+	SELECT
+		b.id,
+		ifnull(sb.type, ''),
+		ifnull(r.iri, '')
+	FROM blobs b
+	LEFT JOIN structural_blobs sb ON sb.id = b.id
+	LEFT JOIN resources r ON r.id = sb.resource
+	WHERE b.multihash = :multihash;
+`)
+
+// qListPendingRefChanges lists the pending changes the ref points to, transitively.
+// Pending changes can only depend on other pending changes or on the ones we had before,
+// so we don't need to walk past them.
+var qListPendingRefChanges = dqb.Str(`
+	WITH RECURSIVE
+	pending (id) AS (
+		SELECT value FROM json_each(:pending)
+	),
+	changes (id) AS (
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN pending p ON p.id = bl.target
+		WHERE bl.source = :ref
+		AND bl.type = 'ref/head'
+		UNION
+		SELECT bl.target
+		FROM blob_links bl
+		JOIN changes c ON c.id = bl.source
+		JOIN pending p ON p.id = bl.target
+		WHERE bl.type = 'change/dep'
+	)
+	SELECT id FROM changes;
+`)
+
+// recordSubscriptionSync stores the outcome of the sync with the peer
+// if the resource is subscribed.
+func recordSubscriptionSync(ctx context.Context, db *sqlitex.Pool, resource string, pid peer.ID, stats entitySyncStats, syncErr error) error {
+	conn, release, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var (
+		now    = time.Now().Unix()
+		ok     = syncErr == nil
+		okTime int64
+		errMsg string
+	)
+	if ok {
+		okTime = now
+	} else {
+		errMsg = syncErr.Error()
+	}
+
+	return sqlitex.Exec(conn, qRecordSubscriptionSync(), nil, pid.String(), okTime, errMsg, stats.HasContent, stats.Fetched, now, resource, ok)
+}
+
+var qRecordSubscriptionSync = dqb.Str(`
+	INSERT INTO subscription_syncs (subscription, peer, last_ok_time, last_error, has_content, blobs_fetched, update_time)
+	SELECT id, :peer, :last_ok_time, :last_error, :has_content, :blobs_fetched, :update_time
+	FROM subscriptions
+	WHERE iri = :iri
+	ON CONFLICT (subscription, peer) DO UPDATE SET
+		last_ok_time = CASE WHEN :ok THEN excluded.last_ok_time ELSE last_ok_time END,
+		has_content = CASE WHEN :ok THEN excluded.has_content ELSE has_content END,
+		last_error = excluded.last_error,
+		blobs_fetched = excluded.blobs_fetched,
+		update_time = excluded.update_time;
+`)
+
 func syncPeerRbsr(
 	ctx context.Context,
 	pid peer.ID,
@@ -828,32 +997,15 @@ func syncPeerRbsr(
 		return fmt.Errorf("BUG: syncPeerRbsr must have timeout")
 	}
 
-	store := rbsr.NewSliceStore()
-	ne, err := rbsr.NewSession(store, 50000)
-
+	// The set includes the blobs of the deleted resources, so we don't fetch them again.
+	store, err := idx.BlobSet(ctx, true)
 	if err != nil {
-		return fmt.Errorf("Failed to Init Syncing Session: %w", err)
+		return fmt.Errorf("Could not list blobs: %w", err)
 	}
 
-	conn, release, err := db.Conn(ctx)
+	ne, err := rbsr.NewSession(store, 50000)
 	if err != nil {
-		return fmt.Errorf("Could not get connection: %w", err)
-	}
-	defer release()
-
-	localHaves := make(map[cid.Cid]struct{})
-	if err = sqlitex.Exec(conn, qListBlobs(), func(stmt *sqlite.Stmt) error {
-		codec := stmt.ColumnInt64(0)
-		hash := stmt.ColumnBytesUnsafe(1)
-		ts := stmt.ColumnInt64(2)
-		rawCid := cid.NewCidV1(uint64(codec), hash)
-		localHaves[rawCid] = struct{}{}
-		return store.Insert(ts, rawCid.Bytes())
-	}); err != nil {
-		return fmt.Errorf("Could not list blobs: %w", err)
-	}
-	if err = store.Seal(); err != nil {
-		return fmt.Errorf("Failed to seal store: %w", err)
+		return fmt.Errorf("Failed to Init Syncing Session: %w", err)
 	}
 
 	msg, err := ne.Initiate()
@@ -904,7 +1056,11 @@ func syncPeerRbsr(
 		if err != nil {
 			return err
 		}
-		if _, ok := localHaves[blockCid]; !ok {
+		ok, err := store.Has(ctx, blockCid)
+		if err != nil {
+			return err
+		}
+		if !ok {
 			blk, err := sess.GetBlock(ctx, blockCid)
 			if err != nil {
 				log.Debug("FailedToGetWantedBlob", zap.String("cid", blockCid.String()), zap.Error(err))
@@ -927,12 +1083,51 @@ func syncPeerRbsr(
 	return nil
 }
 
-var qListBlobs = dqb.Str(`
+// listSyncedBlobs lists the local blobs related to the subscriptions.
+// We don't want to fetch the blobs of the resources we've deleted locally,
+// so we pretend we have them.
+func listSyncedBlobs(conn *sqlite.Conn, eids []*p2p.Filter, fn func(c cid.Cid, ts int64, iri string) error) error {
+	seen := make(map[cid.Cid]struct{})
+	if err := mttnet.ListRelatedBlobs(conn, eids, func(c cid.Cid, ts int64, iri string) error {
+		seen[c] = struct{}{}
+		return fn(c, ts, iri)
+	}); err != nil {
+		return err
+	}
+
+	for _, eid := range eids {
+		pattern := eid.Resource
+		if eid.Recursive {
+			pattern += "*"
+		}
+		if err := sqlitex.Exec(conn, qListDeletedBlobs(), func(stmt *sqlite.Stmt) error {
+			codec := stmt.ColumnInt64(0)
+			hash := stmt.ColumnBytesUnsafe(1)
+			ts := stmt.ColumnInt64(2)
+			iri := stmt.ColumnText(3)
+			c := cid.NewCidV1(uint64(codec), hash)
+			if _, ok := seen[c]; ok {
+				return nil
+			}
+			seen[c] = struct{}{}
+			return fn(c, ts, iri)
+		}, pattern); err != nil {
+			return fmt.Errorf("Could not list deleted blobs: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// qListDeletedBlobs lists the blobs of the locally deleted resources matching the IRI pattern.
+var qListDeletedBlobs = dqb.Str(`
 		SELECT
 			blobs.codec,
 			blobs.multihash,
-			blobs.insert_time
-		FROM blobs INDEXED BY blobs_metadata LEFT JOIN structural_blobs sb ON sb.id = blobs.id
-		WHERE blobs.size >= 0
-		ORDER BY sb.ts, blobs.multihash;
+			blobs.insert_time,
+			db.iri
+		FROM deleted_blobs db
+		JOIN blobs ON blobs.id = db.blob
+		WHERE db.iri GLOB :pattern
+		ORDER BY blobs.multihash;
 	`)
@@ -6,6 +6,7 @@ import (
 	"math"
 	"seed/backend/config"
 	activity_proto "seed/backend/genproto/activity/v1alpha"
+	p2p "seed/backend/genproto/p2p/v1alpha"
 	"seed/backend/mttnet"
 	"sync"
 	"time"
@@ -31,6 +32,7 @@ type worker struct {
 	db         *sqlitex.Pool
 	sema       chan struct{}
 	sstore     SubscriptionStore
+	progress   *progressBroker
 	// stop is assigned during start().
 	stop context.CancelFunc
 }
@@ -46,6 +48,7 @@ func newWorker(
 	db *sqlitex.Pool,
 	semaphore chan struct{},
 	sstore SubscriptionStore,
+	progress *progressBroker,
 ) *worker {
 	log = log.With(
 		zap.String("peer", pid.String()),
@@ -61,6 +64,7 @@ func newWorker(
 		db:         db,
 		sema:       semaphore,
 		sstore:     sstore,
+		progress:   progress,
 	}
 }
 
@@ -203,12 +207,11 @@ func (sw *worker) sync(ctx context.Context) {
 		if len(ret.Subscriptions) == 0 {
 			return
 		}
-		eids := make(map[string]bool)
-		for _, subscription := range ret.Subscriptions {
-			eid := "hm://" + subscription.Account + subscription.Path
-			eids[eid] = subscription.Recursive
+		eids := make([]*p2p.Filter, len(ret.Subscriptions))
+		for i, subscription := range ret.Subscriptions {
+			eids[i] = SubscriptionFilter(subscription)
 		}
-		if err := syncEntities(ctx, sw.pid, c, sw.indexer, sess, sw.db, sw.log, eids); err != nil {
+		if err := syncEntities(ctx, sw.pid, c, sw.indexer, sess, sw.db, sw.log, sw.progress, eids); err != nil {
 			sw.log.Debug("Failed to smart sync", zap.Error(err))
 		}
 		return
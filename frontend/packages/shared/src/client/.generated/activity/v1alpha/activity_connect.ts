@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { ListEventsRequest, ListEventsResponse } from "./activity_pb";
+import { ListEventsRequest, ListEventsResponse, StreamEventsRequest, StreamEventsResponse } from "./activity_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -26,6 +26,19 @@ export const ActivityFeed = {
       O: ListEventsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Streams the new activity events as soon as they are observed locally,
+     * sorted by locally observed time (oldest first).
+     * Clients can resume the stream after reconnecting by providing the last received cursor.
+     *
+     * @generated from rpc com.seed.activity.v1alpha.ActivityFeed.StreamEvents
+     */
+    streamEvents: {
+      name: "StreamEvents",
+      I: StreamEventsRequest,
+      O: StreamEventsResponse,
+      kind: MethodKind.ServerStreaming,
+    },
   }
 } as const;
 
@@ -158,6 +158,138 @@ export class ListEventsResponse extends Message<ListEventsResponse> {
   }
 }
 
+/**
+ * The request to stream the events.
+ *
+ * @generated from message com.seed.activity.v1alpha.StreamEventsRequest
+ */
+export class StreamEventsRequest extends Message<StreamEventsRequest> {
+  /**
+   * Optional. The cursor to resume the stream from.
+   * Only events observed after the cursor are sent.
+   * If empty, only the events observed after the stream is started are sent.
+   *
+   * @generated from field: string cursor = 1;
+   */
+  cursor = "";
+
+  /**
+   * Not supported yet. Requests with this flag are rejected.
+   *
+   * @generated from field: bool trusted_only = 2;
+   */
+  trustedOnly = false;
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_users = 3;
+   */
+  filterUsers: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_event_type = 4;
+   */
+  filterEventType: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_resource = 5;
+   */
+  filterResource: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string add_linked_resource = 6;
+   */
+  addLinkedResource: string[] = [];
+
+  constructor(data?: PartialMessage<StreamEventsRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.StreamEventsRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "cursor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "trusted_only", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 3, name: "filter_users", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "filter_event_type", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "filter_resource", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "add_linked_resource", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StreamEventsRequest {
+    return new StreamEventsRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StreamEventsRequest {
+    return new StreamEventsRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StreamEventsRequest {
+    return new StreamEventsRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StreamEventsRequest | PlainMessage<StreamEventsRequest> | undefined, b: StreamEventsRequest | PlainMessage<StreamEventsRequest> | undefined): boolean {
+    return proto3.util.equals(StreamEventsRequest, a, b);
+  }
+}
+
+/**
+ * The message of the event stream.
+ *
+ * @generated from message com.seed.activity.v1alpha.StreamEventsResponse
+ */
+export class StreamEventsResponse extends Message<StreamEventsResponse> {
+  /**
+   * The list of new events, oldest first.
+   *
+   * @generated from field: repeated com.seed.activity.v1alpha.Event events = 1;
+   */
+  events: Event[] = [];
+
+  /**
+   * The cursor to resume the stream after the events in this message.
+   *
+   * @generated from field: string cursor = 2;
+   */
+  cursor = "";
+
+  constructor(data?: PartialMessage<StreamEventsResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.StreamEventsResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "events", kind: "message", T: Event, repeated: true },
+    { no: 2, name: "cursor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): StreamEventsResponse {
+    return new StreamEventsResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): StreamEventsResponse {
+    return new StreamEventsResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): StreamEventsResponse {
+    return new StreamEventsResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: StreamEventsResponse | PlainMessage<StreamEventsResponse> | undefined, b: StreamEventsResponse | PlainMessage<StreamEventsResponse> | undefined): boolean {
+    return proto3.util.equals(StreamEventsResponse, a, b);
+  }
+}
+
 /**
  * Description of the event occurred in the system.
  *
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { ListSubscriptionsRequest, ListSubscriptionsResponse, SubscribeRequest, UnsubscribeRequest } from "./subscriptions_pb";
+import { GetSyncProgressRequest, ListSubscriptionsRequest, ListSubscriptionsResponse, SubscribeRequest, SyncProgress, UnsubscribeRequest } from "./subscriptions_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -47,6 +47,19 @@ export const Subscriptions = {
       O: ListSubscriptionsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Streams the progress of the syncing of the subscribed content with each peer.
+     * The current state of the syncs in progress is sent first, followed by the updates.
+     * Intermediate updates may be skipped for slow readers, but the final state of each sync is always sent.
+     *
+     * @generated from rpc com.seed.activity.v1alpha.Subscriptions.GetSyncProgress
+     */
+    getSyncProgress: {
+      name: "GetSyncProgress",
+      I: GetSyncProgressRequest,
+      O: SyncProgress,
+      kind: MethodKind.ServerStreaming,
+    },
   }
 } as const;
 
@@ -35,6 +35,31 @@ export class SubscribeRequest extends Message<SubscribeRequest> {
    */
   recursive = false;
 
+  /**
+   * Optional. Only sync content blobs of these types.
+   * Supported types are Change and Comment.
+   * E.g. to only follow discussions, subscribe to Comment blobs.
+   *
+   * @generated from field: repeated string blob_types = 4;
+   */
+  blobTypes: string[] = [];
+
+  /**
+   * Optional. Only sync content blobs created by these accounts.
+   * The changes they depend on are synced as well, whoever created them.
+   *
+   * @generated from field: repeated string authors = 5;
+   */
+  authors: string[] = [];
+
+  /**
+   * Optional. For recursive subscriptions, only sync documents
+   * up to this depth below the path. 0 means no limit.
+   *
+   * @generated from field: int32 max_depth = 6;
+   */
+  maxDepth = 0;
+
   constructor(data?: PartialMessage<SubscribeRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -46,6 +71,9 @@ export class SubscribeRequest extends Message<SubscribeRequest> {
     { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 4, name: "blob_types", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "authors", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "max_depth", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SubscribeRequest {
@@ -249,6 +277,56 @@ export class Subscription extends Message<Subscription> {
    */
   since?: Timestamp;
 
+  /**
+   * Types of content blobs synced for this subscription. Empty means all.
+   *
+   * @generated from field: repeated string blob_types = 5;
+   */
+  blobTypes: string[] = [];
+
+  /**
+   * Authors of the content blobs synced for this subscription. Empty means all.
+   *
+   * @generated from field: repeated string authors = 6;
+   */
+  authors: string[] = [];
+
+  /**
+   * Maximum depth of the recursive subscription. 0 means no limit.
+   *
+   * @generated from field: int32 max_depth = 7;
+   */
+  maxDepth = 0;
+
+  /**
+   * Time of the last successful sync with any peer.
+   * Empty if the subscription was never synced.
+   *
+   * @generated from field: google.protobuf.Timestamp last_sync_time = 8;
+   */
+  lastSyncTime?: Timestamp;
+
+  /**
+   * Error of the last failed sync attempt if it happened after the last successful sync.
+   *
+   * @generated from field: string last_sync_error = 9;
+   */
+  lastSyncError = "";
+
+  /**
+   * Number of peers that had the subscribed content in the latest sync.
+   *
+   * @generated from field: int32 peers_with_content = 10;
+   */
+  peersWithContent = 0;
+
+  /**
+   * Number of blobs fetched from the peers in the latest sync.
+   *
+   * @generated from field: int32 blobs_fetched = 11;
+   */
+  blobsFetched = 0;
+
   constructor(data?: PartialMessage<Subscription>) {
     super();
     proto3.util.initPartial(data, this);
@@ -261,6 +339,13 @@ export class Subscription extends Message<Subscription> {
     { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "recursive", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 4, name: "since", kind: "message", T: Timestamp },
+    { no: 5, name: "blob_types", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 6, name: "authors", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 7, name: "max_depth", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 8, name: "last_sync_time", kind: "message", T: Timestamp },
+    { no: 9, name: "last_sync_error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "peers_with_content", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 11, name: "blobs_fetched", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Subscription {
@@ -280,3 +365,170 @@ export class Subscription extends Message<Subscription> {
   }
 }
 
+/**
+ * Request to stream the sync progress.
+ *
+ * @generated from message com.seed.activity.v1alpha.GetSyncProgressRequest
+ */
+export class GetSyncProgressRequest extends Message<GetSyncProgressRequest> {
+  /**
+   * Optional. Only stream the progress of the subscription to this account.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Optional. Only stream the progress of the subscription to this path.
+   * Only used if account is set. Empty string means root document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  constructor(data?: PartialMessage<GetSyncProgressRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.GetSyncProgressRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetSyncProgressRequest {
+    return new GetSyncProgressRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetSyncProgressRequest {
+    return new GetSyncProgressRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetSyncProgressRequest {
+    return new GetSyncProgressRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetSyncProgressRequest | PlainMessage<GetSyncProgressRequest> | undefined, b: GetSyncProgressRequest | PlainMessage<GetSyncProgressRequest> | undefined): boolean {
+    return proto3.util.equals(GetSyncProgressRequest, a, b);
+  }
+}
+
+/**
+ * Progress of syncing a subscribed resource with a single peer.
+ *
+ * @generated from message com.seed.activity.v1alpha.SyncProgress
+ */
+export class SyncProgress extends Message<SyncProgress> {
+  /**
+   * Account of the synced resource.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Path of the synced resource.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * ID of the peer we sync with.
+   *
+   * @generated from field: string peer_id = 3;
+   */
+  peerId = "";
+
+  /**
+   * Number of blobs the peer has and we don't.
+   * Only known after the reconciliation with the peer.
+   * All the subscriptions are reconciled with the peer at once,
+   * so this is the number for all of them.
+   *
+   * @generated from field: int32 blobs_wanted = 4;
+   */
+  blobsWanted = 0;
+
+  /**
+   * Number of wanted blobs of this resource fetched so far.
+   * Changes are counted once the ref pointing to them is fetched.
+   *
+   * @generated from field: int32 blobs_fetched = 5;
+   */
+  blobsFetched = 0;
+
+  /**
+   * Number of failed attempts to fetch the wanted blobs of all the subscriptions.
+   *
+   * @generated from field: int32 blobs_failed = 6;
+   */
+  blobsFailed = 0;
+
+  /**
+   * Whether the sync with the peer has finished.
+   *
+   * @generated from field: bool done = 7;
+   */
+  done = false;
+
+  /**
+   * Error of the sync if it finished with failure.
+   *
+   * @generated from field: string error = 8;
+   */
+  error = "";
+
+  /**
+   * Time when the sync with the peer started.
+   *
+   * @generated from field: google.protobuf.Timestamp start_time = 9;
+   */
+  startTime?: Timestamp;
+
+  /**
+   * Time of this update.
+   *
+   * @generated from field: google.protobuf.Timestamp update_time = 10;
+   */
+  updateTime?: Timestamp;
+
+  constructor(data?: PartialMessage<SyncProgress>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.SyncProgress";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "peer_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "blobs_wanted", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 5, name: "blobs_fetched", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "blobs_failed", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 7, name: "done", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 8, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 9, name: "start_time", kind: "message", T: Timestamp },
+    { no: 10, name: "update_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SyncProgress {
+    return new SyncProgress().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): SyncProgress {
+    return new SyncProgress().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): SyncProgress {
+    return new SyncProgress().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: SyncProgress | PlainMessage<SyncProgress> | undefined, b: SyncProgress | PlainMessage<SyncProgress> | undefined): boolean {
+    return proto3.util.equals(SyncProgress, a, b);
+  }
+}
+
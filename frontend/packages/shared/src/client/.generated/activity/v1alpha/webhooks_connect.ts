@@ -0,0 +1,81 @@
+// @generated by protoc-gen-connect-es v1.1.3 with parameter "target=ts,import_extension=none"
+// @generated from file activity/v1alpha/webhooks.proto (package com.seed.activity.v1alpha, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import { CreateWebhookRequest, DeleteWebhookRequest, GetWebhookRequest, ListWebhookDeliveriesRequest, ListWebhookDeliveriesResponse, ListWebhooksRequest, ListWebhooksResponse, Webhook } from "./webhooks_pb";
+import { Empty, MethodKind } from "@bufbuild/protobuf";
+
+/**
+ * Webhooks service manages the HTTP endpoints that receive activity events.
+ * For every event matching the filter of the webhook, the daemon sends a POST request
+ * with the JSON-encoded event to the URL of the webhook.
+ * The X-Seed-Timestamp header holds the Unix time of the request in seconds.
+ * The timestamp and the body joined with a dot ("<timestamp>.<body>") are signed with HMAC-SHA256
+ * using the secret of the webhook, and the hex-encoded signature is sent in the X-Seed-Signature header as "sha256=<signature>".
+ * Failed deliveries are retried with exponential backoff, and deliveries to a failing endpoint are paused meanwhile.
+ * Finished deliveries are kept in the delivery log for 7 days.
+ *
+ * @generated from service com.seed.activity.v1alpha.Webhooks
+ */
+export const Webhooks = {
+  typeName: "com.seed.activity.v1alpha.Webhooks",
+  methods: {
+    /**
+     * Registers a new webhook. Only the events observed after the registration are delivered.
+     *
+     * @generated from rpc com.seed.activity.v1alpha.Webhooks.CreateWebhook
+     */
+    createWebhook: {
+      name: "CreateWebhook",
+      I: CreateWebhookRequest,
+      O: Webhook,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Gets a single webhook.
+     *
+     * @generated from rpc com.seed.activity.v1alpha.Webhooks.GetWebhook
+     */
+    getWebhook: {
+      name: "GetWebhook",
+      I: GetWebhookRequest,
+      O: Webhook,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the registered webhooks.
+     *
+     * @generated from rpc com.seed.activity.v1alpha.Webhooks.ListWebhooks
+     */
+    listWebhooks: {
+      name: "ListWebhooks",
+      I: ListWebhooksRequest,
+      O: ListWebhooksResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Removes the webhook along with its delivery log.
+     *
+     * @generated from rpc com.seed.activity.v1alpha.Webhooks.DeleteWebhook
+     */
+    deleteWebhook: {
+      name: "DeleteWebhook",
+      I: DeleteWebhookRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Lists the delivery log of the webhook, newest first.
+     *
+     * @generated from rpc com.seed.activity.v1alpha.Webhooks.ListWebhookDeliveries
+     */
+    listWebhookDeliveries: {
+      name: "ListWebhookDeliveries",
+      I: ListWebhookDeliveriesRequest,
+      O: ListWebhookDeliveriesResponse,
+      kind: MethodKind.Unary,
+    },
+  }
+} as const;
+
@@ -0,0 +1,598 @@
+// @generated by protoc-gen-es v1.4.1 with parameter "target=ts,import_extension=none"
+// @generated from file activity/v1alpha/webhooks.proto (package com.seed.activity.v1alpha, syntax proto3)
+/* eslint-disable */
+// @ts-nocheck
+
+import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
+import { Message, proto3, protoInt64, Timestamp } from "@bufbuild/protobuf";
+import { Event } from "./activity_pb";
+
+/**
+ * Filter of the events delivered to the webhook.
+ * Semantics of the fields are the same as in ListEventsRequest.
+ *
+ * @generated from message com.seed.activity.v1alpha.WebhookFilter
+ */
+export class WebhookFilter extends Message<WebhookFilter> {
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_users = 2;
+   */
+  filterUsers: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_event_type = 3;
+   */
+  filterEventType: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string filter_resource = 4;
+   */
+  filterResource: string[] = [];
+
+  /**
+   * Optional. Same as in ListEventsRequest.
+   *
+   * @generated from field: repeated string add_linked_resource = 5;
+   */
+  addLinkedResource: string[] = [];
+
+  constructor(data?: PartialMessage<WebhookFilter>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.WebhookFilter";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 2, name: "filter_users", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 3, name: "filter_event_type", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 4, name: "filter_resource", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 5, name: "add_linked_resource", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WebhookFilter {
+    return new WebhookFilter().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WebhookFilter {
+    return new WebhookFilter().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WebhookFilter {
+    return new WebhookFilter().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WebhookFilter | PlainMessage<WebhookFilter> | undefined, b: WebhookFilter | PlainMessage<WebhookFilter> | undefined): boolean {
+    return proto3.util.equals(WebhookFilter, a, b);
+  }
+}
+
+/**
+ * Request to register a webhook.
+ *
+ * @generated from message com.seed.activity.v1alpha.CreateWebhookRequest
+ */
+export class CreateWebhookRequest extends Message<CreateWebhookRequest> {
+  /**
+   * Required. The HTTP or HTTPS endpoint to deliver the events to.
+   *
+   * @generated from field: string url = 1;
+   */
+  url = "";
+
+  /**
+   * Optional. The filter of the events. All the events are delivered by default.
+   *
+   * @generated from field: com.seed.activity.v1alpha.WebhookFilter filter = 2;
+   */
+  filter?: WebhookFilter;
+
+  constructor(data?: PartialMessage<CreateWebhookRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.CreateWebhookRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "filter", kind: "message", T: WebhookFilter },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateWebhookRequest {
+    return new CreateWebhookRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CreateWebhookRequest {
+    return new CreateWebhookRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CreateWebhookRequest {
+    return new CreateWebhookRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CreateWebhookRequest | PlainMessage<CreateWebhookRequest> | undefined, b: CreateWebhookRequest | PlainMessage<CreateWebhookRequest> | undefined): boolean {
+    return proto3.util.equals(CreateWebhookRequest, a, b);
+  }
+}
+
+/**
+ * Request to get a webhook.
+ *
+ * @generated from message com.seed.activity.v1alpha.GetWebhookRequest
+ */
+export class GetWebhookRequest extends Message<GetWebhookRequest> {
+  /**
+   * Required. ID of the webhook.
+   *
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  constructor(data?: PartialMessage<GetWebhookRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.GetWebhookRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): GetWebhookRequest {
+    return new GetWebhookRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): GetWebhookRequest {
+    return new GetWebhookRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): GetWebhookRequest {
+    return new GetWebhookRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: GetWebhookRequest | PlainMessage<GetWebhookRequest> | undefined, b: GetWebhookRequest | PlainMessage<GetWebhookRequest> | undefined): boolean {
+    return proto3.util.equals(GetWebhookRequest, a, b);
+  }
+}
+
+/**
+ * Request to list webhooks.
+ *
+ * @generated from message com.seed.activity.v1alpha.ListWebhooksRequest
+ */
+export class ListWebhooksRequest extends Message<ListWebhooksRequest> {
+  /**
+   * Optional. The size of the page. The default is defined by the server.
+   *
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. The page token for requesting next pages.
+   *
+   * @generated from field: string page_token = 2;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListWebhooksRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.ListWebhooksRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 2, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhooksRequest {
+    return new ListWebhooksRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhooksRequest {
+    return new ListWebhooksRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhooksRequest {
+    return new ListWebhooksRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhooksRequest | PlainMessage<ListWebhooksRequest> | undefined, b: ListWebhooksRequest | PlainMessage<ListWebhooksRequest> | undefined): boolean {
+    return proto3.util.equals(ListWebhooksRequest, a, b);
+  }
+}
+
+/**
+ * Response with the list of webhooks.
+ *
+ * @generated from message com.seed.activity.v1alpha.ListWebhooksResponse
+ */
+export class ListWebhooksResponse extends Message<ListWebhooksResponse> {
+  /**
+   * The list of webhooks.
+   *
+   * @generated from field: repeated com.seed.activity.v1alpha.Webhook webhooks = 1;
+   */
+  webhooks: Webhook[] = [];
+
+  /**
+   * The token to request the next page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListWebhooksResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.ListWebhooksResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "webhooks", kind: "message", T: Webhook, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhooksResponse {
+    return new ListWebhooksResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhooksResponse {
+    return new ListWebhooksResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhooksResponse {
+    return new ListWebhooksResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhooksResponse | PlainMessage<ListWebhooksResponse> | undefined, b: ListWebhooksResponse | PlainMessage<ListWebhooksResponse> | undefined): boolean {
+    return proto3.util.equals(ListWebhooksResponse, a, b);
+  }
+}
+
+/**
+ * Request to delete a webhook.
+ *
+ * @generated from message com.seed.activity.v1alpha.DeleteWebhookRequest
+ */
+export class DeleteWebhookRequest extends Message<DeleteWebhookRequest> {
+  /**
+   * Required. ID of the webhook.
+   *
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  constructor(data?: PartialMessage<DeleteWebhookRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.DeleteWebhookRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteWebhookRequest {
+    return new DeleteWebhookRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteWebhookRequest {
+    return new DeleteWebhookRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteWebhookRequest {
+    return new DeleteWebhookRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteWebhookRequest | PlainMessage<DeleteWebhookRequest> | undefined, b: DeleteWebhookRequest | PlainMessage<DeleteWebhookRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteWebhookRequest, a, b);
+  }
+}
+
+/**
+ * Request to list the deliveries of a webhook.
+ *
+ * @generated from message com.seed.activity.v1alpha.ListWebhookDeliveriesRequest
+ */
+export class ListWebhookDeliveriesRequest extends Message<ListWebhookDeliveriesRequest> {
+  /**
+   * Required. ID of the webhook.
+   *
+   * @generated from field: int64 webhook_id = 1;
+   */
+  webhookId = protoInt64.zero;
+
+  /**
+   * Optional. The size of the page. The default is defined by the server.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. The page token for requesting next pages.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken = "";
+
+  constructor(data?: PartialMessage<ListWebhookDeliveriesRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.ListWebhookDeliveriesRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "webhook_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhookDeliveriesRequest {
+    return new ListWebhookDeliveriesRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesRequest {
+    return new ListWebhookDeliveriesRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesRequest {
+    return new ListWebhookDeliveriesRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhookDeliveriesRequest | PlainMessage<ListWebhookDeliveriesRequest> | undefined, b: ListWebhookDeliveriesRequest | PlainMessage<ListWebhookDeliveriesRequest> | undefined): boolean {
+    return proto3.util.equals(ListWebhookDeliveriesRequest, a, b);
+  }
+}
+
+/**
+ * Response with the list of deliveries.
+ *
+ * @generated from message com.seed.activity.v1alpha.ListWebhookDeliveriesResponse
+ */
+export class ListWebhookDeliveriesResponse extends Message<ListWebhookDeliveriesResponse> {
+  /**
+   * The list of deliveries.
+   *
+   * @generated from field: repeated com.seed.activity.v1alpha.WebhookDelivery deliveries = 1;
+   */
+  deliveries: WebhookDelivery[] = [];
+
+  /**
+   * The token to request the next page.
+   *
+   * @generated from field: string next_page_token = 2;
+   */
+  nextPageToken = "";
+
+  constructor(data?: PartialMessage<ListWebhookDeliveriesResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.ListWebhookDeliveriesResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "deliveries", kind: "message", T: WebhookDelivery, repeated: true },
+    { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListWebhookDeliveriesResponse {
+    return new ListWebhookDeliveriesResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesResponse {
+    return new ListWebhookDeliveriesResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ListWebhookDeliveriesResponse {
+    return new ListWebhookDeliveriesResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ListWebhookDeliveriesResponse | PlainMessage<ListWebhookDeliveriesResponse> | undefined, b: ListWebhookDeliveriesResponse | PlainMessage<ListWebhookDeliveriesResponse> | undefined): boolean {
+    return proto3.util.equals(ListWebhookDeliveriesResponse, a, b);
+  }
+}
+
+/**
+ * Description of the registered webhook.
+ *
+ * @generated from message com.seed.activity.v1alpha.Webhook
+ */
+export class Webhook extends Message<Webhook> {
+  /**
+   * ID of the webhook.
+   *
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  /**
+   * The endpoint the events are delivered to.
+   *
+   * @generated from field: string url = 2;
+   */
+  url = "";
+
+  /**
+   * The filter of the events.
+   *
+   * @generated from field: com.seed.activity.v1alpha.WebhookFilter filter = 3;
+   */
+  filter?: WebhookFilter;
+
+  /**
+   * The secret key used to sign the payloads.
+   *
+   * @generated from field: string secret = 4;
+   */
+  secret = "";
+
+  /**
+   * Time when the webhook was registered.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 5;
+   */
+  createTime?: Timestamp;
+
+  constructor(data?: PartialMessage<Webhook>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.Webhook";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "filter", kind: "message", T: WebhookFilter },
+    { no: 4, name: "secret", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "create_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Webhook {
+    return new Webhook().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Webhook {
+    return new Webhook().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Webhook {
+    return new Webhook().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Webhook | PlainMessage<Webhook> | undefined, b: Webhook | PlainMessage<Webhook> | undefined): boolean {
+    return proto3.util.equals(Webhook, a, b);
+  }
+}
+
+/**
+ * Description of a single delivery of an event to the webhook.
+ *
+ * @generated from message com.seed.activity.v1alpha.WebhookDelivery
+ */
+export class WebhookDelivery extends Message<WebhookDelivery> {
+  /**
+   * ID of the delivery.
+   *
+   * @generated from field: int64 id = 1;
+   */
+  id = protoInt64.zero;
+
+  /**
+   * ID of the webhook.
+   *
+   * @generated from field: int64 webhook_id = 2;
+   */
+  webhookId = protoInt64.zero;
+
+  /**
+   * The delivered event.
+   *
+   * @generated from field: com.seed.activity.v1alpha.Event event = 3;
+   */
+  event?: Event;
+
+  /**
+   * Status of the delivery: pending, delivered, or failed.
+   * Failed deliveries are not retried anymore.
+   *
+   * @generated from field: string status = 4;
+   */
+  status = "";
+
+  /**
+   * Number of delivery attempts made so far.
+   *
+   * @generated from field: int32 attempts = 5;
+   */
+  attempts = 0;
+
+  /**
+   * HTTP status code of the last attempt.
+   * Zero if the request failed without a response.
+   *
+   * @generated from field: int32 response_code = 6;
+   */
+  responseCode = 0;
+
+  /**
+   * Error of the last failed attempt.
+   *
+   * @generated from field: string error = 7;
+   */
+  error = "";
+
+  /**
+   * Time when the delivery was created.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 8;
+   */
+  createTime?: Timestamp;
+
+  /**
+   * Time of the last attempt.
+   *
+   * @generated from field: google.protobuf.Timestamp update_time = 9;
+   */
+  updateTime?: Timestamp;
+
+  /**
+   * Time of the next attempt for pending deliveries.
+   *
+   * @generated from field: google.protobuf.Timestamp next_attempt_time = 10;
+   */
+  nextAttemptTime?: Timestamp;
+
+  constructor(data?: PartialMessage<WebhookDelivery>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.activity.v1alpha.WebhookDelivery";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 2, name: "webhook_id", kind: "scalar", T: 3 /* ScalarType.INT64 */ },
+    { no: 3, name: "event", kind: "message", T: Event },
+    { no: 4, name: "status", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "attempts", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 6, name: "response_code", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 7, name: "error", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "create_time", kind: "message", T: Timestamp },
+    { no: 9, name: "update_time", kind: "message", T: Timestamp },
+    { no: 10, name: "next_attempt_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WebhookDelivery {
+    return new WebhookDelivery().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WebhookDelivery {
+    return new WebhookDelivery().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WebhookDelivery {
+    return new WebhookDelivery().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WebhookDelivery | PlainMessage<WebhookDelivery> | undefined, b: WebhookDelivery | PlainMessage<WebhookDelivery> | undefined): boolean {
+    return proto3.util.equals(WebhookDelivery, a, b);
+  }
+}
+
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { Capability, CreateCapabilityRequest, GetCapabilityRequest, ListCapabilitiesRequest, ListCapabilitiesResponse } from "./access_control_pb";
+import { Capability, CreateCapabilityRequest, GetCapabilityRequest, ListCapabilitiesRequest, ListCapabilitiesResponse, Revocation, RevokeCapabilityRequest } from "./access_control_pb";
 import { MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -47,6 +47,18 @@ export const AccessControl = {
       O: Capability,
       kind: MethodKind.Unary,
     },
+    /**
+     * Revokes a previously issued capability.
+     * Changes made by the delegate after the revocation are no longer honored.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.AccessControl.RevokeCapability
+     */
+    revokeCapability: {
+      name: "RevokeCapability",
+      I: RevokeCapabilityRequest,
+      O: Revocation,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
@@ -17,17 +17,34 @@ export enum Role {
    */
   ROLE_UNSPECIFIED = 0,
 
+  /**
+   * Has write access to the document,
+   * and can delegate lower roles for the document and its subpaths.
+   *
+   * @generated from enum value: EDITOR = 1;
+   */
+  EDITOR = 1,
+
   /**
    * Has write access to the document
    *
    * @generated from enum value: WRITER = 2;
    */
   WRITER = 2,
+
+  /**
+   * Can comment on the document, but can't write to it.
+   *
+   * @generated from enum value: COLLABORATOR = 3;
+   */
+  COLLABORATOR = 3,
 }
 // Retrieve enum metadata with: proto3.getEnumType(Role)
 proto3.util.setEnumType(Role, "com.seed.documents.v3alpha.Role", [
   { no: 0, name: "ROLE_UNSPECIFIED" },
+  { no: 1, name: "EDITOR" },
   { no: 2, name: "WRITER" },
+  { no: 3, name: "COLLABORATOR" },
 ]);
 
 /**
@@ -75,6 +92,27 @@ export class ListCapabilitiesRequest extends Message<ListCapabilitiesRequest> {
    */
   pageToken = "";
 
+  /**
+   * Optional. Only return capabilities delegated to this account.
+   *
+   * @generated from field: string delegate = 6;
+   */
+  delegate = "";
+
+  /**
+   * Optional. Only return capabilities issued by this account.
+   *
+   * @generated from field: string issuer = 7;
+   */
+  issuer = "";
+
+  /**
+   * Optional. Only return capabilities with this role.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Role role = 8;
+   */
+  role = Role.ROLE_UNSPECIFIED;
+
   constructor(data?: PartialMessage<ListCapabilitiesRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -88,6 +126,9 @@ export class ListCapabilitiesRequest extends Message<ListCapabilitiesRequest> {
     { no: 3, name: "ignore_inherited", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 4, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
     { no: 5, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "delegate", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "issuer", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListCapabilitiesRequest {
@@ -164,6 +205,7 @@ export class ListCapabilitiesResponse extends Message<ListCapabilitiesResponse>
 export class CreateCapabilityRequest extends Message<CreateCapabilityRequest> {
   /**
    * Required. Name of the key to use for signing the capability.
+   * Must be the owner of the account, or an editor with a capability for the parent path.
    *
    * @generated from field: string signing_key_name = 1;
    */
@@ -281,6 +323,121 @@ export class GetCapabilityRequest extends Message<GetCapabilityRequest> {
   }
 }
 
+/**
+ * Request to revoke a capability.
+ *
+ * @generated from message com.seed.documents.v3alpha.RevokeCapabilityRequest
+ */
+export class RevokeCapabilityRequest extends Message<RevokeCapabilityRequest> {
+  /**
+   * Required. Name of the key to use for signing the revocation.
+   * Must be the issuer of the capability.
+   *
+   * @generated from field: string signing_key_name = 1;
+   */
+  signingKeyName = "";
+
+  /**
+   * Required. ID of the capability to revoke.
+   *
+   * @generated from field: string id = 2;
+   */
+  id = "";
+
+  constructor(data?: PartialMessage<RevokeCapabilityRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.RevokeCapabilityRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): RevokeCapabilityRequest {
+    return new RevokeCapabilityRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): RevokeCapabilityRequest {
+    return new RevokeCapabilityRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): RevokeCapabilityRequest {
+    return new RevokeCapabilityRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: RevokeCapabilityRequest | PlainMessage<RevokeCapabilityRequest> | undefined, b: RevokeCapabilityRequest | PlainMessage<RevokeCapabilityRequest> | undefined): boolean {
+    return proto3.util.equals(RevokeCapabilityRequest, a, b);
+  }
+}
+
+/**
+ * Revocation of a capability.
+ *
+ * @generated from message com.seed.documents.v3alpha.Revocation
+ */
+export class Revocation extends Message<Revocation> {
+  /**
+   * ID of this revocation.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * ID of the revoked capability.
+   *
+   * @generated from field: string capability = 2;
+   */
+  capability = "";
+
+  /**
+   * ID of the account that issued the revocation.
+   *
+   * @generated from field: string issuer = 3;
+   */
+  issuer = "";
+
+  /**
+   * Timestamp when the capability was revoked.
+   *
+   * @generated from field: google.protobuf.Timestamp create_time = 4;
+   */
+  createTime?: Timestamp;
+
+  constructor(data?: PartialMessage<Revocation>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.Revocation";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "issuer", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "create_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Revocation {
+    return new Revocation().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): Revocation {
+    return new Revocation().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): Revocation {
+    return new Revocation().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: Revocation | PlainMessage<Revocation> | undefined, b: Revocation | PlainMessage<Revocation> | undefined): boolean {
+    return proto3.util.equals(Revocation, a, b);
+  }
+}
+
 /**
  * Capability is an unforgeable token that grants access to a specific path within an account.
  *
@@ -346,6 +503,15 @@ export class Capability extends Message<Capability> {
    */
   createTime?: Timestamp;
 
+  /**
+   * Highest role the delegate has for the requested path,
+   * taking into account all of their capabilities, including the inherited ones.
+   * Only set when listing capabilities.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Role effective_role = 9;
+   */
+  effectiveRole = Role.ROLE_UNSPECIFIED;
+
   constructor(data?: PartialMessage<Capability>) {
     super();
     proto3.util.initPartial(data, this);
@@ -362,6 +528,7 @@ export class Capability extends Message<Capability> {
     { no: 6, name: "role", kind: "enum", T: proto3.getEnumType(Role) },
     { no: 7, name: "is_exact", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 8, name: "create_time", kind: "message", T: Timestamp },
+    { no: 9, name: "effective_role", kind: "enum", T: proto3.getEnumType(Role) },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Capability {
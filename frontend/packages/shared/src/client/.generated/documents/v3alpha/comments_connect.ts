@@ -3,8 +3,8 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { Comment, CreateCommentRequest, GetCommentRequest, ListCommentsRequest, ListCommentsResponse } from "./comments_pb";
-import { MethodKind } from "@bufbuild/protobuf";
+import { Comment, CommentThread, CreateCommentRequest, DeleteCommentRequest, GetCommentRequest, ListCommentsRequest, ListCommentsResponse, ResolveThreadRequest, UpdateCommentRequest } from "./comments_pb";
+import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
  * Comments service allows users to add comments to documents.
@@ -47,6 +47,43 @@ export const Comments = {
       O: ListCommentsResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Updates the content of an existing comment.
+     * Only the author of the comment can update it.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Comments.UpdateComment
+     */
+    updateComment: {
+      name: "UpdateComment",
+      I: UpdateCommentRequest,
+      O: Comment,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Deletes an existing comment.
+     * Deleted comments are kept as tombstones to preserve the structure of the threads.
+     * Only the author of the comment can delete it.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Comments.DeleteComment
+     */
+    deleteComment: {
+      name: "DeleteComment",
+      I: DeleteCommentRequest,
+      O: Empty,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Marks a comment thread as resolved or reopens it.
+     * Only the owner of the target document or the author of the thread can resolve it.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Comments.ResolveThread
+     */
+    resolveThread: {
+      name: "ResolveThread",
+      I: ResolveThreadRequest,
+      O: CommentThread,
+      kind: MethodKind.Unary,
+    },
   }
 } as const;
 
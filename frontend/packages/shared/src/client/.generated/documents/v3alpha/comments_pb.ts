@@ -7,6 +7,41 @@ import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialM
 import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
 import { BlockNode } from "./documents_pb";
 
+/**
+ * Filter for comments based on the authorization of their authors.
+ *
+ * @generated from enum com.seed.documents.v3alpha.CommentAuthorFilter
+ */
+export enum CommentAuthorFilter {
+  /**
+   * All comments are returned.
+   *
+   * @generated from enum value: COMMENT_AUTHOR_FILTER_UNSPECIFIED = 0;
+   */
+  COMMENT_AUTHOR_FILTER_UNSPECIFIED = 0,
+
+  /**
+   * Only comments from the owner of the target account,
+   * or from the members commenting with a valid capability for the target.
+   *
+   * @generated from enum value: MEMBERS = 1;
+   */
+  MEMBERS = 1,
+
+  /**
+   * Only comments from the authors without a valid capability for the target.
+   *
+   * @generated from enum value: PUBLIC = 2;
+   */
+  PUBLIC = 2,
+}
+// Retrieve enum metadata with: proto3.getEnumType(CommentAuthorFilter)
+proto3.util.setEnumType(CommentAuthorFilter, "com.seed.documents.v3alpha.CommentAuthorFilter", [
+  { no: 0, name: "COMMENT_AUTHOR_FILTER_UNSPECIFIED" },
+  { no: 1, name: "MEMBERS" },
+  { no: 2, name: "PUBLIC" },
+]);
+
 /**
  * Request to create a comment.
  *
@@ -60,11 +95,19 @@ export class CreateCommentRequest extends Message<CreateCommentRequest> {
   /**
    * Optional. ID of the capability that allows publishing comments for the target account and path.
    * Anyone can create comments to anything, but having a capability to comment makes sure your comments are propagated along with the content.
+   * The capability must be delegated to the signing key, and can have any role.
    *
    * @generated from field: string capability = 7;
    */
   capability = "";
 
+  /**
+   * Optional. Anchor of the comment to a block of the target version of the document.
+   *
+   * @generated from field: com.seed.documents.v3alpha.CommentAnchor anchor = 8;
+   */
+  anchor?: CommentAnchor;
+
   constructor(data?: PartialMessage<CreateCommentRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -80,6 +123,7 @@ export class CreateCommentRequest extends Message<CreateCommentRequest> {
     { no: 5, name: "content", kind: "message", T: BlockNode, repeated: true },
     { no: 6, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 7, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "anchor", kind: "message", T: CommentAnchor },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CreateCommentRequest {
@@ -140,6 +184,172 @@ export class GetCommentRequest extends Message<GetCommentRequest> {
   }
 }
 
+/**
+ * Request to update a comment.
+ *
+ * @generated from message com.seed.documents.v3alpha.UpdateCommentRequest
+ */
+export class UpdateCommentRequest extends Message<UpdateCommentRequest> {
+  /**
+   * Required. ID of the comment to update.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Required. New content of the comment.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.BlockNode content = 2;
+   */
+  content: BlockNode[] = [];
+
+  /**
+   * Required. Name of the key to use for signing the new version of the comment.
+   * Must be the author of the comment.
+   *
+   * @generated from field: string signing_key_name = 3;
+   */
+  signingKeyName = "";
+
+  constructor(data?: PartialMessage<UpdateCommentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.UpdateCommentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "content", kind: "message", T: BlockNode, repeated: true },
+    { no: 3, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): UpdateCommentRequest {
+    return new UpdateCommentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): UpdateCommentRequest {
+    return new UpdateCommentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): UpdateCommentRequest {
+    return new UpdateCommentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: UpdateCommentRequest | PlainMessage<UpdateCommentRequest> | undefined, b: UpdateCommentRequest | PlainMessage<UpdateCommentRequest> | undefined): boolean {
+    return proto3.util.equals(UpdateCommentRequest, a, b);
+  }
+}
+
+/**
+ * Request to delete a comment.
+ *
+ * @generated from message com.seed.documents.v3alpha.DeleteCommentRequest
+ */
+export class DeleteCommentRequest extends Message<DeleteCommentRequest> {
+  /**
+   * Required. ID of the comment to delete.
+   *
+   * @generated from field: string id = 1;
+   */
+  id = "";
+
+  /**
+   * Required. Name of the key to use for signing the deletion.
+   * Must be the author of the comment.
+   *
+   * @generated from field: string signing_key_name = 2;
+   */
+  signingKeyName = "";
+
+  constructor(data?: PartialMessage<DeleteCommentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DeleteCommentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteCommentRequest {
+    return new DeleteCommentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DeleteCommentRequest {
+    return new DeleteCommentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DeleteCommentRequest {
+    return new DeleteCommentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DeleteCommentRequest | PlainMessage<DeleteCommentRequest> | undefined, b: DeleteCommentRequest | PlainMessage<DeleteCommentRequest> | undefined): boolean {
+    return proto3.util.equals(DeleteCommentRequest, a, b);
+  }
+}
+
+/**
+ * Request to resolve or reopen a comment thread.
+ *
+ * @generated from message com.seed.documents.v3alpha.ResolveThreadRequest
+ */
+export class ResolveThreadRequest extends Message<ResolveThreadRequest> {
+  /**
+   * Required. ID of the root comment of the thread.
+   *
+   * @generated from field: string thread_root = 1;
+   */
+  threadRoot = "";
+
+  /**
+   * Required. Name of the key to use for signing the resolution.
+   * Must be the owner of the target document, or the author of the thread.
+   *
+   * @generated from field: string signing_key_name = 2;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. Whether the thread is resolved. False reopens a resolved thread.
+   *
+   * @generated from field: bool resolved = 3;
+   */
+  resolved = false;
+
+  constructor(data?: PartialMessage<ResolveThreadRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ResolveThreadRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "thread_root", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "resolved", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ResolveThreadRequest {
+    return new ResolveThreadRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ResolveThreadRequest {
+    return new ResolveThreadRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ResolveThreadRequest {
+    return new ResolveThreadRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ResolveThreadRequest | PlainMessage<ResolveThreadRequest> | undefined, b: ResolveThreadRequest | PlainMessage<ResolveThreadRequest> | undefined): boolean {
+    return proto3.util.equals(ResolveThreadRequest, a, b);
+  }
+}
+
 /**
  * Request to list comments.
  *
@@ -162,6 +372,7 @@ export class ListCommentsRequest extends Message<ListCommentsRequest> {
 
   /**
    * Optional. The maximum number of comments to return.
+   * All the comments are returned if neither page size nor page token are specified.
    *
    * @generated from field: int32 page_size = 3;
    */
@@ -174,6 +385,31 @@ export class ListCommentsRequest extends Message<ListCommentsRequest> {
    */
   pageToken = "";
 
+  /**
+   * Optional. Filters comments by whether their authors are members of the target.
+   * By default all comments are returned.
+   *
+   * @generated from field: com.seed.documents.v3alpha.CommentAuthorFilter author_filter = 5;
+   */
+  authorFilter = CommentAuthorFilter.COMMENT_AUTHOR_FILTER_UNSPECIFIED;
+
+  /**
+   * Optional. Version of the target document to map the comment anchors to.
+   * Anchors are re-mapped across the changes made to their blocks after the comments were created.
+   * By default anchors are returned as they were created.
+   *
+   * @generated from field: string anchor_version = 6;
+   */
+  anchorVersion = "";
+
+  /**
+   * Optional. Returns the comments grouped into threads instead of a flat list.
+   * Pagination and the author filter are applied to the threads by their root comments.
+   *
+   * @generated from field: bool threaded = 7;
+   */
+  threaded = false;
+
   constructor(data?: PartialMessage<ListCommentsRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -186,6 +422,9 @@ export class ListCommentsRequest extends Message<ListCommentsRequest> {
     { no: 2, name: "target_path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
     { no: 4, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "author_filter", kind: "enum", T: proto3.getEnumType(CommentAuthorFilter) },
+    { no: 6, name: "anchor_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 7, name: "threaded", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListCommentsRequest {
@@ -212,7 +451,7 @@ export class ListCommentsRequest extends Message<ListCommentsRequest> {
  */
 export class ListCommentsResponse extends Message<ListCommentsResponse> {
   /**
-   * List of comments.
+   * List of comments. Empty in the threaded mode.
    *
    * @generated from field: repeated com.seed.documents.v3alpha.Comment comments = 1;
    */
@@ -225,6 +464,13 @@ export class ListCommentsResponse extends Message<ListCommentsResponse> {
    */
   nextPageToken = "";
 
+  /**
+   * List of comment threads, only in the threaded mode.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.CommentThread threads = 3;
+   */
+  threads: CommentThread[] = [];
+
   constructor(data?: PartialMessage<ListCommentsResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -235,6 +481,7 @@ export class ListCommentsResponse extends Message<ListCommentsResponse> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "comments", kind: "message", T: Comment, repeated: true },
     { no: 2, name: "next_page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "threads", kind: "message", T: CommentThread, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListCommentsResponse {
@@ -254,6 +501,88 @@ export class ListCommentsResponse extends Message<ListCommentsResponse> {
   }
 }
 
+/**
+ * Thread of comments, started by a top-level comment.
+ *
+ * @generated from message com.seed.documents.v3alpha.CommentThread
+ */
+export class CommentThread extends Message<CommentThread> {
+  /**
+   * Root comment of the thread.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Comment root = 1;
+   */
+  root?: Comment;
+
+  /**
+   * Replies in the thread, in depth-first order following the reply parents,
+   * with sibling replies ordered by their creation time.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.Comment replies = 2;
+   */
+  replies: Comment[] = [];
+
+  /**
+   * Number of replies in the thread.
+   *
+   * @generated from field: int32 reply_count = 3;
+   */
+  replyCount = 0;
+
+  /**
+   * Whether the thread is resolved.
+   *
+   * @generated from field: bool is_resolved = 4;
+   */
+  isResolved = false;
+
+  /**
+   * Optional. Account ID of the author of the latest resolution of the thread.
+   *
+   * @generated from field: string resolved_by = 5;
+   */
+  resolvedBy = "";
+
+  /**
+   * Optional. Timestamp of the latest resolution of the thread.
+   *
+   * @generated from field: google.protobuf.Timestamp resolve_time = 6;
+   */
+  resolveTime?: Timestamp;
+
+  constructor(data?: PartialMessage<CommentThread>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CommentThread";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "root", kind: "message", T: Comment },
+    { no: 2, name: "replies", kind: "message", T: Comment, repeated: true },
+    { no: 3, name: "reply_count", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 4, name: "is_resolved", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 5, name: "resolved_by", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "resolve_time", kind: "message", T: Timestamp },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CommentThread {
+    return new CommentThread().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CommentThread {
+    return new CommentThread().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CommentThread {
+    return new CommentThread().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CommentThread | PlainMessage<CommentThread> | undefined, b: CommentThread | PlainMessage<CommentThread> | undefined): boolean {
+    return proto3.util.equals(CommentThread, a, b);
+  }
+}
+
 /**
  * Comment is a unit of discussion.
  *
@@ -331,6 +660,29 @@ export class Comment extends Message<Comment> {
    */
   capability = "";
 
+  /**
+   * Optional. Timestamp of the latest edit of the comment.
+   * Empty if the comment was never edited.
+   *
+   * @generated from field: google.protobuf.Timestamp edit_time = 11;
+   */
+  editTime?: Timestamp;
+
+  /**
+   * Whether the comment was deleted by its author.
+   * Deleted comments have no content, but are kept to preserve the structure of the threads.
+   *
+   * @generated from field: bool is_deleted = 12;
+   */
+  isDeleted = false;
+
+  /**
+   * Optional. Anchor of the comment to a block of the target document.
+   *
+   * @generated from field: com.seed.documents.v3alpha.CommentAnchor anchor = 13;
+   */
+  anchor?: CommentAnchor;
+
   constructor(data?: PartialMessage<Comment>) {
     super();
     proto3.util.initPartial(data, this);
@@ -349,6 +701,9 @@ export class Comment extends Message<Comment> {
     { no: 8, name: "content", kind: "message", T: BlockNode, repeated: true },
     { no: 9, name: "create_time", kind: "message", T: Timestamp },
     { no: 10, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 11, name: "edit_time", kind: "message", T: Timestamp },
+    { no: 12, name: "is_deleted", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 13, name: "anchor", kind: "message", T: CommentAnchor },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Comment {
@@ -368,3 +723,74 @@ export class Comment extends Message<Comment> {
   }
 }
 
+/**
+ * Anchor of a comment to a range of text within a block.
+ *
+ * @generated from message com.seed.documents.v3alpha.CommentAnchor
+ */
+export class CommentAnchor extends Message<CommentAnchor> {
+  /**
+   * Required. ID of the block the comment is anchored to.
+   *
+   * @generated from field: string block_id = 1;
+   */
+  blockId = "";
+
+  /**
+   * Optional. Start offsets of the spans of the block text the comment is anchored to,
+   * in UTF-16 code units, same as in annotations.
+   * Must be sorted and have the same number of items as `ends` list.
+   * Empty means the whole block.
+   *
+   * @generated from field: repeated int32 starts = 2;
+   */
+  starts: number[] = [];
+
+  /**
+   * Optional. End offsets of the spans of the block text the comment is anchored to.
+   * Must be sorted and have the same number of items as `starts` list.
+   *
+   * @generated from field: repeated int32 ends = 3;
+   */
+  ends: number[] = [];
+
+  /**
+   * Output only. Whether the anchor couldn't be mapped to the requested version of the document,
+   * because the block was deleted, or the anchored text was removed.
+   * Orphaned anchors keep the offsets from the last version where they were valid.
+   *
+   * @generated from field: bool is_orphaned = 4;
+   */
+  isOrphaned = false;
+
+  constructor(data?: PartialMessage<CommentAnchor>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.CommentAnchor";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "starts", kind: "scalar", T: 5 /* ScalarType.INT32 */, repeated: true },
+    { no: 3, name: "ends", kind: "scalar", T: 5 /* ScalarType.INT32 */, repeated: true },
+    { no: 4, name: "is_orphaned", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): CommentAnchor {
+    return new CommentAnchor().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): CommentAnchor {
+    return new CommentAnchor().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): CommentAnchor {
+    return new CommentAnchor().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: CommentAnchor | PlainMessage<CommentAnchor> | undefined, b: CommentAnchor | PlainMessage<CommentAnchor> | undefined): boolean {
+    return proto3.util.equals(CommentAnchor, a, b);
+  }
+}
+
@@ -3,7 +3,7 @@
 /* eslint-disable */
 // @ts-nocheck
 
-import { CreateDocumentChangeRequest, DeleteDocumentRequest, Document, GetDocumentRequest, ListDocumentChangesRequest, ListDocumentChangesResponse, ListDocumentsRequest, ListDocumentsResponse, ListRootDocumentsRequest, ListRootDocumentsResponse } from "./documents_pb";
+import { CreateDocumentChangeRequest, DeleteDocumentRequest, DiffDocumentRequest, Document, DocumentDiff, DocumentUpdate, ExportDocumentRequest, ExportDocumentResponse, GetDocumentRequest, ImportMarkdownRequest, ListDocumentChangesRequest, ListDocumentChangesResponse, ListDocumentsRequest, ListDocumentsResponse, ListRootDocumentsRequest, ListRootDocumentsResponse, WatchDocumentRequest } from "./documents_pb";
 import { Empty, MethodKind } from "@bufbuild/protobuf";
 
 /**
@@ -37,7 +37,9 @@ export const Documents = {
       kind: MethodKind.Unary,
     },
     /**
-     * Deletes a document.
+     * Deletes a document by publishing a signed tombstone.
+     * Deleted documents are hidden from the list requests, and other peers will hide them too after syncing.
+     * Creating new changes for the same path after the deletion makes the document visible again.
      *
      * @generated from rpc com.seed.documents.v3alpha.Documents.DeleteDocument
      */
@@ -80,6 +82,54 @@ export const Documents = {
       O: ListDocumentChangesResponse,
       kind: MethodKind.Unary,
     },
+    /**
+     * Returns block-level differences between two versions of a document.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.DiffDocument
+     */
+    diffDocument: {
+      name: "DiffDocument",
+      I: DiffDocumentRequest,
+      O: DocumentDiff,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Renders a document as CommonMark text.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.ExportDocument
+     */
+    exportDocument: {
+      name: "ExportDocument",
+      I: ExportDocumentRequest,
+      O: ExportDocumentResponse,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Creates a new document change replacing the content of the document with the provided Markdown.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.ImportMarkdown
+     */
+    importMarkdown: {
+      name: "ImportMarkdown",
+      I: ImportMarkdownRequest,
+      O: Document,
+      kind: MethodKind.Unary,
+    },
+    /**
+     * Streams updates of a document whenever new changes for it are indexed,
+     * either created locally or received from other peers.
+     * The first update reflects the state of the document at the time of the subscription.
+     * Deleting the document emits an update with the deleted flag,
+     * and the stream continues in case the document comes back.
+     *
+     * @generated from rpc com.seed.documents.v3alpha.Documents.WatchDocument
+     */
+    watchDocument: {
+      name: "WatchDocument",
+      I: WatchDocumentRequest,
+      O: DocumentUpdate,
+      kind: MethodKind.ServerStreaming,
+    },
   }
 } as const;
 
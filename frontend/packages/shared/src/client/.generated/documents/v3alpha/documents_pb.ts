@@ -6,6 +6,48 @@
 import type { BinaryReadOptions, FieldList, JsonReadOptions, JsonValue, PartialMessage, PlainMessage } from "@bufbuild/protobuf";
 import { Message, proto3, Timestamp } from "@bufbuild/protobuf";
 
+/**
+ * Kind of the change for a block.
+ *
+ * @generated from enum com.seed.documents.v3alpha.BlockDiffKind
+ */
+export enum BlockDiffKind {
+  /**
+   * Invalid default value.
+   *
+   * @generated from enum value: BLOCK_DIFF_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Block only exists in the target version.
+   *
+   * @generated from enum value: BLOCK_DIFF_KIND_ADDED = 1;
+   */
+  ADDED = 1,
+
+  /**
+   * Block only exists in the source version.
+   *
+   * @generated from enum value: BLOCK_DIFF_KIND_REMOVED = 2;
+   */
+  REMOVED = 2,
+
+  /**
+   * Block exists in both versions, but its content or position has changed.
+   *
+   * @generated from enum value: BLOCK_DIFF_KIND_MODIFIED = 3;
+   */
+  MODIFIED = 3,
+}
+// Retrieve enum metadata with: proto3.getEnumType(BlockDiffKind)
+proto3.util.setEnumType(BlockDiffKind, "com.seed.documents.v3alpha.BlockDiffKind", [
+  { no: 0, name: "BLOCK_DIFF_KIND_UNSPECIFIED" },
+  { no: 1, name: "BLOCK_DIFF_KIND_ADDED" },
+  { no: 2, name: "BLOCK_DIFF_KIND_REMOVED" },
+  { no: 3, name: "BLOCK_DIFF_KIND_MODIFIED" },
+]);
+
 /**
  * Request for getting a single document.
  *
@@ -168,6 +210,22 @@ export class DeleteDocumentRequest extends Message<DeleteDocumentRequest> {
    */
   path = "";
 
+  /**
+   * Required. Name of the key to use for signing the deletion.
+   * Use the Daemon API to list and manage keys.
+   *
+   * @generated from field: string signing_key_name = 3;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. ID of the capability that allows signing key to write on behalf of the account
+   * for this particular path.
+   *
+   * @generated from field: string capability = 4;
+   */
+  capability = "";
+
   constructor(data?: PartialMessage<DeleteDocumentRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -178,6 +236,8 @@ export class DeleteDocumentRequest extends Message<DeleteDocumentRequest> {
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DeleteDocumentRequest {
@@ -588,6 +648,699 @@ export class DocumentChangeInfo extends Message<DocumentChangeInfo> {
   }
 }
 
+/**
+ * Request for exporting a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.ExportDocumentRequest
+ */
+export class ExportDocumentRequest extends Message<ExportDocumentRequest> {
+  /**
+   * Required. ID of the account where the document is located.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Optional. Exact version of the document to export.
+   * If empty, the latest version is used.
+   *
+   * @generated from field: string version = 3;
+   */
+  version = "";
+
+  /**
+   * Optional. Base URL of an IPFS gateway, e.g. https://ipfs.io/ipfs.
+   * If specified, ipfs:// links are rewritten to use this gateway.
+   * Otherwise ipfs:// links are left as is.
+   *
+   * @generated from field: string ipfs_gateway_url = 4;
+   */
+  ipfsGatewayUrl = "";
+
+  constructor(data?: PartialMessage<ExportDocumentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ExportDocumentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "ipfs_gateway_url", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportDocumentRequest {
+    return new ExportDocumentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportDocumentRequest {
+    return new ExportDocumentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportDocumentRequest {
+    return new ExportDocumentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportDocumentRequest | PlainMessage<ExportDocumentRequest> | undefined, b: ExportDocumentRequest | PlainMessage<ExportDocumentRequest> | undefined): boolean {
+    return proto3.util.equals(ExportDocumentRequest, a, b);
+  }
+}
+
+/**
+ * Response with the exported document.
+ *
+ * @generated from message com.seed.documents.v3alpha.ExportDocumentResponse
+ */
+export class ExportDocumentResponse extends Message<ExportDocumentResponse> {
+  /**
+   * Document content in CommonMark format.
+   * Document metadata is included as YAML front matter.
+   *
+   * @generated from field: string markdown = 1;
+   */
+  markdown = "";
+
+  /**
+   * Version of the exported document.
+   *
+   * @generated from field: string version = 2;
+   */
+  version = "";
+
+  constructor(data?: PartialMessage<ExportDocumentResponse>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ExportDocumentResponse";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "markdown", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ExportDocumentResponse {
+    return new ExportDocumentResponse().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ExportDocumentResponse {
+    return new ExportDocumentResponse().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ExportDocumentResponse {
+    return new ExportDocumentResponse().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ExportDocumentResponse | PlainMessage<ExportDocumentResponse> | undefined, b: ExportDocumentResponse | PlainMessage<ExportDocumentResponse> | undefined): boolean {
+    return proto3.util.equals(ExportDocumentResponse, a, b);
+  }
+}
+
+/**
+ * Request for importing Markdown into a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.ImportMarkdownRequest
+ */
+export class ImportMarkdownRequest extends Message<ImportMarkdownRequest> {
+  /**
+   * Required. The ID of the account where the document is located.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document to import into.
+   * If document doesn't exist it will be created.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Version of the document to apply changes to.
+   * Can be empty when creating a new document.
+   *
+   * @generated from field: string base_version = 3;
+   */
+  baseVersion = "";
+
+  /**
+   * Required. Markdown text to import. Content of the document is replaced with it.
+   * Optional YAML front matter is imported as document metadata,
+   * while metadata keys that are not present in the front matter are left untouched.
+   *
+   * @generated from field: string markdown = 4;
+   */
+  markdown = "";
+
+  /**
+   * Required. Name of the key to use for signing.
+   * Use the Daemon API to list and manage keys.
+   *
+   * @generated from field: string signing_key_name = 5;
+   */
+  signingKeyName = "";
+
+  /**
+   * Optional. ID of the capability that allows signing key to write on behalf of the account
+   * for this particular path.
+   *
+   * @generated from field: string capability = 6;
+   */
+  capability = "";
+
+  constructor(data?: PartialMessage<ImportMarkdownRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.ImportMarkdownRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "base_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "markdown", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "signing_key_name", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 6, name: "capability", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ImportMarkdownRequest {
+    return new ImportMarkdownRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): ImportMarkdownRequest {
+    return new ImportMarkdownRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): ImportMarkdownRequest {
+    return new ImportMarkdownRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: ImportMarkdownRequest | PlainMessage<ImportMarkdownRequest> | undefined, b: ImportMarkdownRequest | PlainMessage<ImportMarkdownRequest> | undefined): boolean {
+    return proto3.util.equals(ImportMarkdownRequest, a, b);
+  }
+}
+
+/**
+ * Request for diffing two versions of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.DiffDocumentRequest
+ */
+export class DiffDocumentRequest extends Message<DiffDocumentRequest> {
+  /**
+   * Required. ID of the account where the document is located.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  /**
+   * Required. Version of the document to diff from.
+   *
+   * @generated from field: string from_version = 3;
+   */
+  fromVersion = "";
+
+  /**
+   * Optional. Version of the document to diff to.
+   * If empty, the latest version is used.
+   *
+   * @generated from field: string to_version = 4;
+   */
+  toVersion = "";
+
+  constructor(data?: PartialMessage<DiffDocumentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DiffDocumentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "from_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "to_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiffDocumentRequest {
+    return new DiffDocumentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DiffDocumentRequest {
+    return new DiffDocumentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DiffDocumentRequest {
+    return new DiffDocumentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DiffDocumentRequest | PlainMessage<DiffDocumentRequest> | undefined, b: DiffDocumentRequest | PlainMessage<DiffDocumentRequest> | undefined): boolean {
+    return proto3.util.equals(DiffDocumentRequest, a, b);
+  }
+}
+
+/**
+ * Request for watching updates of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.WatchDocumentRequest
+ */
+export class WatchDocumentRequest extends Message<WatchDocumentRequest> {
+  /**
+   * Required. ID of the account where the document is located.
+   *
+   * @generated from field: string account = 1;
+   */
+  account = "";
+
+  /**
+   * Required. Path of the document.
+   * Empty string means root document.
+   *
+   * @generated from field: string path = 2;
+   */
+  path = "";
+
+  constructor(data?: PartialMessage<WatchDocumentRequest>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.WatchDocumentRequest";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "path", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): WatchDocumentRequest {
+    return new WatchDocumentRequest().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): WatchDocumentRequest {
+    return new WatchDocumentRequest().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): WatchDocumentRequest {
+    return new WatchDocumentRequest().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: WatchDocumentRequest | PlainMessage<WatchDocumentRequest> | undefined, b: WatchDocumentRequest | PlainMessage<WatchDocumentRequest> | undefined): boolean {
+    return proto3.util.equals(WatchDocumentRequest, a, b);
+  }
+}
+
+/**
+ * Update of a watched document.
+ *
+ * @generated from message com.seed.documents.v3alpha.DocumentUpdate
+ */
+export class DocumentUpdate extends Message<DocumentUpdate> {
+  /**
+   * New version of the document. Empty if the document was deleted.
+   *
+   * @generated from field: string version = 1;
+   */
+  version = "";
+
+  /**
+   * Changes since the previously emitted version.
+   * For the first update, and the first one after a deletion,
+   * the changes are computed against an empty document.
+   * For deletions the changes remove all the content.
+   *
+   * @generated from field: com.seed.documents.v3alpha.DocumentDiff diff = 2;
+   */
+  diff?: DocumentDiff;
+
+  /**
+   * Last time the document was updated.
+   *
+   * @generated from field: google.protobuf.Timestamp update_time = 3;
+   */
+  updateTime?: Timestamp;
+
+  /**
+   * Whether the document was deleted.
+   *
+   * @generated from field: bool deleted = 4;
+   */
+  deleted = false;
+
+  constructor(data?: PartialMessage<DocumentUpdate>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DocumentUpdate";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "diff", kind: "message", T: DocumentDiff },
+    { no: 3, name: "update_time", kind: "message", T: Timestamp },
+    { no: 4, name: "deleted", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DocumentUpdate {
+    return new DocumentUpdate().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DocumentUpdate {
+    return new DocumentUpdate().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DocumentUpdate {
+    return new DocumentUpdate().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DocumentUpdate | PlainMessage<DocumentUpdate> | undefined, b: DocumentUpdate | PlainMessage<DocumentUpdate> | undefined): boolean {
+    return proto3.util.equals(DocumentUpdate, a, b);
+  }
+}
+
+/**
+ * Differences between two versions of a document.
+ *
+ * @generated from message com.seed.documents.v3alpha.DocumentDiff
+ */
+export class DocumentDiff extends Message<DocumentDiff> {
+  /**
+   * Version the diff is computed from.
+   *
+   * @generated from field: string from_version = 1;
+   */
+  fromVersion = "";
+
+  /**
+   * Version the diff is computed to.
+   *
+   * @generated from field: string to_version = 2;
+   */
+  toVersion = "";
+
+  /**
+   * Changed metadata keys, sorted by key.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.MetadataDiff metadata = 3;
+   */
+  metadata: MetadataDiff[] = [];
+
+  /**
+   * Changed blocks. Blocks that exist in the target version come first in the document order,
+   * followed by the removed blocks in the order they had in the source version.
+   *
+   * @generated from field: repeated com.seed.documents.v3alpha.BlockDiff blocks = 4;
+   */
+  blocks: BlockDiff[] = [];
+
+  constructor(data?: PartialMessage<DocumentDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.DocumentDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "from_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "to_version", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "metadata", kind: "message", T: MetadataDiff, repeated: true },
+    { no: 4, name: "blocks", kind: "message", T: BlockDiff, repeated: true },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DocumentDiff {
+    return new DocumentDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): DocumentDiff {
+    return new DocumentDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): DocumentDiff {
+    return new DocumentDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: DocumentDiff | PlainMessage<DocumentDiff> | undefined, b: DocumentDiff | PlainMessage<DocumentDiff> | undefined): boolean {
+    return proto3.util.equals(DocumentDiff, a, b);
+  }
+}
+
+/**
+ * Change of a single metadata key.
+ *
+ * @generated from message com.seed.documents.v3alpha.MetadataDiff
+ */
+export class MetadataDiff extends Message<MetadataDiff> {
+  /**
+   * Metadata key.
+   *
+   * @generated from field: string key = 1;
+   */
+  key = "";
+
+  /**
+   * Value in the source version. Empty if the key was added.
+   *
+   * @generated from field: string old_value = 2;
+   */
+  oldValue = "";
+
+  /**
+   * Value in the target version. Empty if the key was removed.
+   *
+   * @generated from field: string new_value = 3;
+   */
+  newValue = "";
+
+  constructor(data?: PartialMessage<MetadataDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.MetadataDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "key", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "old_value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 3, name: "new_value", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): MetadataDiff {
+    return new MetadataDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): MetadataDiff {
+    return new MetadataDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): MetadataDiff {
+    return new MetadataDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: MetadataDiff | PlainMessage<MetadataDiff> | undefined, b: MetadataDiff | PlainMessage<MetadataDiff> | undefined): boolean {
+    return proto3.util.equals(MetadataDiff, a, b);
+  }
+}
+
+/**
+ * Change of a single block.
+ *
+ * @generated from message com.seed.documents.v3alpha.BlockDiff
+ */
+export class BlockDiff extends Message<BlockDiff> {
+  /**
+   * ID of the block.
+   *
+   * @generated from field: string block_id = 1;
+   */
+  blockId = "";
+
+  /**
+   * Kind of the change.
+   *
+   * @generated from field: com.seed.documents.v3alpha.BlockDiffKind kind = 2;
+   */
+  kind = BlockDiffKind.UNSPECIFIED;
+
+  /**
+   * State of the block in the source version. Empty for added blocks.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Block old_block = 3;
+   */
+  oldBlock?: Block;
+
+  /**
+   * State of the block in the target version. Empty for removed blocks.
+   *
+   * @generated from field: com.seed.documents.v3alpha.Block new_block = 4;
+   */
+  newBlock?: Block;
+
+  /**
+   * Position of the block in the source version. Empty for added blocks.
+   *
+   * @generated from field: com.seed.documents.v3alpha.BlockPosition old_position = 5;
+   */
+  oldPosition?: BlockPosition;
+
+  /**
+   * Position of the block in the target version. Empty for removed blocks.
+   *
+   * @generated from field: com.seed.documents.v3alpha.BlockPosition new_position = 6;
+   */
+  newPosition?: BlockPosition;
+
+  /**
+   * Whether parent or left sibling of the block has changed.
+   *
+   * @generated from field: bool moved = 7;
+   */
+  moved = false;
+
+  /**
+   * Whether block type has changed.
+   *
+   * @generated from field: bool type_changed = 8;
+   */
+  typeChanged = false;
+
+  /**
+   * Whether block text has changed.
+   *
+   * @generated from field: bool text_changed = 9;
+   */
+  textChanged = false;
+
+  /**
+   * Whether block ref has changed.
+   *
+   * @generated from field: bool ref_changed = 10;
+   */
+  refChanged = false;
+
+  /**
+   * Keys of the block attributes that were added, removed or changed, sorted.
+   *
+   * @generated from field: repeated string changed_attributes = 11;
+   */
+  changedAttributes: string[] = [];
+
+  /**
+   * Whether block annotations have changed.
+   *
+   * @generated from field: bool annotations_changed = 12;
+   */
+  annotationsChanged = false;
+
+  constructor(data?: PartialMessage<BlockDiff>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.BlockDiff";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "kind", kind: "enum", T: proto3.getEnumType(BlockDiffKind) },
+    { no: 3, name: "old_block", kind: "message", T: Block },
+    { no: 4, name: "new_block", kind: "message", T: Block },
+    { no: 5, name: "old_position", kind: "message", T: BlockPosition },
+    { no: 6, name: "new_position", kind: "message", T: BlockPosition },
+    { no: 7, name: "moved", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 8, name: "type_changed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 9, name: "text_changed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 10, name: "ref_changed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+    { no: 11, name: "changed_attributes", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 12, name: "annotations_changed", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BlockDiff {
+    return new BlockDiff().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BlockDiff {
+    return new BlockDiff().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BlockDiff {
+    return new BlockDiff().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BlockDiff | PlainMessage<BlockDiff> | undefined, b: BlockDiff | PlainMessage<BlockDiff> | undefined): boolean {
+    return proto3.util.equals(BlockDiff, a, b);
+  }
+}
+
+/**
+ * Position of a block within the document tree.
+ *
+ * @generated from message com.seed.documents.v3alpha.BlockPosition
+ */
+export class BlockPosition extends Message<BlockPosition> {
+  /**
+   * ID of the parent block. Empty for top-level blocks.
+   *
+   * @generated from field: string parent = 1;
+   */
+  parent = "";
+
+  /**
+   * ID of the left sibling block. Empty for the first child.
+   *
+   * @generated from field: string left_sibling = 2;
+   */
+  leftSibling = "";
+
+  constructor(data?: PartialMessage<BlockPosition>) {
+    super();
+    proto3.util.initPartial(data, this);
+  }
+
+  static readonly runtime: typeof proto3 = proto3;
+  static readonly typeName = "com.seed.documents.v3alpha.BlockPosition";
+  static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "parent", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "left_sibling", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+  ]);
+
+  static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): BlockPosition {
+    return new BlockPosition().fromBinary(bytes, options);
+  }
+
+  static fromJson(jsonValue: JsonValue, options?: Partial<JsonReadOptions>): BlockPosition {
+    return new BlockPosition().fromJson(jsonValue, options);
+  }
+
+  static fromJsonString(jsonString: string, options?: Partial<JsonReadOptions>): BlockPosition {
+    return new BlockPosition().fromJsonString(jsonString, options);
+  }
+
+  static equals(a: BlockPosition | PlainMessage<BlockPosition> | undefined, b: BlockPosition | PlainMessage<BlockPosition> | undefined): boolean {
+    return proto3.util.equals(BlockPosition, a, b);
+  }
+}
+
 /**
  * Basic data about a document that is returned in list responses.
  * Content is omitted for efficiency reasons.
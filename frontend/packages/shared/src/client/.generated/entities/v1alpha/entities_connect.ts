@@ -48,9 +48,9 @@ export const Entities = {
       kind: MethodKind.Unary,
     },
     /**
-     * Finds the list of local entities whose titles match the input string.
-     * A fuzzy search is performed among documents, groups and accounts.
-     * For groups and documents, we match the title, while we match alias in accounts.
+     * Finds the content of local documents matching the input query.
+     * A full-text search is performed over the text of the blocks and the metadata
+     * of the latest version of each document. Results are ordered by relevance.
      *
      * @generated from rpc com.seed.entities.v1alpha.Entities.SearchEntities
      */
@@ -112,6 +112,8 @@ export class DiscoverEntityRequest extends Message<DiscoverEntityRequest> {
 
   /**
    * Optional. Version of the entity to discover.
+   * If specified, the call blocks until all the changes of this version are available locally,
+   * or until the discovery deadline.
    *
    * @generated from field: string version = 2;
    */
@@ -149,12 +151,17 @@ export class DiscoverEntityRequest extends Message<DiscoverEntityRequest> {
 /**
  * Response to discover an entity.
  *
- * TODO(burdiyan): add summary of the discovery process.
- * Or maybe even make this call streaming?
- *
  * @generated from message com.seed.entities.v1alpha.DiscoverEntityResponse
  */
 export class DiscoverEntityResponse extends Message<DiscoverEntityResponse> {
+  /**
+   * IDs of the peers we've synced the entity with during the discovery.
+   * Empty if the requested version was already available locally.
+   *
+   * @generated from field: repeated string peers = 1;
+   */
+  peers: string[] = [];
+
   constructor(data?: PartialMessage<DiscoverEntityResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -163,6 +170,7 @@ export class DiscoverEntityResponse extends Message<DiscoverEntityResponse> {
   static readonly runtime: typeof proto3 = proto3;
   static readonly typeName = "com.seed.entities.v1alpha.DiscoverEntityResponse";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
+    { no: 1, name: "peers", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): DiscoverEntityResponse {
@@ -459,6 +467,23 @@ export class Entity extends Message<Entity> {
    */
   owner = "";
 
+  /**
+   * Snippet of the content matching the search query, in plain text.
+   * Only set in search results.
+   *
+   * @generated from field: string content = 4;
+   */
+  content = "";
+
+  /**
+   * ID of the block where the search query matched.
+   * Empty if the match was found in the metadata of the entity.
+   * Only set in search results.
+   *
+   * @generated from field: string block_id = 5;
+   */
+  blockId = "";
+
   constructor(data?: PartialMessage<Entity>) {
     super();
     proto3.util.initPartial(data, this);
@@ -470,6 +495,8 @@ export class Entity extends Message<Entity> {
     { no: 1, name: "id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 2, name: "title", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 3, name: "owner", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "content", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "block_id", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): Entity {
@@ -555,20 +582,48 @@ export class DeletedEntity extends Message<DeletedEntity> {
 }
 
 /**
- * Request to
+ * Request to search entities.
  *
  * @generated from message com.seed.entities.v1alpha.SearchEntitiesRequest
  */
 export class SearchEntitiesRequest extends Message<SearchEntitiesRequest> {
   /**
-   * Query to find. Since we use
-   * Fuzzy search, a single query may return multiple
-   * entities.
+   * Required. Query to find. Each word of the query must be present in the matching content.
+   * The last word is matched as a prefix, to support search as you type.
    *
    * @generated from field: string query = 1;
    */
   query = "";
 
+  /**
+   * Optional. Number of results per page. Default is defined by the server.
+   *
+   * @generated from field: int32 page_size = 2;
+   */
+  pageSize = 0;
+
+  /**
+   * Optional. Value from next_page_token obtained from a previous response.
+   *
+   * @generated from field: string page_token = 3;
+   */
+  pageToken = "";
+
+  /**
+   * Optional. Only return results from the documents of this account.
+   *
+   * @generated from field: string account = 4;
+   */
+  account = "";
+
+  /**
+   * Optional. Only return results from the documents whose path starts with this prefix.
+   * Requires account to be set.
+   *
+   * @generated from field: string path_prefix = 5;
+   */
+  pathPrefix = "";
+
   constructor(data?: PartialMessage<SearchEntitiesRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -578,6 +633,10 @@ export class SearchEntitiesRequest extends Message<SearchEntitiesRequest> {
   static readonly typeName = "com.seed.entities.v1alpha.SearchEntitiesRequest";
   static readonly fields: FieldList = proto3.util.newFieldList(() => [
     { no: 1, name: "query", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 2, name: "page_size", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
+    { no: 3, name: "page_token", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 4, name: "account", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 5, name: "path_prefix", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): SearchEntitiesRequest {
@@ -604,7 +663,9 @@ export class SearchEntitiesRequest extends Message<SearchEntitiesRequest> {
  */
 export class SearchEntitiesResponse extends Message<SearchEntitiesResponse> {
   /**
-   * Entities matching the input title
+   * Entities matching the query, ordered by relevance.
+   * The same entity may appear multiple times when the query matches
+   * different blocks of the same document.
    *
    * @generated from field: repeated com.seed.entities.v1alpha.Entity entities = 1;
    */